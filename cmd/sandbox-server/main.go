@@ -2,7 +2,6 @@ package main
 
 import (
 	"OJ-API/config"
-	"OJ-API/database"
 	"OJ-API/gitclone"
 	"OJ-API/models"
 	pb "OJ-API/proto"
@@ -15,7 +14,10 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"runtime/debug"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -28,6 +30,15 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// version 沙箱服務器版本，建置時以 -ldflags "-X main.version=..." 指定，未指定時使用 VCS 資訊
+var version = ""
+
+// fleetState 記錄管理員透過調度器下達的排空與移除指令
+var fleetState = struct {
+	draining atomic.Bool
+	shutdown chan string
+}{shutdown: make(chan string, 1)}
+
 func main() {
 	// 初始化日誌
 	utils.InitLog()
@@ -38,11 +49,6 @@ func main() {
 		utils.Info("No .env.local file found")
 	}
 
-	// 初始化數據庫連接
-	if err := database.Connect(); err != nil {
-		utils.Fatalf("Failed to connect to database: %v", err)
-	}
-
 	// 創建沙箱實例
 	sandboxCount := runtime.NumCPU()
 	if countStr := config.Config("SANDBOX_COUNT"); countStr != "" {
@@ -51,7 +57,16 @@ func main() {
 		}
 	}
 
-	sandboxInstance := sandbox.NewSandbox(sandboxCount)
+	runner, err := sandbox.NewRunner(config.GetSandboxRunner(), config.GetIsolatePath(), config.GetSandboxBoxPath())
+	if err != nil {
+		utils.Fatalf("Failed to create sandbox runner: %v", err)
+	}
+	utils.Infof("Using %s sandbox runner", runner.Name())
+
+	sandboxInstance, err := sandbox.NewSandbox(sandboxCount, runner)
+	if err != nil {
+		utils.Fatalf("Failed to initialize sandbox: %v", err)
+	}
 	defer sandboxInstance.Cleanup()
 
 	// 啟動工作循環
@@ -108,8 +123,12 @@ func main() {
 		}
 	}()
 
-	<-sigChan
-	utils.Info("Shutting down sandbox server...")
+	select {
+	case <-sigChan:
+		utils.Info("Shutting down sandbox server...")
+	case reason := <-fleetState.shutdown:
+		utils.Infof("Shutting down sandbox server: %s", reason)
+	}
 	cancel() // 停止工作循環
 
 	// 等待所有任務完成，但設置超時限制
@@ -205,6 +224,18 @@ func connectToScheduler(schedulerAddress, sandboxID string) (*grpc.ClientConn, e
 	return conn, nil
 }
 
+// streamSender 序列化對串流的發送，gRPC 串流不允許多個 goroutine 同時 Send
+type streamSender struct {
+	mu     sync.Mutex
+	stream pb.SchedulerService_SandboxStreamClient
+}
+
+func (s *streamSender) Send(msg *pb.SandboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(msg)
+}
+
 // handleConnection 處理與調度器的連接
 func handleConnection(ctx context.Context, conn *grpc.ClientConn, sandboxID string, sandboxInstance *sandbox.Sandbox) error {
 	schedulerClient := pb.NewSchedulerServiceClient(conn)
@@ -214,19 +245,23 @@ func handleConnection(ctx context.Context, conn *grpc.ClientConn, sandboxID stri
 	if err != nil {
 		return fmt.Errorf("failed to create stream: %v", err)
 	}
+	sender := &streamSender{stream: stream}
 
 	// 發送連接請求
 	connectMsg := &pb.SandboxMessage{
 		SandboxId: sandboxID,
 		MessageType: &pb.SandboxMessage_Connect{
 			Connect: &pb.SandboxConnectRequest{
-				SandboxId: sandboxID,
-				Capacity:  int32(sandboxInstance.AvailableCount() + sandboxInstance.ProcessingCount()),
+				SandboxId:  sandboxID,
+				Capacity:   int32(sandboxInstance.AvailableCount() + sandboxInstance.ProcessingCount()),
+				Labels:     config.GetSandboxLabels(),
+				Toolchains: config.GetSandboxToolchains(),
+				Version:    sandboxVersion(),
 			},
 		},
 	}
 
-	if err := stream.Send(connectMsg); err != nil {
+	if err := sender.Send(connectMsg); err != nil {
 		return fmt.Errorf("failed to send connect message: %v", err)
 	}
 
@@ -237,14 +272,14 @@ func handleConnection(ctx context.Context, conn *grpc.ClientConn, sandboxID stri
 	// 啟動消息處理 goroutine
 	messageDone := make(chan error, 1)
 	go func() {
-		err := handleSchedulerMessages(stream, sandboxInstance)
+		err := handleSchedulerMessages(stream, sender, sandboxInstance)
 		messageDone <- err
 	}()
 
 	// 啟動狀態更新 goroutine
 	statusDone := make(chan error, 1)
 	go func() {
-		err := sendStatusUpdates(streamCtx, stream, sandboxID, sandboxInstance)
+		err := sendStatusUpdates(streamCtx, sender, sandboxID, sandboxInstance)
 		statusDone <- err
 	}()
 
@@ -264,7 +299,7 @@ func handleConnection(ctx context.Context, conn *grpc.ClientConn, sandboxID stri
 }
 
 // handleSchedulerMessages 處理來自調度器的消息
-func handleSchedulerMessages(stream pb.SchedulerService_SandboxStreamClient, sandboxInstance *sandbox.Sandbox) error {
+func handleSchedulerMessages(stream pb.SchedulerService_SandboxStreamClient, sender *streamSender, sandboxInstance *sandbox.Sandbox) error {
 	for {
 		msg, err := stream.Recv()
 		if err == io.EOF {
@@ -282,7 +317,7 @@ func handleSchedulerMessages(stream pb.SchedulerService_SandboxStreamClient, san
 			if resp.Success {
 				utils.Debugf("Successfully connected to scheduler: %s", resp.Message)
 				// 連接成功後立即發送初始狀態
-				if err := sendCurrentStatus(stream, msg.SandboxId, sandboxInstance); err != nil {
+				if err := sendCurrentStatus(sender, msg.SandboxId, sandboxInstance); err != nil {
 					utils.Debugf("Failed to send initial status: %v", err)
 				}
 			} else {
@@ -294,41 +329,132 @@ func handleSchedulerMessages(stream pb.SchedulerService_SandboxStreamClient, san
 			jobReq := msgType.JobRequest
 			utils.Debugf("Received job request for repo: %s, commit: %s", jobReq.GitFullName, jobReq.GitAfterHash)
 
+			// 排空中不再接收任務，退回由調度器派發給其他沙箱
+			if fleetState.draining.Load() {
+				sandboxInstance.ReportJob(&sandbox.JobReport{
+					JobID:   jobReq.JobId,
+					Type:    sandbox.JobRejected,
+					Message: "sandbox is draining",
+				})
+				continue
+			}
+
+			// 記錄任務，狀態更新時會向調度器續約
+			sandboxInstance.TrackJob(jobReq.JobId)
+
 			// 異步處理任務
 			go func() {
-				// 發送任務響應
 				responseMsg, err := AddJob(sandboxInstance, context.Background(), jobReq)
 				if err != nil {
 					utils.Errorf("Failed to add job: %v", err)
+					// 退回任務，由調度器重新派發
+					sandboxInstance.ReportJob(&sandbox.JobReport{
+						JobID:   jobReq.JobId,
+						Type:    sandbox.JobRejected,
+						Message: err.Error(),
+					})
 					return
 				}
 
-				// 將 AddJobResponse 包裝在 SandboxMessage 中
+				// 發送任務確認
 				sandboxMsg := &pb.SandboxMessage{
 					SandboxId: msg.SandboxId,
-					MessageType: &pb.SandboxMessage_JobResponse{
-						JobResponse: responseMsg,
+					MessageType: &pb.SandboxMessage_JobAck{
+						JobAck: &pb.JobAck{
+							JobId:    jobReq.JobId,
+							Accepted: true,
+							Message:  responseMsg.Message,
+						},
 					},
 				}
 
-				if err := stream.Send(sandboxMsg); err != nil {
-					utils.Debugf("Failed to send job response: %v", err)
+				if err := sender.Send(sandboxMsg); err != nil {
+					utils.Debugf("Failed to send job ack: %v", err)
 				} else {
-					utils.Debugf("Successfully sent job response")
+					utils.Debugf("Successfully sent job ack")
 				}
 			}()
 
+		case *pb.SchedulerMessage_CancelJob:
+			// 處理任務取消，評測結果以 CANCELLED 回報
+			cancelReq := msgType.CancelJob
+			if sandboxInstance.CancelJob(cancelReq.JobId) {
+				utils.Infof("Cancelling job %d: %s", cancelReq.JobId, cancelReq.Reason)
+			} else {
+				utils.Debugf("Ignoring cancellation of unknown job %d", cancelReq.JobId)
+			}
+
 		case *pb.SchedulerMessage_StatusRequest:
 			// 處理狀態請求 - 立即發送狀態
-			if err := sendCurrentStatus(stream, msg.SandboxId, sandboxInstance); err != nil {
+			if err := sendCurrentStatus(sender, msg.SandboxId, sandboxInstance); err != nil {
 				utils.Debugf("Failed to send status response: %v", err)
 			}
+
+		case *pb.SchedulerMessage_Cordon:
+			// 調度器已停止或恢復派發新任務，沙箱不需處理
+			utils.Infof("Sandbox cordoned=%t by scheduler: %s", msgType.Cordon.Cordoned, msgType.Cordon.Reason)
+
+		case *pb.SchedulerMessage_Drain:
+			// 完成目前的任務並回報結果後結束
+			if fleetState.draining.CompareAndSwap(false, true) {
+				utils.Infof("Draining sandbox: %s", msgType.Drain.Reason)
+				go waitForDrain(sandboxInstance, msgType.Drain.Reason)
+			}
+
+		case *pb.SchedulerMessage_Evict:
+			// 中止所有任務並立即結束，調度器已將任務重新派發
+			utils.Warnf("Sandbox evicted by scheduler: %s", msgType.Evict.Reason)
+			fleetState.draining.Store(true)
+			for _, jobID := range sandboxInstance.RunningJobIDs() {
+				sandboxInstance.CancelJob(jobID)
+			}
+			requestShutdown("evicted: " + msgType.Evict.Reason)
+			return nil
+		}
+	}
+}
+
+// waitForDrain 等待所有任務完成且結果都已回報後結束沙箱服務器
+func waitForDrain(sandboxInstance *sandbox.Sandbox, reason string) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if len(sandboxInstance.RunningJobIDs()) == 0 &&
+			sandboxInstance.WaitingCount() == 0 &&
+			sandboxInstance.ProcessingCount() == 0 &&
+			sandboxInstance.PendingReportCount() == 0 {
+			requestShutdown("drained: " + reason)
+			return
+		}
+	}
+}
+
+// requestShutdown 通知主程式結束沙箱服務器
+func requestShutdown(reason string) {
+	select {
+	case fleetState.shutdown <- reason:
+	default:
+	}
+}
+
+// sandboxVersion 回報給調度器的沙箱服務器版本
+func sandboxVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && len(setting.Value) >= 12 {
+				return setting.Value[:12]
+			}
 		}
 	}
+	return "dev"
 }
 
 // sendStatusUpdates 定期發送狀態更新
-func sendStatusUpdates(ctx context.Context, stream pb.SchedulerService_SandboxStreamClient, sandboxID string, sandboxInstance *sandbox.Sandbox) error {
+func sendStatusUpdates(ctx context.Context, sender *streamSender, sandboxID string, sandboxInstance *sandbox.Sandbox) error {
 	ticker := time.NewTicker(300 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -337,13 +463,83 @@ func sendStatusUpdates(ctx context.Context, stream pb.SchedulerService_SandboxSt
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if err := sendCurrentStatus(stream, sandboxID, sandboxInstance); err != nil {
+			if err := sendJobReports(sender, sandboxID, sandboxInstance); err != nil {
+				return err
+			}
+			if err := sendCurrentStatus(sender, sandboxID, sandboxInstance); err != nil {
 				return err
 			}
 		}
 	}
 }
 
+// sendJobReports 發送待回報的任務結果，發送失敗的回報會在重新連線後再次發送
+func sendJobReports(sender *streamSender, sandboxID string, sandboxInstance *sandbox.Sandbox) error {
+	for report := sandboxInstance.NextReport(); report != nil; report = sandboxInstance.NextReport() {
+		msg := &pb.SandboxMessage{SandboxId: sandboxID}
+		switch report.Type {
+		case sandbox.JobStarted, sandbox.JobProgressed:
+			progress := &pb.JobProgress{
+				JobId:   report.JobID,
+				Message: report.Message,
+				Stage:   report.Stage,
+				Target:  report.Target,
+				Status:  report.Status,
+			}
+			if !report.StartedAt.IsZero() {
+				progress.StartedAt = report.StartedAt.UnixMilli()
+			}
+			msg.MessageType = &pb.SandboxMessage_JobProgress{
+				JobProgress: progress,
+			}
+		case sandbox.JobFinished:
+			result := &pb.JobResult{
+				JobId:         report.JobID,
+				Success:       report.Success,
+				Score:         report.Score,
+				Message:       report.Message,
+				StartedAt:     report.StartedAt.UnixMilli(),
+				FinishedAt:    report.FinishedAt.UnixMilli(),
+				CompileTimeMs: report.CompileTime.Milliseconds(),
+				ExecuteTimeMs: report.ExecuteTime.Milliseconds(),
+				ScoreTimeMs:   report.ScoreTime.Milliseconds(),
+				Verdict:       string(report.Verdict),
+			}
+			for _, record := range report.Executions {
+				result.Executions = append(result.Executions, &pb.ExecutionRecord{
+					Target:     record.Target,
+					Verdict:    string(record.Verdict),
+					TimeMs:     int64(record.Time * 1000),
+					WallTimeMs: int64(record.TimeWall * 1000),
+					MemoryKb:   int64(record.Memory),
+					ExitCode:   int32(record.ExitCode),
+					ExitSignal: int32(record.ExitSig),
+					Status:     record.Status,
+					Message:    record.Message,
+				})
+			}
+			msg.MessageType = &pb.SandboxMessage_JobResult{
+				JobResult: result,
+			}
+		default:
+			msg.MessageType = &pb.SandboxMessage_JobAck{
+				JobAck: &pb.JobAck{
+					JobId:    report.JobID,
+					Accepted: false,
+					Message:  report.Message,
+				},
+			}
+		}
+
+		if err := sender.Send(msg); err != nil {
+			sandboxInstance.RequeueReport(report)
+			return err
+		}
+		utils.Debugf("Sent report for job %d (type: %d)", report.JobID, report.Type)
+	}
+	return nil
+}
+
 var lastStatus = struct {
 	lastAvailable  int32
 	lastWaiting    int32
@@ -353,7 +549,7 @@ var lastStatus = struct {
 }{}
 
 // sendCurrentStatus 發送當前狀態
-func sendCurrentStatus(stream pb.SchedulerService_SandboxStreamClient, sandboxID string, sandboxInstance *sandbox.Sandbox) error {
+func sendCurrentStatus(sender *streamSender, sandboxID string, sandboxInstance *sandbox.Sandbox) error {
 	available := int32(sandboxInstance.AvailableCount())
 	waiting := int32(sandboxInstance.WaitingCount())
 	processing := int32(sandboxInstance.ProcessingCount())
@@ -386,6 +582,7 @@ func sendCurrentStatus(stream pb.SchedulerService_SandboxStreamClient, sandboxID
 		WaitingCount:    waiting,
 		ProcessingCount: processing,
 		TotalCount:      total,
+		RunningJobIds:   sandboxInstance.RunningJobIDs(),
 	}
 
 	statusMsg := &pb.SandboxMessage{
@@ -395,7 +592,7 @@ func sendCurrentStatus(stream pb.SchedulerService_SandboxStreamClient, sandboxID
 		},
 	}
 
-	if err := stream.Send(statusMsg); err != nil {
+	if err := sender.Send(statusMsg); err != nil {
 		utils.Debugf("Failed to send status update: %v", err)
 		return err
 	} else {
@@ -415,10 +612,55 @@ func sendCurrentStatus(stream pb.SchedulerService_SandboxStreamClient, sandboxID
 func AddJob(sandboxInstance *sandbox.Sandbox, ctx context.Context, req *pb.AddJobRequest) (*pb.AddJobResponse, error) {
 	sandboxInstance.SubtractAvailableCount()
 	defer sandboxInstance.AddAvailableCount()
-	// 從數據庫獲取完整的 UserQuestionTable 模型，包含關聯的 UQR 和 Question
-	var uqr models.UserQuestionTable
-	if err := database.DBConn.Preload("UQR.Question").First(&uqr, req.UserQuestionTableId).Error; err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get user question table: %v", err)
+	// 評測設定由調度器提供，沙箱不需連線資料庫
+	spec := req.GetSpec()
+	if spec == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "missing judge spec")
+	}
+	script := models.QuestionTestScript{
+		CompileScript:  spec.CompileScript,
+		ExecuteScript:  spec.ExecuteScript,
+		ScoreScript:    spec.ScoreScript,
+		ScoreMap:       spec.ScoreMap,
+		Memory:         uint(spec.Memory),
+		StackMemory:    uint(spec.StackMemory),
+		Time:           uint(spec.Time),
+		WallTime:       uint(spec.WallTime),
+		FileSize:       uint(spec.FileSize),
+		Processes:      uint(spec.Processes),
+		OpenFiles:      uint(spec.OpenFiles),
+		JudgeMode:      models.JudgeMode(spec.JudgeMode),
+		Checker:        models.CheckerType(spec.Checker),
+		FloatTolerance: spec.FloatTolerance,
+		CheckerScript:  spec.CheckerScript,
+		JudgeTimeout:   uint(spec.JudgeTimeout),
+	}
+	// 舊版調度器未提供時為 0，即不限制
+	if l := spec.GetCompileLimits(); l != nil {
+		script.CompileTime = uint(l.Time)
+		script.CompileWallTime = uint(l.WallTime)
+		script.CompileMemory = uint(l.Memory)
+		script.CompileProcesses = uint(l.Processes)
+		script.CompileFileSize = uint(l.FileSize)
+	}
+	if l := spec.GetScoreLimits(); l != nil {
+		script.ScoreTime = uint(l.Time)
+		script.ScoreWallTime = uint(l.WallTime)
+		script.ScoreMemory = uint(l.Memory)
+		script.ScoreProcesses = uint(l.Processes)
+		script.ScoreFileSize = uint(l.FileSize)
+	}
+	testCases := make([]models.QuestionTestCase, 0, len(spec.TestCases))
+	for i, tc := range spec.TestCases {
+		testCases = append(testCases, models.QuestionTestCase{
+			Ordinal: i,
+			Name:    tc.Name,
+			Input:   tc.Input,
+			Output:  tc.Output,
+			Weight:  tc.Weight,
+			Time:    uint(tc.Time),
+			Memory:  uint(tc.Memory),
+		})
 	}
 
 	codePath, err := gitclone.CloneRepository(req.GitFullName, req.GitRepoUrl, req.GitAfterHash, req.GitUsername, req.GitToken)
@@ -428,7 +670,7 @@ func AddJob(sandboxInstance *sandbox.Sandbox, ctx context.Context, req *pb.AddJo
 	}
 
 	// 添加任務到隊列
-	sandboxInstance.ReserveJob(req.ParentGitFullName, []byte(codePath), uqr)
+	sandboxInstance.ReserveJob(req.JobId, req.ParentGitFullName, []byte(codePath), script, testCases)
 
 	return &pb.AddJobResponse{
 		Success: true,
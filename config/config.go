@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -70,6 +71,44 @@ func GetIsolatePath() string {
 	return isolatePath
 }
 
+// GetSandboxRunner returns the runner backend used by the sandbox: isolate, nsjail or process
+func GetSandboxRunner() string {
+	runner := Config("SANDBOX_RUNNER")
+	if runner == "" {
+		runner = "isolate" // Default runner if not provided
+	}
+	return runner
+}
+
+// GetSandboxBoxPath returns the folder holding the boxes of the nsjail and process runners
+func GetSandboxBoxPath() string {
+	boxPath := Config("SANDBOX_BOX_PATH")
+	if boxPath == "" {
+		boxPath = "/var/local/lib/oj-sandbox" // Default path if not provided
+	}
+	return boxPath
+}
+
+// GetSandboxLabels returns the comma separated labels the sandbox advertises to the scheduler
+func GetSandboxLabels() []string {
+	return splitList(Config("SANDBOX_LABELS"))
+}
+
+// GetSandboxToolchains returns the comma separated toolchains installed on the sandbox
+func GetSandboxToolchains() []string {
+	return splitList(Config("SANDBOX_TOOLCHAINS"))
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 // GetGiteaOAuthConfig returns the Gitea OAuth configuration
 func GetGiteaOAuthConfig() struct {
 	URL          string
@@ -0,0 +1,54 @@
+// Package dbtest provides a PostgreSQL database to tests of code that relies on
+// PostgreSQL specific SQL.
+package dbtest
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"OJ-API/database"
+)
+
+// Open connects to the database given by TEST_DATABASE_DSN, migrates the models
+// and returns a transaction that is rolled back when the test ends. DBConn points
+// to the transaction until then.
+//
+// The test is skipped if TEST_DATABASE_DSN is not set.
+func Open(t *testing.T, models ...interface{}) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	tx := db.Begin()
+	conn := database.DBConn
+	database.DBConn = tx
+	t.Cleanup(func() {
+		database.DBConn = conn
+		tx.Rollback()
+	})
+
+	if err := tx.AutoMigrate(models...); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return tx
+}
+
+// Create inserts the values in order and fails the test if any of them cannot be created.
+func Create(t *testing.T, db *gorm.DB, values ...interface{}) {
+	t.Helper()
+	for _, value := range values {
+		if err := db.Create(value).Error; err != nil {
+			t.Fatalf("failed to create %T: %v", value, err)
+		}
+	}
+}
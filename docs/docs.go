@@ -22,7 +22,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Export question score to CSV, XLSX, or JSON",
+                "description": "Export question score to CSV, XLSX, or JSON, with the verdict and resource usage of each user's best submission",
                 "consumes": [
                     "application/json"
                 ],
@@ -71,6 +71,68 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/admin/rejudge/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Export the score changes of a finished rejudge batch to CSV, XLSX, or JSON. Each rejudged submission is compared with the best earlier judged submission of the user, per test suite of the AllTests result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Export rejudge report",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rejudge batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format: csv, xlsx, or json",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Forbidden"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "500": {
+                        "description": "Internal Server Error"
+                    }
+                }
+            }
+        },
         "/api/admin/user": {
             "get": {
                 "security": [
@@ -1232,6 +1294,185 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/extensions/admin": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the per-user deadline extensions and accommodations, optionally filtered by user, question or exam",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Extension"
+                ],
+                "summary": "List deadline extensions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by question ID",
+                        "name": "question_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by exam ID",
+                        "name": "exam_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.ExtensionData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a user a new end time and/or extra time on the judge limits for a question or an exam. An existing extension for the same user and question or exam is replaced.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Extension"
+                ],
+                "summary": "Create or replace a deadline extension",
+                "parameters": [
+                    {
+                        "description": "Extension",
+                        "name": "extension",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PutExtensionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.Extension"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/extensions/admin/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a deadline extension, the user falls back to the original end time and limits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Extension"
+                ],
+                "summary": "Delete a deadline extension",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "extension ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
         "/api/gitea": {
             "post": {
                 "description": "Receive Gitea hook",
@@ -1287,6 +1528,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ResponseHTTP"
                         }
                     },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
                     "503": {
                         "description": "Service Unavailable",
                         "schema": {
@@ -1745,14 +1992,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/questions/admin/question": {
-            "post": {
+        "/api/questions/admin/profiles": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a question",
+                "description": "List the built-in judge profiles with their generated compile, execute and score scripts. A profile can be selected per question, and non-empty raw scripts override the generated ones.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1762,18 +2009,7 @@ const docTemplate = `{
                 "tags": [
                     "Question"
                 ],
-                "summary": "Add a question",
-                "parameters": [
-                    {
-                        "description": "Question object",
-                        "name": "question",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AddQuestionRequest"
-                        }
-                    }
-                ],
+                "summary": "List built-in judge profiles",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1786,36 +2022,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.AddQuestionResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/profiles.Profile"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
-                    },
                     "401": {
                         "description": "Unauthorized"
-                    },
-                    "404": {
-                        "description": "Not Found"
-                    },
-                    "503": {
-                        "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/questions/admin/{ID}/question": {
-            "delete": {
+        "/api/questions/admin/question": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a question",
+                "description": "Add a question",
                 "consumes": [
                     "application/json"
                 ],
@@ -1825,10 +2055,73 @@ const docTemplate = `{
                 "tags": [
                     "Question"
                 ],
-                "summary": "Delete a question",
+                "summary": "Add a question",
                 "parameters": [
                     {
-                        "type": "integer",
+                        "description": "Question object",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AddQuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.AddQuestionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/questions/admin/{ID}/question": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a question",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Question"
+                ],
+                "summary": "Delete a question",
+                "parameters": [
+                    {
+                        "type": "integer",
                         "description": "ID of the Question to delete",
                         "name": "ID",
                         "in": "path",
@@ -2053,14 +2346,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/questions/user": {
+        "/api/questions/admin/{ID}/testcases": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of questions by user",
+                "description": "Get the stdin/stdout test cases used when the question is judged in io mode. When none are stored, the sandbox reads \u003cname\u003e.in/\u003cname\u003e.out pairs from the testcases directory of the question repository.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2070,19 +2363,14 @@ const docTemplate = `{
                 "tags": [
                     "Question"
                 ],
-                "summary": "Get a list of questions by user",
+                "summary": "Get the test cases of a question",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "page number of results to return (1-based)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size of results. Default is 10.",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "ID of the Question",
+                        "name": "ID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2099,7 +2387,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/handlers.GetUsersQuestionsResponseData"
+                                                "$ref": "#/definitions/models.QuestionTestCase"
                                             }
                                         }
                                     }
@@ -2107,9 +2395,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
-                    },
                     "401": {
                         "description": "Unauthorized"
                     },
@@ -2120,16 +2405,14 @@ const docTemplate = `{
                         "description": "Service Unavailable"
                     }
                 }
-            }
-        },
-        "/api/questions/user/{ID}/question": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific question associated with a user by its Question ID",
+                "description": "Replace all stdin/stdout test cases of a question. The order of the list is the judging order. Send an empty list to fall back to the testcases directory of the question repository.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2139,14 +2422,23 @@ const docTemplate = `{
                 "tags": [
                     "Question"
                 ],
-                "summary": "Get a user's question by Question ID",
+                "summary": "Replace the test cases of a question",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID of the Question to get",
+                        "description": "ID of the Question",
                         "name": "ID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Test cases",
+                        "name": "testcases",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PutTestCasesRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2161,16 +2453,16 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.GetUserQuestionResponseData"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.QuestionTestCase"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
-                    },
                     "401": {
                         "description": "Unauthorized"
                     },
@@ -2183,9 +2475,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/questions/{ID}/question": {
+        "/api/questions/user": {
             "get": {
-                "description": "Retrieve only public questions",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of questions by user",
                 "consumes": [
                     "application/json"
                 ],
@@ -2195,14 +2492,19 @@ const docTemplate = `{
                 "tags": [
                     "Question"
                 ],
-                "summary": "Get a question by ID",
+                "summary": "Get a list of questions by user",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID of the Question to get",
-                        "name": "ID",
-                        "in": "path",
-                        "required": true
+                        "description": "page number of results to return (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size of results. Default is 10.",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -2217,13 +2519,22 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.GetQuestionResponseData"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.GetUsersQuestionsResponseData"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
                     "404": {
                         "description": "Not Found"
                     },
@@ -2233,14 +2544,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/sandbox/admin/sandbox_cmd": {
-            "post": {
+        "/api/questions/user/{ID}/question": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Specify the shell commands and limitation for the corresponding repo",
+                "description": "Retrieve a specific question associated with a user by its Question ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -2248,18 +2559,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Sandbox"
+                    "Question"
                 ],
-                "summary": "Specify the shell commands and limitation for the corresponding repo",
+                "summary": "Get a user's question by Question ID",
                 "parameters": [
                     {
-                        "description": "Shell command",
-                        "name": "cmd",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.Sandbox"
-                        }
+                        "type": "integer",
+                        "description": "ID of the Question to get",
+                        "name": "ID",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2274,7 +2583,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.QuestionTestScript"
+                                            "$ref": "#/definitions/handlers.GetUserQuestionResponseData"
                                         }
                                     }
                                 }
@@ -2296,16 +2605,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/sandbox/status": {
+        "/api/questions/{ID}/question": {
             "get": {
-                "description": "Get the current available sandbox count and waiting count",
+                "description": "Retrieve only public questions",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Sandbox"
+                    "Question"
+                ],
+                "summary": "Get a question by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the Question to get",
+                        "name": "ID",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get the current available sandbox count and waiting count",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2318,30 +2639,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.StatusResponse"
+                                            "$ref": "#/definitions/handlers.GetQuestionResponseData"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/score": {
+        "/api/sandbox/admin/instances": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a score by repo",
+                "description": "List the sandbox instances connected to this API server with their labels, version, active jobs and judge totals since they connected",
                 "consumes": [
                     "application/json"
                 ],
@@ -2349,37 +2670,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Score"
-                ],
-                "summary": "Get a score by repo",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "owner of the repo",
-                        "name": "owner",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "name of the repo",
-                        "name": "repo",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page number of results to return (1-based)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size of results. Default is 10.",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Sandbox"
                 ],
+                "summary": "List sandbox instances",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2392,36 +2685,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.GetScoreResponseData"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/services.SandboxInstanceInfo"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
-                    },
                     "401": {
                         "description": "Unauthorized"
-                    },
-                    "404": {
-                        "description": "Not Found"
-                    },
-                    "503": {
-                        "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/score/admin/{question_id}/question/rescore": {
+        "/api/sandbox/admin/instances/{id}/cordon": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Re-score a specific question by question ID",
+                "description": "Stop sending new jobs to a sandbox, its running jobs are not affected",
                 "consumes": [
                     "application/json"
                 ],
@@ -2429,16 +2716,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Score"
+                    "Sandbox"
                 ],
-                "summary": "Re-score a specific question",
+                "summary": "Cordon a sandbox",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "question ID",
-                        "name": "question_id",
+                        "type": "string",
+                        "description": "sandbox ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "action",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SandboxActionRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -2463,14 +2758,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/score/all": {
-            "get": {
+        "/api/sandbox/admin/instances/{id}/drain": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all scores for the user",
+                "description": "Stop sending new jobs to a sandbox, the sandbox finishes its current jobs, reports their results and then disconnects and shuts down",
                 "consumes": [
                     "application/json"
                 ],
@@ -2478,40 +2773,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Score"
+                    "Sandbox"
                 ],
-                "summary": "Get all scores for the user",
+                "summary": "Drain a sandbox",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page number of results to return (1-based)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "sandbox ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "page size of results. Default is 10.",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Reason",
+                        "name": "action",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SandboxActionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handlers.ResponseHTTP"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/handlers.GetTopScoreResponseData"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
                         }
                     },
                     "400": {
@@ -2529,14 +2815,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/score/leaderboard": {
-            "get": {
+        "/api/sandbox/admin/instances/{id}/evict": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the leaderboard (Optional Authentication if Admin will show all users, otherwise only public users)",
+                "description": "Remove a sandbox immediately, it aborts its jobs and shuts down. Its unfinished jobs are queued again for other sandboxes.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2544,40 +2830,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Score"
+                    "Sandbox"
                 ],
-                "summary": "Get the leaderboard",
+                "summary": "Evict a sandbox",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page number of results to return (1-based)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "sandbox ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "page size of results. Default is 10.",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Reason",
+                        "name": "action",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SandboxActionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handlers.ResponseHTTP"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/handlers.GetLeaderboardResponseData"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
                         }
                     },
                     "400": {
@@ -2595,14 +2872,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/score/top": {
-            "get": {
+        "/api/sandbox/admin/instances/{id}/uncordon": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the top score of each question for user",
+                "description": "Resume sending new jobs to a cordoned sandbox, a draining sandbox cannot be uncordoned",
                 "consumes": [
                     "application/json"
                 ],
@@ -2610,40 +2887,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Score"
+                    "Sandbox"
                 ],
-                "summary": "Get the top score of each question for user",
+                "summary": "Uncordon a sandbox",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "page number of results to return (1-based)",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "sandbox ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "page size of results. Default is 10.",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Reason",
+                        "name": "action",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SandboxActionRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/handlers.ResponseHTTP"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/handlers.GetTopScoreResponseData"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
                         }
                     },
                     "400": {
@@ -2655,20 +2923,23 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
+                    "409": {
+                        "description": "Conflict"
+                    },
                     "503": {
                         "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/score/uqr/{UQR_ID}/score": {
-            "get": {
+        "/api/sandbox/admin/sandbox_cmd": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a score by UQR ID",
+                "description": "Specify the shell commands and limitation for the corresponding repo",
                 "consumes": [
                     "application/json"
                 ],
@@ -2676,28 +2947,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Score"
+                    "Sandbox"
                 ],
-                "summary": "Get a score by UQR ID",
+                "summary": "Specify the shell commands and limitation for the corresponding repo",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "UQR ID",
-                        "name": "UQR_ID",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page number of results to return (1-based)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "page size of results. Default is 10.",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Shell command",
+                        "name": "cmd",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Sandbox"
+                        }
                     }
                 ],
                 "responses": {
@@ -2712,7 +2973,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.Score"
+                                            "$ref": "#/definitions/models.QuestionTestScript"
                                         }
                                     }
                                 }
@@ -2734,14 +2995,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/score/{question_id}/question": {
+        "/api/sandbox/status": {
+            "get": {
+                "description": "Get the current available sandbox count and waiting count, with the queued jobs per priority class (exam, practice, rejudge)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Get the current available sandbox count and waiting count",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.StatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/score": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a score by question ID",
+                "description": "Get a score by repo",
                 "consumes": [
                     "application/json"
                 ],
@@ -2751,13 +3050,20 @@ const docTemplate = `{
                 "tags": [
                     "Score"
                 ],
-                "summary": "Get a score by question ID",
+                "summary": "Get a score by repo",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "question ID",
-                        "name": "question_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "owner of the repo",
+                        "name": "owner",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name of the repo",
+                        "name": "repo",
+                        "in": "query",
                         "required": true
                     },
                     {
@@ -2785,7 +3091,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.Score"
+                                            "$ref": "#/definitions/handlers.GetScoreResponseData"
                                         }
                                     }
                                 }
@@ -2807,14 +3113,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/score/{question_id}/question/user_rescore": {
-            "post": {
+        "/api/score/admin/overrides": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Re-score a specific user's question by question ID",
+                "description": "List the manual score overrides, optionally filtered by user or question. Revoked overrides are kept as audit trail and only listed with include_revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2824,46 +3130,64 @@ const docTemplate = `{
                 "tags": [
                     "Score"
                 ],
-                "summary": "Re-score a specific user's question",
+                "summary": "List score overrides",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "question ID",
+                        "description": "filter by user ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "filter by question ID",
                         "name": "question_id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "include revoked overrides",
+                        "name": "include_revoked",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.ScoreOverrideData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request"
-                    },
                     "401": {
                         "description": "Unauthorized"
                     },
-                    "404": {
-                        "description": "Not Found"
-                    },
                     "503": {
                         "description": "Service Unavailable"
                     }
                 }
-            }
-        },
-        "/api/user": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user info by ID",
+                "description": "Replace or adjust the final score of a user on a question. The judged score is kept and still reported, an existing override of the same user and question is revoked.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2871,14 +3195,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Score"
                 ],
-                "summary": "Get user info",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
+                "summary": "Override the final score of a user",
+                "parameters": [
+                    {
+                        "description": "Score override",
+                        "name": "override",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateScoreOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
                                 {
                                     "$ref": "#/definitions/handlers.ResponseHTTP"
                                 },
@@ -2886,7 +3221,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handlers.GetUserData"
+                                            "$ref": "#/definitions/models.ScoreOverride"
                                         }
                                     }
                                 }
@@ -2894,40 +3229,28 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Bad Request"
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Unauthorized"
                     },
                     "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Not Found"
                     },
                     "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/user/change_password": {
-            "post": {
+        "/api/score/admin/overrides/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Change user password",
+                "description": "Revoke a score override, the user falls back to the judged score. The override is kept as audit trail.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2935,18 +3258,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Score"
                 ],
-                "summary": "Change user password",
+                "summary": "Revoke a score override",
                 "parameters": [
                     {
-                        "description": "ChangeUserPasswordRequest",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ChangeUserPasswordRequest"
-                        }
+                        "type": "integer",
+                        "description": "score override ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -2971,9 +3292,69 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/forget_password": {
+        "/api/score/admin/rejudge": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the rejudge batches with the number of queued, running, done, failed and cancelled submissions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "List rejudge batches",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "filter by question ID",
+                        "name": "question_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handlers.RejudgeBatchData"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            },
             "post": {
-                "description": "Forget password",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Judge the given submissions or commits again exactly at their recorded commit, tracked as one rejudge batch. Each new result keeps the push time and late penalty of the original submission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2981,17 +3362,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Score"
                 ],
-                "summary": "Forget password",
+                "summary": "Rejudge specific submissions",
                 "parameters": [
                     {
-                        "description": "ForgetPasswordRequest",
-                        "name": "request",
+                        "description": "Submissions to rejudge",
+                        "name": "rejudge",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.ForgetPasswordRequest"
+                            "$ref": "#/definitions/handlers.RejudgeRequest"
                         }
                     }
                 ],
@@ -2999,7 +3380,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.RejudgeResponseData"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -3011,23 +3404,20 @@ const docTemplate = `{
                     "404": {
                         "description": "Not Found"
                     },
-                    "429": {
-                        "description": "Too Many Requests"
-                    },
                     "503": {
                         "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/user/is_public": {
-            "post": {
+        "/api/score/admin/rejudge/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the user's is_public setting",
+                "description": "Get the progress of a rejudge batch and the state of each of its submissions",
                 "consumes": [
                     "application/json"
                 ],
@@ -3035,18 +3425,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Score"
                 ],
-                "summary": "Set user is_public",
+                "summary": "Get a rejudge batch",
                 "parameters": [
                     {
-                        "description": "is_public",
-                        "name": "is_public",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.IsPublicRequest"
-                        }
+                        "type": "integer",
+                        "description": "rejudge batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -3061,7 +3449,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/models.User"
+                                            "$ref": "#/definitions/handlers.RejudgeBatchDetail"
                                         }
                                     }
                                 }
@@ -3069,68 +3457,92 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Bad Request"
                     },
                     "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Unauthorized"
                     },
                     "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Not Found"
                     },
                     "503": {
-                        "description": "Service Unavailable",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseHTTP"
-                        }
+                        "description": "Service Unavailable"
                     }
                 }
             }
         },
-        "/api/user/reset_password": {
-            "get": {
-                "description": "Show password reset form page",
+        "/api/score/admin/rejudge/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel the submissions of a rejudge batch that are still queued or being judged. Finished submissions keep their result.",
                 "consumes": [
-                    "text/html"
+                    "application/json"
                 ],
                 "produces": [
-                    "text/html"
+                    "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Score"
                 ],
-                "summary": "Show password reset page",
+                "summary": "Cancel a rejudge batch",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Reset token",
-                        "name": "token",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "rejudge batch ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "HTML page",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "409": {
+                        "description": "Conflict"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
                     }
                 }
-            },
+            }
+        },
+        "/api/score/admin/uqt/{id}/cancel": {
             "post": {
-                "description": "Handle password reset with token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel a queued or in-flight judge job of a submission. A running job is aborted on its sandbox, the box is released and the submission is marked CANCELLED with score -4.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3138,24 +3550,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Score"
                 ],
-                "summary": "Reset password",
+                "summary": "Cancel the judge of a submission",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Reset token",
-                        "name": "token",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "submission (user question table) ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Reset password request",
+                        "description": "Cancel reason",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.ResetPasswordRequest"
+                            "$ref": "#/definitions/handlers.CancelSubmissionRequest"
                         }
                     }
                 ],
@@ -3180,911 +3591,3147 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "gitea.Commit": {
-            "type": "object",
-            "properties": {
-                "author": {
-                    "$ref": "#/definitions/gitea.User"
-                },
-                "commit": {
-                    "$ref": "#/definitions/gitea.RepoCommit"
-                },
-                "committer": {
-                    "$ref": "#/definitions/gitea.User"
-                },
+        },
+        "/api/score/admin/{question_id}/question/rescore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Re-score every user of a question. By default the latest push of each user is judged again at its recorded commit, mode=best judges the best-scored push instead and mode=head judges the current repository HEAD.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Re-score a specific question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "question ID",
+                        "name": "question_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "last",
+                            "best",
+                            "head"
+                        ],
+                        "type": "string",
+                        "default": "last",
+                        "description": "submission to judge again: last, best or head",
+                        "name": "mode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.RejudgeBatch"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all scores for the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Get all scores for the user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number of results to return (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size of results. Default is 10.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.GetTopScoreResponseData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/leaderboard": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the leaderboard (Optional Authentication if Admin will show all users, otherwise only public users)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Get the leaderboard",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number of results to return (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size of results. Default is 10.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.GetLeaderboardResponseData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/top": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the top score of each question for user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Get the top score of each question for user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "page number of results to return (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size of results. Default is 10.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.GetTopScoreResponseData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/uqr/{UQR_ID}/score": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a score by UQR ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Get a score by UQR ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "UQR ID",
+                        "name": "UQR_ID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number of results to return (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size of results. Default is 10.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.Score"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/uqt/{id}/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Push state transitions and per-target compile/execute/score progress of a submission as Server-Sent Events. The stream ends after the finished event.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Stream judge status of a submission",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "submission (user question table) ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/services.JudgeEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/{question_id}/question": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a score by question ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Get a score by question ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "question ID",
+                        "name": "question_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page number of results to return (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page size of results. Default is 10.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.Score"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/score/{question_id}/question/user_rescore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Judge the latest push of the user again at its commit as a new submission, queued and scored like a push",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Score"
+                ],
+                "summary": "Re-score a specific user's question",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "question ID",
+                        "name": "question_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/user": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get user info by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handlers.GetUserData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/change_password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change user password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change user password",
+                "parameters": [
+                    {
+                        "description": "ChangeUserPasswordRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ChangeUserPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/user/forget_password": {
+            "post": {
+                "description": "Forget password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Forget password",
+                "parameters": [
+                    {
+                        "description": "ForgetPasswordRequest",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ForgetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "429": {
+                        "description": "Too Many Requests"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        },
+        "/api/user/is_public": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the user's is_public setting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Set user is_public",
+                "parameters": [
+                    {
+                        "description": "is_public",
+                        "name": "is_public",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.IsPublicRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/handlers.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/models.User"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/reset_password": {
+            "get": {
+                "description": "Show password reset form page",
+                "consumes": [
+                    "text/html"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Show password reset page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reset token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "HTML page",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    }
+                }
+            },
+            "post": {
+                "description": "Handle password reset with token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reset token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Reset password request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not Found"
+                    },
+                    "503": {
+                        "description": "Service Unavailable"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "gitea.Commit": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/gitea.User"
+                },
+                "commit": {
+                    "$ref": "#/definitions/gitea.RepoCommit"
+                },
+                "committer": {
+                    "$ref": "#/definitions/gitea.User"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitea.CommitAffectedFiles"
+                    }
+                },
+                "html_url": {
+                    "type": "string"
+                },
+                "parents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gitea.CommitMeta"
+                    }
+                },
+                "sha": {
+                    "type": "string"
+                },
+                "stats": {
+                    "$ref": "#/definitions/gitea.CommitStats"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.CommitAffectedFiles": {
+            "type": "object",
+            "properties": {
+                "filename": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.CommitMeta": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "sha": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.CommitStats": {
+            "type": "object",
+            "properties": {
+                "additions": {
+                    "type": "integer"
+                },
+                "deletions": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gitea.CommitUser": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.ExternalTracker": {
+            "type": "object",
+            "properties": {
+                "external_tracker_format": {
+                    "description": "External Issue Tracker URL Format. Use the placeholders {user}, {repo} and {index} for the username, repository name and issue index.",
+                    "type": "string"
+                },
+                "external_tracker_style": {
+                    "description": "External Issue Tracker Number Format, either ` + "`" + `numeric` + "`" + ` or ` + "`" + `alphanumeric` + "`" + `",
+                    "type": "string"
+                },
+                "external_tracker_url": {
+                    "description": "URL of external issue tracker.",
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.ExternalWiki": {
+            "type": "object",
+            "properties": {
+                "external_wiki_url": {
+                    "description": "URL of external wiki.",
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.InternalTracker": {
+            "type": "object",
+            "properties": {
+                "allow_only_contributors_to_track_time": {
+                    "description": "Let only contributors track time (Built-in issue tracker)",
+                    "type": "boolean"
+                },
+                "enable_issue_dependencies": {
+                    "description": "Enable dependencies for issues and pull requests (Built-in issue tracker)",
+                    "type": "boolean"
+                },
+                "enable_time_tracker": {
+                    "description": "Enable time tracking (Built-in issue tracker)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitea.MergeStyle": {
+            "type": "string",
+            "enum": [
+                "merge",
+                "rebase",
+                "rebase-merge",
+                "squash"
+            ],
+            "x-enum-varnames": [
+                "MergeStyleMerge",
+                "MergeStyleRebase",
+                "MergeStyleRebaseMerge",
+                "MergeStyleSquash"
+            ]
+        },
+        "gitea.PayloadCommitVerification": {
+            "type": "object",
+            "properties": {
+                "payload": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "signature": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitea.Permission": {
+            "type": "object",
+            "properties": {
+                "admin": {
+                    "type": "boolean"
+                },
+                "pull": {
+                    "type": "boolean"
+                },
+                "push": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "gitea.ProjectsMode": {
+            "type": "string",
+            "enum": [
+                "repo",
+                "owner",
+                "all"
+            ],
+            "x-enum-varnames": [
+                "ProjectsModeRepo",
+                "ProjectsModeOwner",
+                "ProjectsModeAll"
+            ]
+        },
+        "gitea.PublicKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "fingerprint": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_type": {
+                    "type": "string"
+                },
+                "read_only": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/gitea.User"
+                }
+            }
+        },
+        "gitea.RepoCommit": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "$ref": "#/definitions/gitea.CommitUser"
+                },
+                "committer": {
+                    "$ref": "#/definitions/gitea.CommitUser"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "tree": {
+                    "$ref": "#/definitions/gitea.CommitMeta"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "verification": {
+                    "$ref": "#/definitions/gitea.PayloadCommitVerification"
+                }
+            }
+        },
+        "gitea.Repository": {
+            "type": "object",
+            "properties": {
+                "allow_fast_forward_only_merge": {
+                    "type": "boolean"
+                },
+                "allow_merge_commits": {
+                    "type": "boolean"
+                },
+                "allow_rebase": {
+                    "type": "boolean"
+                },
+                "allow_rebase_explicit": {
+                    "type": "boolean"
+                },
+                "allow_squash_merge": {
+                    "type": "boolean"
+                },
+                "archived": {
+                    "type": "boolean"
+                },
+                "avatar_url": {
+                    "type": "string"
+                },
+                "clone_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "default_branch": {
+                    "type": "string"
+                },
+                "default_delete_branch_after_merge": {
+                    "type": "boolean"
+                },
+                "default_merge_style": {
+                    "$ref": "#/definitions/gitea.MergeStyle"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "empty": {
+                    "type": "boolean"
+                },
+                "external_tracker": {
+                    "$ref": "#/definitions/gitea.ExternalTracker"
+                },
+                "external_wiki": {
+                    "$ref": "#/definitions/gitea.ExternalWiki"
+                },
+                "fork": {
+                    "type": "boolean"
+                },
+                "forks_count": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "has_actions": {
+                    "type": "boolean"
+                },
+                "has_issues": {
+                    "type": "boolean"
+                },
+                "has_packages": {
+                    "type": "boolean"
+                },
+                "has_projects": {
+                    "type": "boolean"
+                },
+                "has_pull_requests": {
+                    "type": "boolean"
+                },
+                "has_releases": {
+                    "type": "boolean"
+                },
+                "has_wiki": {
+                    "type": "boolean"
+                },
+                "html_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ignore_whitespace_conflicts": {
+                    "type": "boolean"
+                },
+                "internal": {
+                    "type": "boolean"
+                },
+                "internal_tracker": {
+                    "$ref": "#/definitions/gitea.InternalTracker"
+                },
+                "mirror": {
+                    "type": "boolean"
+                },
+                "mirror_interval": {
+                    "type": "string"
+                },
+                "mirror_updated": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "object_format_name": {
+                    "type": "string"
+                },
+                "open_issues_count": {
+                    "type": "integer"
+                },
+                "open_pr_counter": {
+                    "type": "integer"
+                },
+                "original_url": {
+                    "type": "string"
+                },
+                "owner": {
+                    "$ref": "#/definitions/gitea.User"
+                },
+                "parent": {
+                    "$ref": "#/definitions/gitea.Repository"
+                },
+                "permissions": {
+                    "$ref": "#/definitions/gitea.Permission"
+                },
+                "private": {
+                    "type": "boolean"
+                },
+                "projects_mode": {
+                    "$ref": "#/definitions/gitea.ProjectsMode"
+                },
+                "release_counter": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "ssh_url": {
+                    "type": "string"
+                },
+                "stars_count": {
+                    "type": "integer"
+                },
+                "template": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "watchers_count": {
+                    "type": "integer"
+                },
+                "website": {
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.User": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Is user active",
+                    "type": "boolean"
+                },
+                "avatar_url": {
+                    "description": "URL to the user's avatar",
+                    "type": "string"
+                },
                 "created": {
+                    "description": "Date and Time of user creation",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "the user's description",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "followers_count": {
+                    "description": "user counts",
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
+                "full_name": {
+                    "description": "the user's full name",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "the user's id",
+                    "type": "integer"
+                },
+                "is_admin": {
+                    "description": "Is the user an administrator",
+                    "type": "boolean"
+                },
+                "language": {
+                    "description": "User locale",
+                    "type": "string"
+                },
+                "last_login": {
+                    "description": "Date and Time of last login",
+                    "type": "string"
+                },
+                "location": {
+                    "description": "the user's location",
+                    "type": "string"
+                },
+                "login": {
+                    "description": "the user's username",
+                    "type": "string"
+                },
+                "login_name": {
+                    "description": "The login_name of non local users (e.g. LDAP / OAuth / SMTP)",
+                    "type": "string"
+                },
+                "prohibit_login": {
+                    "description": "Is user login prohibited",
+                    "type": "boolean"
+                },
+                "restricted": {
+                    "description": "Is user restricted",
+                    "type": "boolean"
+                },
+                "source_id": {
+                    "description": "The ID of the Authentication Source for non local users.",
+                    "type": "integer"
+                },
+                "starred_repos_count": {
+                    "type": "integer"
+                },
+                "visibility": {
+                    "description": "User visibility level option",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gitea.VisibleType"
+                        }
+                    ]
+                },
+                "website": {
+                    "description": "the user's website",
+                    "type": "string"
+                }
+            }
+        },
+        "gitea.VisibleType": {
+            "type": "string",
+            "enum": [
+                "public",
+                "limited",
+                "private"
+            ],
+            "x-enum-varnames": [
+                "VisibleTypePublic",
+                "VisibleTypeLimited",
+                "VisibleTypePrivate"
+            ]
+        },
+        "handlers.AddQuestionRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "git_repo_url",
+                "title"
+            ],
+            "properties": {
+                "cancel_superseded": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "checker": {
+                    "type": "string",
+                    "example": "exact"
+                },
+                "checker_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "coalesce_queued": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "compile_file_size": {
+                    "type": "integer",
+                    "example": 10240
+                },
+                "compile_memory": {
+                    "type": "integer",
+                    "example": 2097152
+                },
+                "compile_processes": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "compile_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "compile_time": {
+                    "type": "integer",
+                    "example": 20000
+                },
+                "compile_wall_time": {
+                    "type": "integer",
+                    "example": 30000
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Question Description"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "execute_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "file_size": {
+                    "type": "integer",
+                    "example": 10240
+                },
+                "float_tolerance": {
+                    "type": "number",
+                    "example": 0.000001
+                },
+                "git_repo_url": {
+                    "type": "string",
+                    "example": "user_name/repo_name"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "judge_mode": {
+                    "type": "string",
+                    "example": "unit"
+                },
+                "judge_timeout": {
+                    "type": "integer",
+                    "example": 60000
+                },
+                "late_cutoff": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "late_grace_period": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "late_penalty": {
+                    "type": "number",
+                    "example": 10
+                },
+                "late_penalty_interval": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "late_policy": {
+                    "type": "string",
+                    "example": "none"
+                },
+                "max_submits_per_day": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "max_submits_per_hour": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "memory": {
+                    "type": "integer",
+                    "example": 262144
+                },
+                "min_submit_interval": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "open_files": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "processes": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "profile": {
+                    "type": "string",
+                    "example": "cpp-gtest"
+                },
+                "required_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "java",
+                        "big-memory"
+                    ]
+                },
+                "score_file_size": {
+                    "type": "integer",
+                    "example": 10240
+                },
+                "score_map": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "score_memory": {
+                    "type": "integer",
+                    "example": 1048576
+                },
+                "score_policy": {
+                    "type": "string",
+                    "example": "best"
+                },
+                "score_policy_top_k": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score_processes": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "score_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "score_time": {
+                    "type": "integer",
+                    "example": 10000
+                },
+                "score_wall_time": {
+                    "type": "integer",
+                    "example": 20000
+                },
+                "stack_memory": {
+                    "type": "integer",
+                    "example": 8192
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "submit_quota": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "time": {
+                    "type": "integer",
+                    "example": 1000
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Question Title"
+                },
+                "wall_time": {
+                    "type": "integer",
+                    "example": 3000
+                }
+            }
+        },
+        "handlers.AddQuestionResponse": {
+            "type": "object",
+            "required": [
+                "description",
+                "git_repo_url",
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Question Description"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "git_repo_url": {
+                    "type": "string",
+                    "example": "user_name/repo_name"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 123
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Question Title"
+                }
+            }
+        },
+        "handlers.BulkCreateUser": {
+            "type": "object",
+            "required": [
+                "default_password",
+                "email_domain",
+                "usernames"
+            ],
+            "properties": {
+                "default_password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "email_domain": {
+                    "type": "string",
+                    "example": "example.com"
+                },
+                "usernames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "username1",
+                        "username2"
+                    ]
+                }
+            }
+        },
+        "handlers.BulkCreateUserItem": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "username1@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password"
+                },
+                "username": {
+                    "type": "string",
+                    "example": "username1"
+                }
+            }
+        },
+        "handlers.BulkCreateUserRequest": {
+            "type": "object",
+            "required": [
+                "user"
+            ],
+            "properties": {
+                "user": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.BulkCreateUserItem"
+                    }
+                }
+            }
+        },
+        "handlers.BulkCreateUserResponse": {
+            "type": "object",
+            "properties": {
+                "failed_users": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "username1": "error"
+                    }
+                },
+                "successful_users": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "username1"
+                    ]
+                }
+            }
+        },
+        "handlers.CancelSubmissionRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Cancelled by administrator"
+                }
+            }
+        },
+        "handlers.ChangeUserEmailDTO": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ChangeUserPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "old_password"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "old_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreatePublicKey": {
+            "type": "object",
+            "required": [
+                "key",
+                "read_only",
+                "title"
+            ],
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC3..."
+                },
+                "read_only": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Public Key"
+                }
+            }
+        },
+        "handlers.CreateScoreOverrideRequest": {
+            "type": "object",
+            "required": [
+                "question_id",
+                "reason",
+                "user_id"
+            ],
+            "properties": {
+                "delta": {
+                    "type": "number",
+                    "example": 10
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Test case 3 had a wrong expected output"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 100
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.DeletePublicKey": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.EnhancedGetLeaderboardResponseData": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.EnhancedLeaderboardScore"
+                    }
+                }
+            }
+        },
+        "handlers.EnhancedLeaderboardScore": {
+            "type": "object",
+            "properties": {
+                "in_progress": {
+                    "type": "boolean"
+                },
+                "question_scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.EnhancedQuestionScore"
+                    }
+                },
+                "total_score": {
+                    "type": "number"
+                },
+                "user_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.EnhancedQuestionScore": {
+            "type": "object",
+            "properties": {
+                "git_user_repo_url": {
+                    "type": "string"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "question_title": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "weighted_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.ExamInfoResponse": {
+            "type": "object",
+            "properties": {
+                "exam_description": {
+                    "type": "string"
+                },
+                "exam_end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "exam_extended_end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "exam_start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "exam_title": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ExamListData": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "files": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/gitea.CommitAffectedFiles"
-                    }
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
                 },
-                "html_url": {
+                "id": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "title": {
                     "type": "string"
+                }
+            }
+        },
+        "handlers.ExamQuestionData": {
+            "type": "object",
+            "properties": {
+                "has_question": {
+                    "type": "boolean"
                 },
-                "parents": {
+                "point": {
+                    "type": "integer"
+                },
+                "question": {
+                    "$ref": "#/definitions/models.Question"
+                },
+                "top_score": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.ExamQuestionsResponse": {
+            "type": "object",
+            "properties": {
+                "question_count": {
+                    "type": "integer"
+                },
+                "questions": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/gitea.CommitMeta"
+                        "$ref": "#/definitions/handlers.ExamQuestionData"
                     }
-                },
-                "sha": {
+                }
+            }
+        },
+        "handlers.ExamRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "stats": {
-                    "$ref": "#/definitions/gitea.CommitStats"
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
                 },
-                "url": {
+                "score_policy": {
+                    "type": "string",
+                    "example": "last_before_deadline"
+                },
+                "score_policy_top_k": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "gitea.CommitAffectedFiles": {
+        "handlers.ExtensionData": {
             "type": "object",
             "properties": {
-                "filename": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "limit_multiplier": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_name": {
+                    "type": "string",
+                    "example": "student"
                 }
             }
         },
-        "gitea.CommitMeta": {
+        "handlers.ForgetPasswordRequest": {
             "type": "object",
+            "required": [
+                "email"
+            ],
             "properties": {
-                "created": {
+                "email": {
+                    "type": "string",
+                    "example": "username@example.com"
+                }
+            }
+        },
+        "handlers.GetAllUserInfoResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "total_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.GetLeaderboardResponseData": {
+            "type": "object",
+            "required": [
+                "count",
+                "scores"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.LeaderboardScore"
+                    }
+                }
+            }
+        },
+        "handlers.GetQuestionListResponseData": {
+            "type": "object",
+            "required": [
+                "question_count",
+                "questions"
+            ],
+            "properties": {
+                "question_count": {
+                    "type": "integer"
+                },
+                "questions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers._GetQuestionListQuestionData"
+                    }
+                }
+            }
+        },
+        "handlers.GetQuestionResponseData": {
+            "type": "object",
+            "required": [
+                "description",
+                "git_repo_url",
+                "parent_git_repo_url",
+                "readme",
+                "title"
+            ],
+            "properties": {
+                "description": {
                     "type": "string"
                 },
-                "sha": {
+                "git_repo_url": {
                     "type": "string"
                 },
-                "url": {
+                "parent_git_repo_url": {
+                    "type": "string"
+                },
+                "readme": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "gitea.CommitStats": {
+        "handlers.GetScoreResponseData": {
             "type": "object",
+            "required": [
+                "scores",
+                "scores_count"
+            ],
             "properties": {
-                "additions": {
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.Score"
+                    }
+                },
+                "scores_count": {
                     "type": "integer"
+                }
+            }
+        },
+        "handlers.GetTopExamScoreResponseData": {
+            "type": "object",
+            "required": [
+                "scores",
+                "scores_count"
+            ],
+            "properties": {
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TopExamScore"
+                    }
                 },
-                "deletions": {
+                "scores_count": {
                     "type": "integer"
+                }
+            }
+        },
+        "handlers.GetTopScoreResponseData": {
+            "type": "object",
+            "required": [
+                "scores",
+                "scores_count"
+            ],
+            "properties": {
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TopScore"
+                    }
                 },
-                "total": {
+                "scores_count": {
                     "type": "integer"
                 }
             }
         },
-        "gitea.CommitUser": {
+        "handlers.GetUserData": {
             "type": "object",
             "properties": {
-                "date": {
+                "email": {
                     "type": "string"
                 },
-                "email": {
+                "enable": {
+                    "type": "boolean"
+                },
+                "hash": {
                     "type": "string"
                 },
-                "name": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "is_public": {
+                    "type": "boolean"
+                },
+                "user_name": {
                     "type": "string"
                 }
             }
         },
-        "gitea.ExternalTracker": {
+        "handlers.GetUserQuestionResponseData": {
             "type": "object",
+            "required": [
+                "description",
+                "git_repo_url",
+                "parent_git_repo_url",
+                "readme",
+                "title",
+                "uqr_id"
+            ],
             "properties": {
-                "external_tracker_format": {
-                    "description": "External Issue Tracker URL Format. Use the placeholders {user}, {repo} and {index} for the username, repository name and issue index.",
+                "description": {
                     "type": "string"
                 },
-                "external_tracker_style": {
-                    "description": "External Issue Tracker Number Format, either ` + "`" + `numeric` + "`" + ` or ` + "`" + `alphanumeric` + "`" + `",
+                "git_repo_url": {
                     "type": "string"
                 },
-                "external_tracker_url": {
-                    "description": "URL of external issue tracker.",
+                "parent_git_repo_url": {
+                    "type": "string"
+                },
+                "readme": {
                     "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "uqr_id": {
+                    "type": "integer"
                 }
             }
         },
-        "gitea.ExternalWiki": {
+        "handlers.GetUsersQuestionsResponseData": {
             "type": "object",
+            "required": [
+                "question",
+                "question_count"
+            ],
             "properties": {
-                "external_wiki_url": {
-                    "description": "URL of external wiki.",
-                    "type": "string"
+                "question": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers._GetUsersQuestionsResponseData"
+                    }
+                },
+                "question_count": {
+                    "type": "integer"
                 }
             }
         },
-        "gitea.InternalTracker": {
+        "handlers.IsPublicRequest": {
             "type": "object",
             "properties": {
-                "allow_only_contributors_to_track_time": {
-                    "description": "Let only contributors track time (Built-in issue tracker)",
-                    "type": "boolean"
-                },
-                "enable_issue_dependencies": {
-                    "description": "Enable dependencies for issues and pull requests (Built-in issue tracker)",
-                    "type": "boolean"
-                },
-                "enable_time_tracker": {
-                    "description": "Enable time tracking (Built-in issue tracker)",
+                "is_public": {
                     "type": "boolean"
                 }
             }
         },
-        "gitea.MergeStyle": {
-            "type": "string",
-            "enum": [
-                "merge",
-                "rebase",
-                "rebase-merge",
-                "squash"
-            ],
-            "x-enum-varnames": [
-                "MergeStyleMerge",
-                "MergeStyleRebase",
-                "MergeStyleRebaseMerge",
-                "MergeStyleSquash"
-            ]
-        },
-        "gitea.PayloadCommitVerification": {
+        "handlers.LeaderboardScore": {
             "type": "object",
+            "required": [
+                "question_scores",
+                "total_score",
+                "user_name"
+            ],
             "properties": {
-                "payload": {
-                    "type": "string"
+                "in_progress": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "reason": {
-                    "type": "string"
+                "question_scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.QuestionScore"
+                    }
                 },
-                "signature": {
-                    "type": "string"
+                "total_score": {
+                    "type": "number",
+                    "example": 200
                 },
-                "verified": {
-                    "type": "boolean"
+                "user_name": {
+                    "type": "string",
+                    "example": "owner"
                 }
             }
         },
-        "gitea.Permission": {
+        "handlers.LoginRequest": {
             "type": "object",
             "properties": {
-                "admin": {
-                    "type": "boolean"
+                "password": {
+                    "type": "string",
+                    "example": "password"
                 },
-                "pull": {
-                    "type": "boolean"
+                "token": {
+                    "description": "Optional token for API access",
+                    "type": "string",
+                    "example": ""
                 },
-                "push": {
-                    "type": "boolean"
+                "username": {
+                    "type": "string",
+                    "example": "username"
                 }
             }
         },
-        "gitea.ProjectsMode": {
-            "type": "string",
-            "enum": [
-                "repo",
-                "owner",
-                "all"
-            ],
-            "x-enum-varnames": [
-                "ProjectsModeRepo",
-                "ProjectsModeOwner",
-                "ProjectsModeAll"
-            ]
-        },
-        "gitea.PublicKey": {
+        "handlers.LoginResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "fingerprint": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "key": {
+                "access_token": {
                     "type": "string"
                 },
-                "key_type": {
+                "refresh_token": {
                     "type": "string"
                 },
-                "read_only": {
+                "reset_password": {
                     "type": "boolean"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "url": {
-                    "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/gitea.User"
                 }
             }
         },
-        "gitea.RepoCommit": {
+        "handlers.PatchQuestionRequest": {
             "type": "object",
             "properties": {
-                "author": {
-                    "$ref": "#/definitions/gitea.CommitUser"
+                "cancel_superseded": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "committer": {
-                    "$ref": "#/definitions/gitea.CommitUser"
+                "checker": {
+                    "type": "string",
+                    "example": "exact"
                 },
-                "message": {
-                    "type": "string"
+                "checker_script": {
+                    "type": "string",
+                    "example": "script example"
                 },
-                "tree": {
-                    "$ref": "#/definitions/gitea.CommitMeta"
+                "coalesce_queued": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "url": {
-                    "type": "string"
+                "compile_file_size": {
+                    "type": "integer",
+                    "example": 10240
                 },
-                "verification": {
-                    "$ref": "#/definitions/gitea.PayloadCommitVerification"
-                }
-            }
-        },
-        "gitea.Repository": {
-            "type": "object",
-            "properties": {
-                "allow_fast_forward_only_merge": {
-                    "type": "boolean"
+                "compile_memory": {
+                    "type": "integer",
+                    "example": 2097152
+                },
+                "compile_processes": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "compile_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "compile_time": {
+                    "type": "integer",
+                    "example": 20000
+                },
+                "compile_wall_time": {
+                    "type": "integer",
+                    "example": 30000
+                },
+                "description": {
+                    "type": "string",
+                    "example": "Question Description"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "execute_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "file_size": {
+                    "type": "integer",
+                    "example": 10240
+                },
+                "float_tolerance": {
+                    "type": "number",
+                    "example": 0.000001
+                },
+                "git_repo_url": {
+                    "type": "string",
+                    "example": "user_name/repo_name"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "judge_mode": {
+                    "type": "string",
+                    "example": "unit"
+                },
+                "judge_timeout": {
+                    "type": "integer",
+                    "example": 60000
+                },
+                "late_cutoff": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "late_grace_period": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "late_penalty": {
+                    "type": "number",
+                    "example": 10
                 },
-                "allow_merge_commits": {
-                    "type": "boolean"
+                "late_penalty_interval": {
+                    "type": "integer",
+                    "example": 60
                 },
-                "allow_rebase": {
-                    "type": "boolean"
+                "late_policy": {
+                    "type": "string",
+                    "example": "none"
                 },
-                "allow_rebase_explicit": {
-                    "type": "boolean"
+                "max_submits_per_day": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "allow_squash_merge": {
-                    "type": "boolean"
+                "max_submits_per_hour": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "archived": {
-                    "type": "boolean"
+                "memory": {
+                    "type": "integer",
+                    "example": 262144
                 },
-                "avatar_url": {
-                    "type": "string"
+                "min_submit_interval": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "clone_url": {
-                    "type": "string"
+                "open_files": {
+                    "type": "integer",
+                    "example": 64
                 },
-                "created_at": {
-                    "type": "string"
+                "processes": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "default_branch": {
-                    "type": "string"
+                "profile": {
+                    "type": "string",
+                    "example": "cpp-gtest"
                 },
-                "default_delete_branch_after_merge": {
-                    "type": "boolean"
+                "required_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "java",
+                        "big-memory"
+                    ]
                 },
-                "default_merge_style": {
-                    "$ref": "#/definitions/gitea.MergeStyle"
+                "score_file_size": {
+                    "type": "integer",
+                    "example": 10240
                 },
-                "description": {
-                    "type": "string"
+                "score_map": {
+                    "type": "string",
+                    "example": "score map for task score"
                 },
-                "empty": {
-                    "type": "boolean"
+                "score_memory": {
+                    "type": "integer",
+                    "example": 1048576
                 },
-                "external_tracker": {
-                    "$ref": "#/definitions/gitea.ExternalTracker"
+                "score_policy": {
+                    "type": "string",
+                    "example": "best"
                 },
-                "external_wiki": {
-                    "$ref": "#/definitions/gitea.ExternalWiki"
+                "score_policy_top_k": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "fork": {
-                    "type": "boolean"
+                "score_processes": {
+                    "type": "integer",
+                    "example": 100
                 },
-                "forks_count": {
-                    "type": "integer"
+                "score_script": {
+                    "type": "string",
+                    "example": "script example"
                 },
-                "full_name": {
-                    "type": "string"
+                "score_time": {
+                    "type": "integer",
+                    "example": 10000
                 },
-                "has_actions": {
-                    "type": "boolean"
+                "score_wall_time": {
+                    "type": "integer",
+                    "example": 20000
                 },
-                "has_issues": {
-                    "type": "boolean"
+                "stack_memory": {
+                    "type": "integer",
+                    "example": 8192
                 },
-                "has_packages": {
-                    "type": "boolean"
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
                 },
-                "has_projects": {
-                    "type": "boolean"
+                "submit_quota": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "has_pull_requests": {
-                    "type": "boolean"
+                "time": {
+                    "type": "integer",
+                    "example": 1000
                 },
-                "has_releases": {
-                    "type": "boolean"
+                "title": {
+                    "type": "string",
+                    "example": "Question Title"
                 },
-                "has_wiki": {
-                    "type": "boolean"
+                "wall_time": {
+                    "type": "integer",
+                    "example": 3000
+                }
+            }
+        },
+        "handlers.PutExtensionRequest": {
+            "type": "object",
+            "required": [
+                "user_id"
+            ],
+            "properties": {
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
                 },
-                "html_url": {
-                    "type": "string"
+                "exam_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "id": {
-                    "type": "integer"
+                "limit_multiplier": {
+                    "type": "number",
+                    "example": 1.5
                 },
-                "ignore_whitespace_conflicts": {
-                    "type": "boolean"
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "internal": {
-                    "type": "boolean"
+                "reason": {
+                    "type": "string",
+                    "example": "Accommodation"
                 },
-                "internal_tracker": {
-                    "$ref": "#/definitions/gitea.InternalTracker"
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.PutTestCasesRequest": {
+            "type": "object",
+            "properties": {
+                "test_cases": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TestCaseData"
+                    }
+                }
+            }
+        },
+        "handlers.QuestionScore": {
+            "type": "object",
+            "required": [
+                "git_user_repo_url",
+                "question_id",
+                "question_title",
+                "score"
+            ],
+            "properties": {
+                "git_user_repo_url": {
+                    "type": "string",
+                    "example": "owner/repo"
                 },
-                "mirror": {
-                    "type": "boolean"
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "mirror_interval": {
-                    "type": "string"
+                "question_title": {
+                    "type": "string",
+                    "example": "Two Sum"
                 },
-                "mirror_updated": {
-                    "type": "string"
+                "score": {
+                    "type": "number",
+                    "example": 100
+                }
+            }
+        },
+        "handlers.QuestionScripts": {
+            "type": "object",
+            "properties": {
+                "cancel_superseded": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "name": {
-                    "type": "string"
+                "checker": {
+                    "type": "string",
+                    "example": "exact"
                 },
-                "object_format_name": {
-                    "type": "string"
+                "checker_script": {
+                    "type": "string",
+                    "example": "script example"
                 },
-                "open_issues_count": {
-                    "type": "integer"
+                "coalesce_queued": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "open_pr_counter": {
-                    "type": "integer"
+                "compile_script": {
+                    "type": "string",
+                    "example": "script example"
                 },
-                "original_url": {
-                    "type": "string"
+                "execute_script": {
+                    "type": "string",
+                    "example": "script example"
                 },
-                "owner": {
-                    "$ref": "#/definitions/gitea.User"
+                "float_tolerance": {
+                    "type": "number",
+                    "example": 0.000001
                 },
-                "parent": {
-                    "$ref": "#/definitions/gitea.Repository"
+                "judge_mode": {
+                    "type": "string",
+                    "example": "unit"
                 },
-                "permissions": {
-                    "$ref": "#/definitions/gitea.Permission"
+                "late_cutoff": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "private": {
-                    "type": "boolean"
+                "late_grace_period": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "projects_mode": {
-                    "$ref": "#/definitions/gitea.ProjectsMode"
+                "late_penalty": {
+                    "type": "number",
+                    "example": 10
                 },
-                "release_counter": {
-                    "type": "integer"
+                "late_penalty_interval": {
+                    "type": "integer",
+                    "example": 60
                 },
-                "size": {
-                    "type": "integer"
+                "late_policy": {
+                    "type": "string",
+                    "example": "none"
                 },
-                "ssh_url": {
-                    "type": "string"
+                "max_submits_per_day": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "stars_count": {
-                    "type": "integer"
+                "max_submits_per_hour": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "template": {
-                    "type": "boolean"
+                "min_submit_interval": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "updated_at": {
-                    "type": "string"
+                "profile": {
+                    "type": "string",
+                    "example": "cpp-gtest"
                 },
-                "watchers_count": {
-                    "type": "integer"
+                "required_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "java",
+                        "big-memory"
+                    ]
                 },
-                "website": {
-                    "type": "string"
+                "score_map": {
+                    "type": "string",
+                    "example": "score map for task score"
+                },
+                "score_policy": {
+                    "type": "string",
+                    "example": "best"
+                },
+                "score_policy_top_k": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score_script": {
+                    "type": "string",
+                    "example": "script example"
+                },
+                "submit_quota": {
+                    "type": "integer",
+                    "example": 0
                 }
             }
         },
-        "gitea.User": {
+        "handlers.RejudgeBatchData": {
             "type": "object",
             "properties": {
-                "active": {
-                    "description": "Is user active",
-                    "type": "boolean"
-                },
-                "avatar_url": {
-                    "description": "URL to the user's avatar",
-                    "type": "string"
+                "cancelled": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "created": {
-                    "description": "Date and Time of user creation",
+                "cancelled_at": {
                     "type": "string"
                 },
-                "description": {
-                    "description": "the user's description",
-                    "type": "string"
+                "cancelled_by": {
+                    "type": "integer"
                 },
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "followers_count": {
-                    "description": "user counts",
+                "created_by": {
                     "type": "integer"
                 },
-                "following_count": {
-                    "type": "integer"
+                "done": {
+                    "type": "integer",
+                    "example": 280
                 },
-                "full_name": {
-                    "description": "the user's full name",
-                    "type": "string"
+                "failed": {
+                    "type": "integer",
+                    "example": 1
                 },
                 "id": {
-                    "description": "the user's id",
                     "type": "integer"
                 },
-                "is_admin": {
-                    "description": "Is the user an administrator",
-                    "type": "boolean"
-                },
-                "language": {
-                    "description": "User locale",
-                    "type": "string"
-                },
-                "last_login": {
-                    "description": "Date and Time of last login",
-                    "type": "string"
-                },
-                "location": {
-                    "description": "the user's location",
-                    "type": "string"
-                },
-                "login": {
-                    "description": "the user's username",
+                "mode": {
                     "type": "string"
                 },
-                "login_name": {
-                    "description": "The login_name of non local users (e.g. LDAP / OAuth / SMTP)",
-                    "type": "string"
+                "question_id": {
+                    "type": "integer"
                 },
-                "prohibit_login": {
-                    "description": "Is user login prohibited",
-                    "type": "boolean"
+                "queued": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "restricted": {
-                    "description": "Is user restricted",
-                    "type": "boolean"
+                "running": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "source_id": {
-                    "description": "The ID of the Authentication Source for non local users.",
-                    "type": "integer"
+                "status": {
+                    "type": "string",
+                    "example": "running"
                 },
-                "starred_repos_count": {
+                "total": {
                     "type": "integer"
-                },
-                "visibility": {
-                    "description": "User visibility level option",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/gitea.VisibleType"
-                        }
-                    ]
-                },
-                "website": {
-                    "description": "the user's website",
-                    "type": "string"
                 }
             }
         },
-        "gitea.VisibleType": {
-            "type": "string",
-            "enum": [
-                "public",
-                "limited",
-                "private"
-            ],
-            "x-enum-varnames": [
-                "VisibleTypePublic",
-                "VisibleTypeLimited",
-                "VisibleTypePrivate"
-            ]
-        },
-        "handlers.AddQuestionRequest": {
+        "handlers.RejudgeBatchDetail": {
             "type": "object",
-            "required": [
-                "description",
-                "git_repo_url",
-                "title"
-            ],
             "properties": {
-                "compile_script": {
-                    "type": "string",
-                    "example": "script example"
-                },
-                "description": {
-                    "type": "string",
-                    "example": "Question Description"
-                },
-                "end_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                "cancelled": {
+                    "type": "integer",
+                    "example": 0
                 },
-                "execute_script": {
-                    "type": "string",
-                    "example": "script example"
+                "cancelled_at": {
+                    "type": "string"
                 },
-                "file_size": {
-                    "type": "integer",
-                    "example": 10240
+                "cancelled_by": {
+                    "type": "integer"
                 },
-                "git_repo_url": {
-                    "type": "string",
-                    "example": "user_name/repo_name"
+                "created_at": {
+                    "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                "created_by": {
+                    "type": "integer"
                 },
-                "memory": {
+                "done": {
                     "type": "integer",
-                    "example": 262144
+                    "example": 280
                 },
-                "open_files": {
+                "failed": {
                     "type": "integer",
-                    "example": 64
+                    "example": 1
                 },
-                "processes": {
-                    "type": "integer",
-                    "example": 10
+                "id": {
+                    "type": "integer"
                 },
-                "score_map": {
-                    "type": "string",
-                    "example": "script example"
+                "mode": {
+                    "type": "string"
                 },
-                "score_script": {
-                    "type": "string",
-                    "example": "script example"
+                "question_id": {
+                    "type": "integer"
                 },
-                "stack_memory": {
+                "queued": {
                     "type": "integer",
-                    "example": 8192
-                },
-                "start_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": 10
                 },
-                "time": {
+                "running": {
                     "type": "integer",
-                    "example": 1000
+                    "example": 2
                 },
-                "title": {
+                "status": {
                     "type": "string",
-                    "example": "Question Title"
+                    "example": "running"
                 },
-                "wall_time": {
-                    "type": "integer",
-                    "example": 3000
+                "submissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RejudgeBatchSubmission"
+                    }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.AddQuestionResponse": {
+        "handlers.RejudgeBatchSubmission": {
             "type": "object",
-            "required": [
-                "description",
-                "git_repo_url",
-                "title"
-            ],
             "properties": {
-                "description": {
+                "commit": {
                     "type": "string",
-                    "example": "Question Description"
+                    "example": "0123456789abcdef0123456789abcdef01234567"
                 },
-                "end_time": {
+                "git_user_repo_url": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": "student/question"
                 },
-                "git_repo_url": {
+                "message": {
                     "type": "string",
-                    "example": "user_name/repo_name"
+                    "example": "Judged successfully"
                 },
-                "id": {
-                    "type": "integer",
-                    "example": 123
+                "score": {
+                    "type": "number",
+                    "example": 100
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                "uqt_id": {
+                    "type": "integer",
+                    "example": 3
                 },
-                "start_time": {
+                "user_name": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": "student"
                 },
-                "title": {
+                "verdict": {
                     "type": "string",
-                    "example": "Question Title"
+                    "example": "ACCEPTED"
                 }
             }
         },
-        "handlers.BulkCreateUser": {
+        "handlers.RejudgeRequest": {
             "type": "object",
-            "required": [
-                "default_password",
-                "email_domain",
-                "usernames"
-            ],
             "properties": {
-                "default_password": {
-                    "type": "string",
-                    "example": "password"
+                "commits": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "0123456789abcdef0123456789abcdef01234567"
+                    ]
                 },
-                "email_domain": {
-                    "type": "string",
-                    "example": "example.com"
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "usernames": {
+                "uqt_ids": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "type": "integer"
                     },
                     "example": [
-                        "username1",
-                        "username2"
+                        1,
+                        2
                     ]
                 }
             }
         },
-        "handlers.BulkCreateUserItem": {
+        "handlers.RejudgeResponseData": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "username"
-            ],
             "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "username1@example.com"
-                },
-                "password": {
-                    "type": "string",
-                    "example": "password"
+                "batch": {
+                    "$ref": "#/definitions/models.RejudgeBatch"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "username1"
+                "submissions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RejudgedSubmission"
+                    }
                 }
             }
         },
-        "handlers.BulkCreateUserRequest": {
+        "handlers.RejudgedSubmission": {
             "type": "object",
-            "required": [
-                "user"
-            ],
             "properties": {
-                "user": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.BulkCreateUserItem"
-                    }
+                "commit": {
+                    "type": "string",
+                    "example": "0123456789abcdef0123456789abcdef01234567"
+                },
+                "error": {
+                    "type": "string",
+                    "example": ""
+                },
+                "source_uqt_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "uqt_id": {
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "handlers.BulkCreateUserResponse": {
+        "handlers.ResetPasswordRequest": {
             "type": "object",
+            "required": [
+                "new_password"
+            ],
             "properties": {
-                "failed_users": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    },
-                    "example": {
-                        "username1": "error"
-                    }
-                },
-                "successful_users": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "username1"
-                    ]
+                "new_password": {
+                    "type": "string",
+                    "minLength": 6
                 }
             }
         },
-        "handlers.ChangeUserEmailDTO": {
+        "handlers.ResetUserPasswordDTO": {
             "type": "object",
             "required": [
-                "email"
+                "password"
             ],
             "properties": {
-                "email": {
+                "password": {
                     "type": "string"
                 }
             }
         },
-        "handlers.ChangeUserPasswordRequest": {
+        "handlers.ResponseHTTP": {
             "type": "object",
-            "required": [
-                "new_password",
-                "old_password"
-            ],
             "properties": {
-                "new_password": {
+                "data": {},
+                "message": {
                     "type": "string"
                 },
-                "old_password": {
-                    "type": "string"
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "handlers.CreatePublicKey": {
+        "handlers.Sandbox": {
             "type": "object",
             "required": [
-                "key",
-                "read_only",
-                "title"
+                "compilescript",
+                "executescript",
+                "scorescript",
+                "source_git_url"
             ],
             "properties": {
-                "key": {
+                "compilescript": {
                     "type": "string",
-                    "example": "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC3..."
+                    "example": "#!/bin/bash\n\necho 'Hello, World!'"
                 },
-                "read_only": {
-                    "type": "boolean",
-                    "example": true
+                "executescript": {
+                    "type": "string",
+                    "example": "#!/bin/bash\n\necho 'Hello, World!'"
                 },
-                "title": {
+                "scorescript": {
                     "type": "string",
-                    "example": "Public Key"
+                    "example": "#!/bin/bash\n\necho 'Hello, World!'"
+                },
+                "source_git_url": {
+                    "type": "string",
+                    "example": "user_name/repo_name"
                 }
             }
         },
-        "handlers.DeletePublicKey": {
+        "handlers.SandboxActionRequest": {
             "type": "object",
-            "required": [
-                "id"
-            ],
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "reason": {
+                    "type": "string",
+                    "example": "Host maintenance"
                 }
             }
         },
-        "handlers.EnhancedGetLeaderboardResponseData": {
+        "handlers.Score": {
             "type": "object",
+            "required": [
+                "judge_time",
+                "message",
+                "score"
+            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "cpu_time_ms": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "scores": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "judge_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "late_penalty": {
+                    "type": "number",
+                    "example": 0
+                },
+                "memory_kb": {
+                    "type": "integer",
+                    "example": 2048
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Scored successfully"
+                },
+                "metrics": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.EnhancedLeaderboardScore"
+                        "$ref": "#/definitions/models.ExecutionMetric"
                     }
+                },
+                "raw_score": {
+                    "type": "number",
+                    "example": 100
+                },
+                "score": {
+                    "type": "number",
+                    "example": 100
+                },
+                "verdict": {
+                    "type": "string",
+                    "example": "ACCEPTED"
                 }
             }
         },
-        "handlers.EnhancedLeaderboardScore": {
+        "handlers.ScoreOverrideData": {
             "type": "object",
             "properties": {
-                "question_scores": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.EnhancedQuestionScore"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "total_score": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "delta": {
                     "type": "number"
                 },
-                "user_name": {
+                "id": {
+                    "type": "integer"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "reason": {
                     "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "revoked_by": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "user_name": {
+                    "type": "string",
+                    "example": "student"
                 }
             }
         },
-        "handlers.EnhancedQuestionScore": {
+        "handlers.StatusResponse": {
             "type": "object",
             "properties": {
-                "git_user_repo_url": {
-                    "type": "string"
-                },
-                "question_id": {
+                "available_count": {
                     "type": "integer"
                 },
-                "question_title": {
-                    "type": "string"
+                "processing_count": {
+                    "type": "integer"
                 },
-                "score": {
-                    "type": "number"
+                "queue": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/services.QueuePriorityStats"
+                    }
                 },
-                "weighted_score": {
-                    "type": "number"
+                "waiting_count": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.ExamInfoResponse": {
+        "handlers.TestCaseData": {
             "type": "object",
             "properties": {
-                "exam_description": {
-                    "type": "string"
+                "input": {
+                    "type": "string",
+                    "example": "1 2\n"
                 },
-                "exam_end_time": {
+                "memory": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "name": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": "sample_1"
                 },
-                "exam_start_time": {
+                "output": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": "3\n"
                 },
-                "exam_title": {
-                    "type": "string"
+                "time": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 1
                 }
             }
         },
-        "handlers.ExamListData": {
+        "handlers.TopExamScore": {
             "type": "object",
             "required": [
-                "title"
+                "git_user_repo_url",
+                "judge_time",
+                "message",
+                "point",
+                "question_id",
+                "score"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "end_time": {
+                "git_user_repo_url": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": "owner/repo"
                 },
-                "id": {
-                    "type": "integer"
+                "judge_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
                 },
-                "start_time": {
+                "message": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                    "example": "Scored successfully"
                 },
-                "title": {
-                    "type": "string"
+                "point": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "score": {
+                    "type": "number",
+                    "example": 100
                 }
             }
         },
-        "handlers.ExamQuestionData": {
+        "handlers.TopScore": {
             "type": "object",
+            "required": [
+                "git_user_repo_url",
+                "judge_time",
+                "message",
+                "question_id",
+                "question_title",
+                "score"
+            ],
             "properties": {
-                "has_question": {
-                    "type": "boolean"
+                "git_user_repo_url": {
+                    "type": "string",
+                    "example": "owner/repo"
                 },
-                "point": {
-                    "type": "integer"
+                "judge_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
                 },
-                "question": {
-                    "$ref": "#/definitions/models.Question"
+                "message": {
+                    "type": "string",
+                    "example": "Scored successfully"
+                },
+                "question_id": {
+                    "type": "integer",
+                    "example": 1
                 },
-                "top_score": {
-                    "type": "number"
-                }
-            }
-        },
-        "handlers.ExamQuestionsResponse": {
-            "type": "object",
-            "properties": {
-                "question_count": {
-                    "type": "integer"
+                "question_title": {
+                    "type": "string",
+                    "example": "Two Sum"
                 },
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.ExamQuestionData"
-                    }
+                "score": {
+                    "type": "number",
+                    "example": 100
                 }
             }
         },
-        "handlers.ExamRequest": {
+        "handlers.UpdateExamRequest": {
             "type": "object",
             "required": [
                 "title"
@@ -4097,6 +6744,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2006-01-02T15:04:05Z"
                 },
+                "score_policy": {
+                    "type": "string",
+                    "example": "last_before_deadline"
+                },
+                "score_policy_top_k": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "start_time": {
                     "type": "string",
                     "example": "2006-01-02T15:04:05Z"
@@ -4106,76 +6761,96 @@ const docTemplate = `{
                 }
             }
         },
-        "handlers.ForgetPasswordRequest": {
-            "type": "object",
-            "required": [
-                "email"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "username@example.com"
-                }
-            }
-        },
-        "handlers.GetAllUserInfoResponse": {
+        "handlers.UpdateUserInfoDTO": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.User"
-                    }
+                "enable": {
+                    "type": "boolean"
                 },
-                "total_count": {
-                    "type": "integer"
+                "is_public": {
+                    "type": "boolean"
                 }
             }
         },
-        "handlers.GetLeaderboardResponseData": {
+        "handlers.WebhookPayload": {
             "type": "object",
-            "required": [
-                "count",
-                "scores"
-            ],
             "properties": {
-                "count": {
-                    "type": "integer"
+                "after": {
+                    "type": "string"
                 },
-                "scores": {
+                "before": {
+                    "type": "string"
+                },
+                "commits": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.LeaderboardScore"
+                        "$ref": "#/definitions/gitea.Commit"
                     }
+                },
+                "compare_url": {
+                    "type": "string"
+                },
+                "pusher": {
+                    "$ref": "#/definitions/gitea.User"
+                },
+                "ref": {
+                    "type": "string"
+                },
+                "repository": {
+                    "$ref": "#/definitions/gitea.Repository"
+                },
+                "sender": {
+                    "$ref": "#/definitions/gitea.User"
                 }
             }
         },
-        "handlers.GetQuestionListResponseData": {
+        "handlers._GetQuestionListQuestionData": {
             "type": "object",
-            "required": [
-                "question_count",
-                "questions"
-            ],
             "properties": {
-                "question_count": {
+                "description": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "extended_end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z"
+                },
+                "git_repo_url": {
+                    "type": "string"
+                },
+                "has_question": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "questions": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers._GetQuestionListQuestionData"
-                    }
+                "is_active": {
+                    "type": "boolean"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "top_score": {
+                    "type": "number"
                 }
             }
         },
-        "handlers.GetQuestionResponseData": {
+        "handlers._GetUsersQuestionsResponseData": {
             "type": "object",
             "required": [
                 "description",
                 "git_repo_url",
                 "parent_git_repo_url",
-                "readme",
-                "title"
+                "q_id",
+                "title",
+                "uqr_id"
             ],
             "properties": {
                 "description": {
@@ -4187,796 +6862,846 @@ const docTemplate = `{
                 "parent_git_repo_url": {
                     "type": "string"
                 },
-                "readme": {
-                    "type": "string"
+                "q_id": {
+                    "type": "integer"
                 },
                 "title": {
                     "type": "string"
+                },
+                "uqr_id": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.GetScoreResponseData": {
+        "handlers.point": {
             "type": "object",
             "required": [
-                "scores",
-                "scores_count"
+                "score"
             ],
             "properties": {
-                "scores": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.Score"
-                    }
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CheckerType": {
+            "type": "string",
+            "enum": [
+                "exact",
+                "whitespace",
+                "float",
+                "custom"
+            ],
+            "x-enum-varnames": [
+                "CheckerExact",
+                "CheckerWhitespace",
+                "CheckerFloat",
+                "CheckerCustom"
+            ]
+        },
+        "models.Exam": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
                 },
-                "scores_count": {
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owner": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "owner_id": {
                     "type": "integer"
+                },
+                "score_policy": {
+                    "$ref": "#/definitions/models.ScorePolicy"
+                },
+                "score_policy_top_k": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.GetTopExamScoreResponseData": {
+        "models.ExecutionMetric": {
             "type": "object",
-            "required": [
-                "scores",
-                "scores_count"
-            ],
             "properties": {
-                "scores": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.TopExamScore"
-                    }
+                "cpu_time_ms": {
+                    "type": "integer"
                 },
-                "scores_count": {
+                "exit_code": {
+                    "type": "integer"
+                },
+                "exit_signal": {
+                    "type": "integer"
+                },
+                "memory_kb": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                },
+                "verdict": {
+                    "type": "string"
+                },
+                "wall_time_ms": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.GetTopScoreResponseData": {
+        "models.Extension": {
             "type": "object",
-            "required": [
-                "scores",
-                "scores_count"
-            ],
             "properties": {
-                "scores": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.TopScore"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "scores_count": {
+                "created_by": {
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
+                },
+                "exam_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "limit_multiplier": {
+                    "type": "number"
+                },
+                "question_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.GetUserData": {
+        "models.JudgeJobPriority": {
+            "type": "string",
+            "enum": [
+                "exam",
+                "practice",
+                "rejudge"
+            ],
+            "x-enum-varnames": [
+                "JudgeJobPriorityExam",
+                "JudgeJobPriorityPractice",
+                "JudgeJobPriorityRejudge"
+            ]
+        },
+        "models.JudgeMode": {
+            "type": "string",
+            "enum": [
+                "unit",
+                "io"
+            ],
+            "x-enum-varnames": [
+                "JudgeModeUnit",
+                "JudgeModeIO"
+            ]
+        },
+        "models.LatePolicy": {
+            "type": "string",
+            "enum": [
+                "none",
+                "linear",
+                "step"
+            ],
+            "x-enum-varnames": [
+                "LatePolicyNone",
+                "LatePolicyLinear",
+                "LatePolicyStep"
+            ]
+        },
+        "models.Question": {
             "type": "object",
             "properties": {
-                "email": {
+                "description": {
                     "type": "string"
                 },
-                "enable": {
-                    "type": "boolean"
+                "end_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
                 },
-                "hash": {
+                "git_repo_url": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "is_admin": {
+                "is_active": {
                     "type": "boolean"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "start_time": {
+                    "type": "string",
+                    "example": "2006-01-02T15:04:05Z07:00"
                 },
-                "user_name": {
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "handlers.GetUserQuestionResponseData": {
+        "models.QuestionTestCase": {
             "type": "object",
-            "required": [
-                "description",
-                "git_repo_url",
-                "parent_git_repo_url",
-                "readme",
-                "title",
-                "uqr_id"
-            ],
             "properties": {
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "git_repo_url": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "parent_git_repo_url": {
+                "input": {
                     "type": "string"
                 },
-                "readme": {
+                "memory": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "title": {
+                "ordinal": {
+                    "type": "integer"
+                },
+                "output": {
                     "type": "string"
                 },
-                "uqr_id": {
+                "question_id": {
                     "type": "integer"
-                }
-            }
-        },
-        "handlers.GetUsersQuestionsResponseData": {
-            "type": "object",
-            "required": [
-                "question",
-                "question_count"
-            ],
-            "properties": {
-                "question": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers._GetUsersQuestionsResponseData"
-                    }
                 },
-                "question_count": {
+                "time": {
                     "type": "integer"
-                }
-            }
-        },
-        "handlers.IsPublicRequest": {
-            "type": "object",
-            "properties": {
-                "is_public": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "handlers.LeaderboardScore": {
-            "type": "object",
-            "required": [
-                "question_scores",
-                "total_score",
-                "user_name"
-            ],
-            "properties": {
-                "question_scores": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.QuestionScore"
-                    }
                 },
-                "total_score": {
-                    "type": "number",
-                    "example": 200
+                "updated_at": {
+                    "type": "string"
                 },
-                "user_name": {
-                    "type": "string",
-                    "example": "owner"
+                "weight": {
+                    "type": "number"
                 }
             }
         },
-        "handlers.LoginRequest": {
+        "models.QuestionTestScript": {
             "type": "object",
             "properties": {
-                "password": {
-                    "type": "string",
-                    "example": "password"
-                },
-                "token": {
-                    "description": "Optional token for API access",
-                    "type": "string",
-                    "example": ""
+                "cancel_superseded": {
+                    "type": "boolean"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "username"
-                }
-            }
-        },
-        "handlers.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "access_token": {
-                    "type": "string"
+                "checker": {
+                    "$ref": "#/definitions/models.CheckerType"
                 },
-                "refresh_token": {
+                "checker_script": {
                     "type": "string"
                 },
-                "reset_password": {
+                "coalesce_queued": {
                     "type": "boolean"
-                }
-            }
-        },
-        "handlers.PatchQuestionRequest": {
-            "type": "object",
-            "properties": {
+                },
+                "compile_file_size": {
+                    "type": "integer"
+                },
+                "compile_memory": {
+                    "type": "integer"
+                },
+                "compile_processes": {
+                    "type": "integer"
+                },
                 "compile_script": {
-                    "type": "string",
-                    "example": "script example"
+                    "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "example": "Question Description"
+                "compile_time": {
+                    "type": "integer"
                 },
-                "end_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                "compile_wall_time": {
+                    "type": "integer"
                 },
                 "execute_script": {
-                    "type": "string",
-                    "example": "script example"
+                    "type": "string"
                 },
                 "file_size": {
-                    "type": "integer",
-                    "example": 10240
+                    "type": "integer"
                 },
-                "git_repo_url": {
-                    "type": "string",
-                    "example": "user_name/repo_name"
+                "float_tolerance": {
+                    "type": "number"
                 },
-                "is_active": {
-                    "type": "boolean",
-                    "example": true
+                "id": {
+                    "type": "integer"
                 },
-                "memory": {
-                    "type": "integer",
-                    "example": 262144
+                "judge_mode": {
+                    "$ref": "#/definitions/models.JudgeMode"
                 },
-                "open_files": {
-                    "type": "integer",
-                    "example": 64
+                "judge_timeout": {
+                    "type": "integer"
                 },
-                "processes": {
-                    "type": "integer",
-                    "example": 10
+                "late_cutoff": {
+                    "type": "integer"
                 },
-                "score_map": {
-                    "type": "string",
-                    "example": "score map for task score"
+                "late_grace_period": {
+                    "type": "integer"
                 },
-                "score_script": {
-                    "type": "string",
-                    "example": "script example"
+                "late_penalty": {
+                    "type": "number"
                 },
-                "stack_memory": {
-                    "type": "integer",
-                    "example": 8192
+                "late_penalty_interval": {
+                    "type": "integer"
                 },
-                "start_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                "late_policy": {
+                    "$ref": "#/definitions/models.LatePolicy"
                 },
-                "time": {
-                    "type": "integer",
-                    "example": 1000
+                "max_submits_per_day": {
+                    "type": "integer"
                 },
-                "title": {
-                    "type": "string",
-                    "example": "Question Title"
+                "max_submits_per_hour": {
+                    "type": "integer"
                 },
-                "wall_time": {
-                    "type": "integer",
-                    "example": 3000
-                }
-            }
-        },
-        "handlers.QuestionScore": {
-            "type": "object",
-            "required": [
-                "git_user_repo_url",
-                "question_id",
-                "question_title",
-                "score"
-            ],
-            "properties": {
-                "git_user_repo_url": {
-                    "type": "string",
-                    "example": "owner/repo"
+                "memory": {
+                    "type": "integer"
                 },
-                "question_id": {
-                    "type": "integer",
-                    "example": 1
+                "min_submit_interval": {
+                    "type": "integer"
                 },
-                "question_title": {
-                    "type": "string",
-                    "example": "Two Sum"
+                "open_files": {
+                    "type": "integer"
                 },
-                "score": {
-                    "type": "number",
-                    "example": 100
-                }
-            }
-        },
-        "handlers.QuestionScripts": {
-            "type": "object",
-            "properties": {
-                "compile_script": {
-                    "type": "string",
-                    "example": "script example"
+                "processes": {
+                    "type": "integer"
                 },
-                "execute_script": {
-                    "type": "string",
-                    "example": "script example"
+                "profile": {
+                    "type": "string"
                 },
-                "score_map": {
-                    "type": "string",
-                    "example": "score map for task score"
+                "question": {
+                    "$ref": "#/definitions/models.Question"
+                },
+                "question_id": {
+                    "type": "integer"
                 },
-                "score_script": {
-                    "type": "string",
-                    "example": "script example"
-                }
-            }
-        },
-        "handlers.ResetPasswordRequest": {
-            "type": "object",
-            "required": [
-                "new_password"
-            ],
-            "properties": {
-                "new_password": {
-                    "type": "string",
-                    "minLength": 6
-                }
-            }
-        },
-        "handlers.ResetUserPasswordDTO": {
-            "type": "object",
-            "required": [
-                "password"
-            ],
-            "properties": {
-                "password": {
+                "required_labels": {
                     "type": "string"
-                }
-            }
-        },
-        "handlers.ResponseHTTP": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "message": {
+                },
+                "score_file_size": {
+                    "type": "integer"
+                },
+                "score_map": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "handlers.Sandbox": {
-            "type": "object",
-            "required": [
-                "compilescript",
-                "executescript",
-                "scorescript",
-                "source_git_url"
-            ],
-            "properties": {
-                "compilescript": {
-                    "type": "string",
-                    "example": "#!/bin/bash\n\necho 'Hello, World!'"
+                "score_memory": {
+                    "type": "integer"
                 },
-                "executescript": {
-                    "type": "string",
-                    "example": "#!/bin/bash\n\necho 'Hello, World!'"
+                "score_policy": {
+                    "$ref": "#/definitions/models.ScorePolicy"
                 },
-                "scorescript": {
-                    "type": "string",
-                    "example": "#!/bin/bash\n\necho 'Hello, World!'"
+                "score_policy_top_k": {
+                    "type": "integer"
                 },
-                "source_git_url": {
-                    "type": "string",
-                    "example": "user_name/repo_name"
-                }
-            }
-        },
-        "handlers.Score": {
-            "type": "object",
-            "required": [
-                "judge_time",
-                "message",
-                "score"
-            ],
-            "properties": {
-                "judge_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "score_processes": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Scored successfully"
+                "score_script": {
+                    "type": "string"
                 },
-                "score": {
-                    "type": "number",
-                    "example": 100
-                }
-            }
-        },
-        "handlers.StatusResponse": {
-            "type": "object",
-            "properties": {
-                "available_count": {
+                "score_time": {
                     "type": "integer"
                 },
-                "processing_count": {
+                "score_wall_time": {
                     "type": "integer"
                 },
-                "waiting_count": {
+                "stack_memory": {
+                    "type": "integer"
+                },
+                "submit_quota": {
+                    "type": "integer"
+                },
+                "time": {
+                    "type": "integer"
+                },
+                "wall_time": {
                     "type": "integer"
                 }
             }
         },
-        "handlers.TopExamScore": {
+        "models.RejudgeBatch": {
             "type": "object",
-            "required": [
-                "git_user_repo_url",
-                "judge_time",
-                "message",
-                "point",
-                "question_id",
-                "score"
-            ],
             "properties": {
-                "git_user_repo_url": {
-                    "type": "string",
-                    "example": "owner/repo"
+                "cancelled_at": {
+                    "type": "string"
                 },
-                "judge_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "cancelled_by": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Scored successfully"
+                "created_at": {
+                    "type": "string"
                 },
-                "point": {
-                    "type": "integer",
-                    "example": 100
+                "created_by": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "mode": {
+                    "type": "string"
                 },
                 "question_id": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
                 },
-                "score": {
-                    "type": "number",
-                    "example": 100
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.TopScore": {
+        "models.ScoreOverride": {
             "type": "object",
-            "required": [
-                "git_user_repo_url",
-                "judge_time",
-                "message",
-                "question_id",
-                "question_title",
-                "score"
-            ],
             "properties": {
-                "git_user_repo_url": {
-                    "type": "string",
-                    "example": "owner/repo"
+                "created_at": {
+                    "type": "string"
                 },
-                "judge_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "created_by": {
+                    "type": "integer"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "Scored successfully"
+                "delta": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
                 },
                 "question_id": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
                 },
-                "question_title": {
-                    "type": "string",
-                    "example": "Two Sum"
+                "reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "revoked_by": {
+                    "type": "integer"
                 },
                 "score": {
-                    "type": "number",
-                    "example": 100
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "handlers.UpdateExamRequest": {
-            "type": "object",
-            "required": [
-                "title"
+        "models.ScorePolicy": {
+            "type": "string",
+            "enum": [
+                "best",
+                "last",
+                "last_before_deadline",
+                "top_k_average"
             ],
+            "x-enum-varnames": [
+                "ScorePolicyBest",
+                "ScorePolicyLast",
+                "ScorePolicyLastBeforeDeadline",
+                "ScorePolicyTopKAverage"
+            ]
+        },
+        "models.User": {
+            "type": "object",
             "properties": {
-                "description": {
+                "email": {
                     "type": "string"
                 },
-                "end_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                "enable": {
+                    "type": "boolean"
                 },
-                "start_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z"
+                "forget_password_request_time": {
+                    "type": "string"
                 },
-                "title": {
+                "gitea_token": {
                     "type": "string"
-                }
-            }
-        },
-        "handlers.UpdateUserInfoDTO": {
-            "type": "object",
-            "properties": {
-                "enable": {
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_admin": {
                     "type": "boolean"
                 },
                 "is_public": {
                     "type": "boolean"
+                },
+                "nonce": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "reset_password": {
+                    "type": "boolean"
+                },
+                "user_name": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.WebhookPayload": {
+        "profiles.Limits": {
             "type": "object",
             "properties": {
-                "after": {
-                    "type": "string"
+                "compile_memory": {
+                    "description": "KB",
+                    "type": "integer",
+                    "example": 2097152
                 },
-                "before": {
-                    "type": "string"
+                "compile_processes": {
+                    "type": "integer",
+                    "example": 64
                 },
-                "commits": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/gitea.Commit"
-                    }
+                "compile_time": {
+                    "description": "ms",
+                    "type": "integer",
+                    "example": 60000
                 },
-                "compare_url": {
-                    "type": "string"
+                "compile_wall_time": {
+                    "description": "ms",
+                    "type": "integer",
+                    "example": 120000
+                },
+                "judge_timeout": {
+                    "description": "ms",
+                    "type": "integer",
+                    "example": 150000
                 },
-                "pusher": {
-                    "$ref": "#/definitions/gitea.User"
+                "memory": {
+                    "description": "KB",
+                    "type": "integer",
+                    "example": 262144
                 },
-                "ref": {
-                    "type": "string"
+                "open_files": {
+                    "type": "integer",
+                    "example": 64
                 },
-                "repository": {
-                    "$ref": "#/definitions/gitea.Repository"
+                "processes": {
+                    "type": "integer",
+                    "example": 10
                 },
-                "sender": {
-                    "$ref": "#/definitions/gitea.User"
+                "time": {
+                    "description": "ms",
+                    "type": "integer",
+                    "example": 5000
+                },
+                "wall_time": {
+                    "description": "ms",
+                    "type": "integer",
+                    "example": 10000
                 }
             }
         },
-        "handlers._GetQuestionListQuestionData": {
+        "profiles.Profile": {
             "type": "object",
             "properties": {
-                "description": {
+                "compile_script": {
                     "type": "string"
                 },
-                "end_time": {
+                "description": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                    "example": "CMake project tested with GoogleTest"
                 },
-                "git_repo_url": {
+                "execute_script": {
                     "type": "string"
                 },
-                "has_question": {
-                    "type": "boolean"
-                },
-                "id": {
-                    "type": "integer"
+                "language": {
+                    "type": "string",
+                    "example": "C++"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "limits": {
+                    "$ref": "#/definitions/profiles.Limits"
                 },
-                "start_time": {
+                "name": {
                     "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                    "example": "cpp-gtest"
                 },
-                "title": {
-                    "type": "string"
+                "requires": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "cmake",
+                        "g++"
+                    ]
                 },
-                "top_score": {
-                    "type": "number"
+                "result_format": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/profiles.ResultFormat"
+                        }
+                    ],
+                    "example": "gtest"
+                },
+                "score_script": {
+                    "type": "string"
                 }
             }
         },
-        "handlers._GetUsersQuestionsResponseData": {
-            "type": "object",
-            "required": [
-                "description",
-                "git_repo_url",
-                "parent_git_repo_url",
-                "q_id",
-                "title",
-                "uqr_id"
+        "profiles.ResultFormat": {
+            "type": "string",
+            "enum": [
+                "gtest",
+                "junit",
+                "pytest",
+                "tap"
+            ],
+            "x-enum-comments": {
+                "FormatGTest": "GoogleTest --gtest_output=json",
+                "FormatJUnit": "JUnit XML",
+                "FormatPytest": "pytest-json-report",
+                "FormatTAP": "Test Anything Protocol"
+            },
+            "x-enum-descriptions": [
+                "GoogleTest --gtest_output=json",
+                "JUnit XML",
+                "pytest-json-report",
+                "Test Anything Protocol"
             ],
+            "x-enum-varnames": [
+                "FormatGTest",
+                "FormatJUnit",
+                "FormatPytest",
+                "FormatTAP"
+            ]
+        },
+        "services.JudgeEvent": {
+            "type": "object",
             "properties": {
-                "description": {
+                "message": {
                     "type": "string"
                 },
-                "git_repo_url": {
-                    "type": "string"
+                "score": {
+                    "type": "number",
+                    "example": -1
                 },
-                "parent_git_repo_url": {
-                    "type": "string"
+                "stage": {
+                    "type": "string",
+                    "example": "compile"
                 },
-                "q_id": {
-                    "type": "integer"
+                "status": {
+                    "type": "string",
+                    "example": "SUCCESS"
                 },
-                "title": {
+                "target": {
+                    "type": "string",
+                    "example": "test_add"
+                },
+                "time": {
                     "type": "string"
                 },
-                "uqr_id": {
-                    "type": "integer"
+                "type": {
+                    "type": "string",
+                    "example": "progress"
+                },
+                "uqt_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "verdict": {
+                    "type": "string",
+                    "example": "ACCEPTED"
                 }
             }
         },
-        "handlers.point": {
+        "services.QueuePriorityStats": {
             "type": "object",
-            "required": [
-                "score"
-            ],
             "properties": {
-                "score": {
-                    "type": "integer"
+                "oldest_wait_seconds": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "priority": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.JudgeJobPriority"
+                        }
+                    ],
+                    "example": "exam"
+                },
+                "queued": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "users": {
+                    "type": "integer",
+                    "example": 2
                 }
             }
         },
-        "models.Exam": {
+        "services.SandboxInstanceInfo": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "end_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "active_jobs": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "id": {
-                    "type": "integer"
+                "available_count": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "owner": {
-                    "$ref": "#/definitions/models.User"
+                "capacity": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "owner_id": {
-                    "type": "integer"
+                "connected_at": {
+                    "type": "string"
                 },
-                "start_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "cordoned": {
+                    "type": "boolean"
                 },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.Question": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
+                "draining": {
+                    "type": "boolean"
                 },
-                "end_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "error_rate": {
+                    "type": "number",
+                    "example": 0.025
                 },
-                "git_repo_url": {
-                    "type": "string"
+                "errors": {
+                    "type": "integer",
+                    "example": 3
                 },
                 "id": {
-                    "type": "integer"
+                    "type": "string",
+                    "example": "0b7c6f1e-3a8e-4d0a-9a51-0f6f0c1e2d3a"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "judged": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "start_time": {
-                    "type": "string",
-                    "example": "2006-01-02T15:04:05Z07:00"
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "title": {
+                "last_seen": {
                     "type": "string"
+                },
+                "toolchains": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "string",
+                    "example": "v1.4.0"
                 }
             }
         },
-        "models.QuestionTestScript": {
+        "utils.ExportQuestionScoreResponse": {
             "type": "object",
             "properties": {
-                "compile_script": {
+                "cpu_time_ms": {
+                    "type": "integer"
+                },
+                "earliest_best_submit_time": {
                     "type": "string"
                 },
-                "execute_script": {
+                "git_user_repo_url": {
                     "type": "string"
                 },
-                "file_size": {
-                    "type": "integer"
+                "judged_score": {
+                    "type": "number"
                 },
-                "id": {
-                    "type": "integer"
+                "late_penalty": {
+                    "type": "number"
                 },
-                "memory": {
+                "memory_kb": {
                     "type": "integer"
                 },
-                "open_files": {
-                    "type": "integer"
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExecutionMetric"
+                    }
                 },
-                "processes": {
-                    "type": "integer"
+                "override_reason": {
+                    "type": "string"
                 },
-                "question": {
-                    "$ref": "#/definitions/models.Question"
+                "raw_score": {
+                    "type": "number"
                 },
-                "question_id": {
-                    "type": "integer"
+                "score": {
+                    "type": "number"
                 },
-                "score_map": {
+                "user_name": {
                     "type": "string"
                 },
-                "score_script": {
+                "verdict": {
                     "type": "string"
-                },
-                "stack_memory": {
-                    "type": "integer"
-                },
-                "time": {
-                    "type": "integer"
-                },
-                "wall_time": {
-                    "type": "integer"
                 }
             }
         },
-        "models.User": {
+        "utils.RejudgeReportRow": {
             "type": "object",
             "properties": {
-                "email": {
+                "commit": {
                     "type": "string"
                 },
-                "enable": {
-                    "type": "boolean"
+                "delta": {
+                    "type": "number"
                 },
-                "forget_password_request_time": {
+                "git_user_repo_url": {
                     "type": "string"
                 },
-                "gitea_token": {
-                    "type": "string"
+                "new_score": {
+                    "type": "number"
                 },
-                "id": {
+                "new_uqt_id": {
                     "type": "integer"
                 },
-                "is_admin": {
-                    "type": "boolean"
+                "old_score": {
+                    "type": "number"
                 },
-                "is_public": {
-                    "type": "boolean"
+                "old_uqt_id": {
+                    "type": "integer"
                 },
-                "nonce": {
-                    "type": "string"
+                "test_suites": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.RejudgeTestSuiteDelta"
+                    }
                 },
-                "refresh_token": {
+                "user_name": {
                     "type": "string"
                 },
-                "reset_password": {
-                    "type": "boolean"
-                },
-                "user_name": {
+                "verdict": {
                     "type": "string"
                 }
             }
         },
-        "utils.ExportQuestionScoreResponse": {
+        "utils.RejudgeTestSuiteDelta": {
             "type": "object",
             "properties": {
-                "earliest_best_submit_time": {
-                    "type": "string"
+                "delta": {
+                    "type": "number"
                 },
-                "git_user_repo_url": {
+                "name": {
                     "type": "string"
                 },
-                "score": {
+                "new_score": {
                     "type": "number"
                 },
-                "user_name": {
-                    "type": "string"
+                "old_score": {
+                    "type": "number"
                 }
             }
         }
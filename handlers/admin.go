@@ -3,6 +3,7 @@ package handlers
 import (
 	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -14,6 +15,8 @@ import (
 	"OJ-API/config"
 	"OJ-API/database"
 	"OJ-API/models"
+	"OJ-API/sandbox"
+	"OJ-API/services"
 	"OJ-API/utils"
 )
 
@@ -336,7 +339,7 @@ func UpdateUserInfo(c *gin.Context) {
 
 // Export Question Score
 // @Summary Export question score
-// @Description Export question score to CSV, XLSX, or JSON
+// @Description Export question score to CSV, XLSX, or JSON, with the verdict and resource usage of each user's best submission
 // @Tags admin
 // @Accept json
 // @Produce json
@@ -382,14 +385,16 @@ func ExportQuestionScore(c *gin.Context) {
 		return
 	}
 
-	// Fetch question scores with earliest submit time for highest score
+	// Fetch the final score of each user resolved by the score policy of the question and
+	// any score override, and the resource usage of the submission it comes from
 	var scores []utils.ExportQuestionScoreResponse
 	if err := db.Table("user_question_relations UQR").
-		Select("U.user_name as user_name, UQR.git_user_repo_url as git_user_repo_url, COALESCE(MAX(UQT.score), 0) AS score, MIN(CASE WHEN UQT.score = (SELECT MAX(score) FROM user_question_tables WHERE uqr_id = UQR.id) THEN UQT.created_at END) AS earliest_best_submit_time").
+		Select("U.user_name as user_name, UQR.git_user_repo_url as git_user_repo_url, COALESCE(FS.score, 0) AS score, COALESCE(FS.judged_score, 0) AS judged_score, COALESCE(O.reason, '') AS override_reason, FS.submitted_at AS earliest_best_submit_time, COALESCE(B.id, 0) AS best_uqt_id, COALESCE(B.verdict, '') AS verdict, COALESCE(B.cpu_time_ms, 0) AS cpu_time_ms, COALESCE(B.memory_kb, 0) AS memory_kb, COALESCE(B.raw_score, 0) AS raw_score, COALESCE(B.late_penalty, 0) AS late_penalty").
 		Where("UQR.question_id = ? AND U.is_admin = false", question.ID).
 		Joins("JOIN users U ON U.id = UQR.user_id").
-		Joins("LEFT JOIN user_question_tables UQT ON UQT.uqr_id = UQR.id").
-		Group("U.user_name, UQR.git_user_repo_url").
+		Joins("LEFT JOIN (?) FS ON FS.uqr_id = UQR.id", services.FinalScores(db, db.Model(&models.Question{}).Select("id").Where("id = ?", question.ID))).
+		Joins("LEFT JOIN user_question_tables B ON B.id = FS.uqt_id").
+		Joins("LEFT JOIN score_overrides O ON O.id = FS.override_id").
 		Find(&scores).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ResponseHTTP{
 			Success: false,
@@ -398,6 +403,31 @@ func ExportQuestionScore(c *gin.Context) {
 		return
 	}
 
+	// Attach per-target metrics of the best submissions
+	bestIDs := make([]uint, 0, len(scores))
+	for _, score := range scores {
+		if score.BestUQTID != 0 {
+			bestIDs = append(bestIDs, score.BestUQTID)
+		}
+	}
+	if len(bestIDs) > 0 {
+		var metrics []models.ExecutionMetric
+		if err := db.Where("uqt_id IN ?", bestIDs).Order("id").Find(&metrics).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, ResponseHTTP{
+				Success: false,
+				Message: "Failed to fetch execution metrics",
+			})
+			return
+		}
+		byUQT := make(map[uint][]models.ExecutionMetric)
+		for _, m := range metrics {
+			byUQT[m.UQTID] = append(byUQT[m.UQTID], m)
+		}
+		for i := range scores {
+			scores[i].Metrics = byUQT[scores[i].BestUQTID]
+		}
+	}
+
 	switch format {
 	case "csv":
 		// Generate CSV
@@ -407,7 +437,7 @@ func ExportQuestionScore(c *gin.Context) {
 		writer := csv.NewWriter(&csvData)
 
 		// Write CSV header
-		writer.Write([]string{"User Name", "Git User Repo URL", "Score", "Earliest Best Submit Time"})
+		writer.Write([]string{"User Name", "Git User Repo URL", "Score", "Judged Score", "Raw Score", "Late Penalty (%)", "Override Reason", "Earliest Best Submit Time", "Verdict", "CPU Time (ms)", "Peak Memory (KB)", "Targets"})
 
 		// Write CSV rows
 		for _, score := range scores {
@@ -415,7 +445,15 @@ func ExportQuestionScore(c *gin.Context) {
 				score.UserName,
 				score.GitUserRepoURL,
 				strconv.FormatFloat(score.Score, 'f', 2, 64),
+				strconv.FormatFloat(score.JudgedScore, 'f', 2, 64),
+				strconv.FormatFloat(score.RawScore, 'f', 2, 64),
+				strconv.FormatFloat(score.LatePenalty, 'f', 2, 64),
+				score.OverrideReason,
 				score.EarliestBestSubmitTime.Format("2006-01-02 15:04:05"),
+				score.Verdict,
+				strconv.FormatInt(score.CPUTimeMs, 10),
+				strconv.FormatInt(score.MemoryKB, 10),
+				utils.FormatExecutionMetrics(score.Metrics),
 			})
 		}
 
@@ -555,3 +593,175 @@ func ChangeUserEmail(c *gin.Context) {
 		Message: "User email updated successfully",
 	})
 }
+
+// Export Rejudge Report
+// @Summary Export rejudge report
+// @Description Export the score changes of a finished rejudge batch to CSV, XLSX, or JSON. Each rejudged submission is compared with the best earlier judged submission of the user, per test suite of the AllTests result.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Rejudge batch ID"
+// @Param format query string false "Export format: csv, xlsx, or json" default(json)
+// @Success 200 {object} ResponseHTTP{data=[]utils.RejudgeReportRow}
+// @Success 200 {file} application/csv
+// @Success 200 {file} application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Failure 400
+// @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /api/admin/rejudge/{id}/export [get]
+// @Security BearerAuth
+func ExportRejudgeReport(c *gin.Context) {
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(403, ResponseHTTP{
+			Success: false,
+			Message: "Permission denied",
+		})
+		return
+	}
+	db := database.DBConn
+	id := c.Param("id")
+	format := c.DefaultQuery("format", "json")
+
+	// Validate format parameter
+	if format != "csv" && format != "xlsx" && format != "json" {
+		c.JSON(http.StatusBadRequest, ResponseHTTP{
+			Success: false,
+			Message: "Invalid format. Supported formats: csv, xlsx, json",
+		})
+		return
+	}
+
+	var batch models.RejudgeBatch
+	if err := db.First(&batch, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, ResponseHTTP{
+			Success: false,
+			Message: "Rejudge batch not found",
+		})
+		return
+	}
+
+	// The report compares final results, wait until nothing is pending
+	var pending int64
+	if err := db.Model(&models.UserQuestionTable{}).
+		Where("rejudge_batch_id = ? AND score IN ?", batch.ID, []float64{-3, -1}).
+		Count(&pending).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch rejudge batch progress",
+		})
+		return
+	}
+	if pending > 0 {
+		c.JSON(http.StatusConflict, ResponseHTTP{
+			Success: false,
+			Message: fmt.Sprintf("Rejudge batch is still running, %d submissions pending", pending),
+		})
+		return
+	}
+
+	// Compare each rejudged submission with the best judged submission of the user before it
+	var rows []utils.RejudgeReportRow
+	if err := db.Table("user_question_tables UQT").
+		Select("U.user_name, UQR.git_user_repo_url, UQT.commit, COALESCE(OLD.id, 0) AS old_uqt_id, UQT.id AS new_uqt_id, COALESCE(OLD.score, 0) AS old_score, GREATEST(UQT.score, 0) AS new_score, GREATEST(UQT.score, 0) - COALESCE(OLD.score, 0) AS delta, UQT.verdict, COALESCE(OLD.message, '') AS old_message, UQT.message AS new_message").
+		Joins("JOIN user_question_relations UQR ON UQR.id = UQT.uqr_id").
+		Joins("JOIN users U ON U.id = UQR.user_id").
+		Joins(`LEFT JOIN LATERAL (
+			SELECT P.id, P.score, P.message FROM user_question_tables P
+			WHERE P.uqr_id = UQT.uqr_id AND P.id < UQT.id AND P.score >= 0
+				AND P.rejudge_batch_id IS DISTINCT FROM UQT.rejudge_batch_id
+			ORDER BY P.score DESC, P.id ASC LIMIT 1
+		) OLD ON TRUE`).
+		Where("UQT.rejudge_batch_id = ?", batch.ID).
+		Order("U.user_name, UQT.id").
+		Scan(&rows).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch rejudge report",
+		})
+		return
+	}
+	for i := range rows {
+		rows[i].TestSuites = testSuiteDeltas(rows[i].OldMessage, rows[i].NewMessage)
+	}
+
+	switch format {
+	case "csv":
+		var csvData bytes.Buffer
+		// Add UTF-8 BOM for proper encoding
+		csvData.Write([]byte{0xEF, 0xBB, 0xBF})
+		writer := csv.NewWriter(&csvData)
+
+		writer.Write([]string{"User Name", "Git User Repo URL", "Commit", "Old Score", "New Score", "Delta", "Verdict", "Test Suite Changes"})
+		for _, row := range rows {
+			writer.Write([]string{
+				row.UserName,
+				row.GitUserRepoURL,
+				row.Commit,
+				strconv.FormatFloat(row.OldScore, 'f', 2, 64),
+				strconv.FormatFloat(row.NewScore, 'f', 2, 64),
+				strconv.FormatFloat(row.Delta, 'f', 2, 64),
+				row.Verdict,
+				utils.FormatTestSuiteDeltas(row.TestSuites),
+			})
+		}
+		writer.Flush()
+
+		c.Header("Content-Type", "application/csv")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"rejudge_%d_%s.csv\"", batch.ID, time.Now().Format("20060102_150405")))
+		c.String(http.StatusOK, csvData.String())
+	case "xlsx":
+		if err := utils.ExportRejudgeReportToXLSX(c, batch.ID, rows); err != nil {
+			c.JSON(http.StatusInternalServerError, ResponseHTTP{
+				Success: false,
+				Message: "Failed to generate XLSX file",
+			})
+			return
+		}
+	default:
+		c.JSON(http.StatusOK, ResponseHTTP{
+			Success: true,
+			Data:    rows,
+			Message: "Rejudge report retrieved successfully",
+		})
+	}
+}
+
+// testSuiteDeltas compares the test suite scores of two AllTests results, a message
+// that is not an AllTests result counts as no test suites
+func testSuiteDeltas(oldMessage, newMessage string) []utils.RejudgeTestSuiteDelta {
+	var oldTests, newTests sandbox.AllTests
+	json.Unmarshal([]byte(oldMessage), &oldTests)
+	json.Unmarshal([]byte(newMessage), &newTests)
+
+	oldScores := make(map[string]float64, len(oldTests.TestSuites))
+	for _, suite := range oldTests.TestSuites {
+		oldScores[suite.Name] = suite.GetScore
+	}
+
+	deltas := make([]utils.RejudgeTestSuiteDelta, 0, len(newTests.TestSuites))
+	for _, suite := range newTests.TestSuites {
+		old := oldScores[suite.Name]
+		delete(oldScores, suite.Name)
+		deltas = append(deltas, utils.RejudgeTestSuiteDelta{
+			Name:     suite.Name,
+			OldScore: old,
+			NewScore: suite.GetScore,
+			Delta:    suite.GetScore - old,
+		})
+	}
+	// Suites that no longer exist lost all their points
+	for _, suite := range oldTests.TestSuites {
+		if old, ok := oldScores[suite.Name]; ok {
+			deltas = append(deltas, utils.RejudgeTestSuiteDelta{
+				Name:     suite.Name,
+				OldScore: old,
+				Delta:    -old,
+			})
+		}
+	}
+	return deltas
+}
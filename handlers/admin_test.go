@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"reflect"
+	"testing"
+
+	"OJ-API/utils"
+)
+
+func TestTestSuiteDeltas(t *testing.T) {
+	const (
+		sumAndProduct = `{"name": "AllTests", "testsuites": [
+			{"name": "Sum", "maxscore": 50, "getscore": 50},
+			{"name": "Product", "maxscore": 50, "getscore": 25}
+		]}`
+		sumAndPower = `{"name": "AllTests", "testsuites": [
+			{"name": "Sum", "maxscore": 50, "getscore": 40},
+			{"name": "Power", "maxscore": 50, "getscore": 50}
+		]}`
+	)
+
+	cases := []struct {
+		name       string
+		oldMessage string
+		newMessage string
+		want       []utils.RejudgeTestSuiteDelta
+	}{
+		{
+			name:       "same suites",
+			oldMessage: sumAndProduct,
+			newMessage: sumAndProduct,
+			want: []utils.RejudgeTestSuiteDelta{
+				{Name: "Sum", OldScore: 50, NewScore: 50, Delta: 0},
+				{Name: "Product", OldScore: 25, NewScore: 25, Delta: 0},
+			},
+		},
+		{
+			name:       "changed, added and removed suites",
+			oldMessage: sumAndProduct,
+			newMessage: sumAndPower,
+			want: []utils.RejudgeTestSuiteDelta{
+				{Name: "Sum", OldScore: 50, NewScore: 40, Delta: -10},
+				{Name: "Power", OldScore: 0, NewScore: 50, Delta: 50},
+				{Name: "Product", OldScore: 25, NewScore: 0, Delta: -25},
+			},
+		},
+		{
+			name:       "old result was a compile error",
+			oldMessage: "main.cpp:1:1: error: expected unqualified-id",
+			newMessage: sumAndPower,
+			want: []utils.RejudgeTestSuiteDelta{
+				{Name: "Sum", OldScore: 0, NewScore: 40, Delta: 40},
+				{Name: "Power", OldScore: 0, NewScore: 50, Delta: 50},
+			},
+		},
+		{
+			name:       "new result was a compile error",
+			oldMessage: sumAndProduct,
+			newMessage: "Compile error",
+			want: []utils.RejudgeTestSuiteDelta{
+				{Name: "Sum", OldScore: 50, NewScore: 0, Delta: -50},
+				{Name: "Product", OldScore: 25, NewScore: 0, Delta: -25},
+			},
+		},
+		{
+			name:       "no test suites",
+			oldMessage: "",
+			newMessage: "",
+			want:       []utils.RejudgeTestSuiteDelta{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := testSuiteDeltas(tc.oldMessage, tc.newMessage); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("testSuiteDeltas() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
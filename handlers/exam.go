@@ -10,6 +10,7 @@ import (
 
 	"OJ-API/database"
 	"OJ-API/models"
+	"OJ-API/services"
 	"OJ-API/utils"
 )
 
@@ -18,6 +19,9 @@ type ExamRequest struct {
 	Description string    `json:"description"`
 	StartTime   time.Time `json:"start_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
 	EndTime     time.Time `json:"end_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
+
+	ScorePolicy     string `json:"score_policy" example:"last_before_deadline" description:"Final score policy for all questions of the exam, empty to use the policy of each question"`
+	ScorePolicyTopK *uint  `json:"score_policy_top_k" example:"1" description:"Number of best scores averaged by top_k_average"`
 }
 
 // CreateExam handles the creation of a new exam
@@ -61,6 +65,17 @@ func CreateExam(c *gin.Context) {
 		})
 		return
 	}
+	scorePolicyTopK := uint(1)
+	if exam.ScorePolicyTopK != nil {
+		scorePolicyTopK = *exam.ScorePolicyTopK
+	}
+	if msg := validateScorePolicy(models.ScorePolicy(exam.ScorePolicy), scorePolicyTopK); msg != "" {
+		c.JSON(http.StatusBadRequest, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
 
 	db := database.DBConn
 	newExam := models.Exam{
@@ -69,6 +84,9 @@ func CreateExam(c *gin.Context) {
 		StartTime:   exam.StartTime,
 		EndTime:     exam.EndTime,
 		OwnerID:     jwtClaims.UserID,
+
+		ScorePolicy:     models.ScorePolicy(exam.ScorePolicy),
+		ScorePolicyTopK: scorePolicyTopK,
 	}
 	if err := db.Create(&newExam).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ResponseHTTP{
@@ -127,6 +145,9 @@ type UpdateExamRequest struct {
 	Description string    `json:"description"`
 	StartTime   time.Time `json:"start_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
 	EndTime     time.Time `json:"end_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
+
+	ScorePolicy     *string `json:"score_policy" example:"last_before_deadline" description:"Final score policy for all questions of the exam, empty to use the policy of each question"`
+	ScorePolicyTopK *uint   `json:"score_policy_top_k" example:"1" description:"Number of best scores averaged by top_k_average"`
 }
 
 // UpdateExam updates an existing exam
@@ -193,6 +214,19 @@ func UpdateExam(c *gin.Context) {
 	if !exam.EndTime.IsZero() {
 		existingExam.EndTime = exam.EndTime
 	}
+	if exam.ScorePolicy != nil {
+		existingExam.ScorePolicy = models.ScorePolicy(*exam.ScorePolicy)
+	}
+	if exam.ScorePolicyTopK != nil {
+		existingExam.ScorePolicyTopK = *exam.ScorePolicyTopK
+	}
+	if msg := validateScorePolicy(existingExam.ScorePolicy, existingExam.ScorePolicyTopK); msg != "" {
+		c.JSON(http.StatusBadRequest, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
 
 	if err := db.Save(&existingExam).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, ResponseHTTP{
@@ -513,6 +547,8 @@ type ExamInfoResponse struct {
 	ExamDescription string    `json:"exam_description"`
 	ExamStartTime   time.Time `json:"exam_start_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
 	ExamEndTime     time.Time `json:"exam_end_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
+
+	ExamExtendedEndTime *time.Time `json:"exam_extended_end_time,omitempty" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
 }
 
 // GetExamInfo retrieves basic information about an exam
@@ -543,10 +579,24 @@ func GetExamInfo(c *gin.Context) {
 	jwtClaims, _ := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
 	isAdmin := jwtClaims != nil && jwtClaims.IsAdmin
 
+	// The end time may be extended for the current user
+	var extendedEndTime *time.Time
+	if jwtClaims != nil {
+		var extension models.Extension
+		if err := db.Where("user_id = ? AND exam_id = ? AND end_time IS NOT NULL", jwtClaims.UserID, exam.ID).
+			Take(&extension).Error; err == nil {
+			extendedEndTime = extension.EndTime
+		}
+	}
+
 	// Non-admin users can only view ongoing exams
 	if !isAdmin {
 		now := time.Now()
-		if now.Before(exam.StartTime) || now.After(exam.EndTime) {
+		endTime := exam.EndTime
+		if extendedEndTime != nil {
+			endTime = *extendedEndTime
+		}
+		if now.Before(exam.StartTime) || now.After(endTime) {
 			c.JSON(http.StatusForbidden, ResponseHTTP{
 				Success: false,
 				Message: "Exam is not currently available",
@@ -562,6 +612,8 @@ func GetExamInfo(c *gin.Context) {
 			ExamDescription: exam.Description,
 			ExamStartTime:   exam.StartTime,
 			ExamEndTime:     exam.EndTime,
+
+			ExamExtendedEndTime: extendedEndTime,
 		},
 	})
 }
@@ -837,6 +889,7 @@ type EnhancedLeaderboardScore struct {
 	UserName       string                  `json:"user_name"`
 	TotalScore     float64                 `json:"total_score"`
 	QuestionScores []EnhancedQuestionScore `json:"question_scores"`
+	InProgress     bool                    `json:"in_progress" description:"The user still has time left on an extended deadline"`
 }
 
 type EnhancedGetLeaderboardResponseData struct {
@@ -882,31 +935,22 @@ func GetExamLeaderboard(c *gin.Context) {
 		return
 	}
 
+	// Final scores of the exam questions, resolved by the score policy of the exam or the questions
+	questionIDs := db.Model(&models.ExamQuestion{}).
+		Select("question_id").
+		Where("exam_id = ?", id)
+	subquery := db.Table("(?) AS fs", services.FinalScores(db, questionIDs)).
+		Select("fs.user_id, fs.question_id, fs.uqr_id, fs.submitted_at AS created_at, GREATEST(fs.score, 0) AS max_score").
+		Joins("JOIN questions Q ON Q.id = fs.question_id").
+		Joins("JOIN users ON users.id = fs.user_id").
+		Where("Q.is_active = ?", true).
+		Where("users.is_admin = ?", false)
+
 	// Get total count of users who have scores for this exam
 	var totalCount int64
-	subquery := db.Raw(`
-	SELECT DISTINCT ON (UQR.user_id, UQR.question_id)
-           UQR.user_id AS user_id,
-           UQR.question_id,
-           uqt.created_at,
-           GREATEST(uqt.score, 0) AS max_score
-    FROM user_question_tables uqt
-    JOIN user_question_relations UQR ON uqt.uqr_id = UQR.id
-    JOIN questions Q ON UQR.question_id = Q.id
-    JOIN users ON users.id = UQR.user_id
-    WHERE Q.is_active = TRUE
-      AND UQR.question_id IN (
-			SELECT eq.question_id FROM exam_questions eq WHERE eq.exam_id = ?
-		)
-      AND users.is_admin = FALSE
-    ORDER BY UQR.user_id, UQR.question_id, uqt.score DESC, uqt.created_at ASC
-	`, id)
-	if err := db.Table("(SELECT DISTINCT user_id FROM user_question_relations "+
-		"JOIN user_question_tables ON user_question_relations.id = user_question_tables.uqr_id "+
-		"JOIN exam_questions ON user_question_relations.question_id = exam_questions.question_id "+
-		"JOIN users ON user_question_relations.user_id = users.id "+
-		"WHERE exam_questions.exam_id = ? AND users.is_admin = false ) AS t", id).
-		Count(&totalCount).Error; err != nil {
+	if err := db.Table("(?) AS t", subquery).
+		Select("COUNT(DISTINCT user_id)").
+		Scan(&totalCount).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
 			Message: "Failed to count users with scores",
@@ -964,19 +1008,12 @@ func GetExamLeaderboard(c *gin.Context) {
 	}
 
 	var questionScores []QuestionScoreDetail
-	subquery2 := db.Model(&models.UserQuestionTable{}).
-		Select("UQR.user_id, UQR.question_id, GREATEST(MAX(user_question_tables.score), 0) AS score, MAX(UQR.git_user_repo_url) AS git_user_repo_url, MAX(EQ.point) AS point").
-		Joins("JOIN user_question_relations UQR ON user_question_tables.uqr_id = UQR.id").
-		Joins("JOIN exam_questions EQ ON UQR.question_id = EQ.question_id").
-		Joins("JOIN questions Q ON UQR.question_id = Q.id").
-		Where("Q.is_active = ?", true).
-		Where("UQR.user_id IN ?", userIDs).
-		Where("EQ.exam_id = ?", id).
-		Group("UQR.user_id, UQR.question_id")
-
-	if err := db.Table("(?) AS sq", subquery2).
+	if err := db.Table("(?) AS sq", subquery).
 		Joins("JOIN questions ON questions.id = sq.question_id").
-		Select("sq.user_id, sq.question_id, questions.title AS question_title, sq.git_user_repo_url, sq.score, sq.point, (sq.score / 100 * sq.point) AS weighted_score").
+		Joins("JOIN user_question_relations UQR ON UQR.id = sq.uqr_id").
+		Joins("JOIN exam_questions EQ ON EQ.question_id = sq.question_id AND EQ.exam_id = ?", id).
+		Select("sq.user_id, sq.question_id, questions.title AS question_title, UQR.git_user_repo_url, sq.max_score AS score, EQ.point, (sq.max_score / 100 * EQ.point) AS weighted_score").
+		Where("sq.user_id IN ?", userIDs).
 		Find(&questionScores).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
@@ -984,6 +1021,17 @@ func GetExamLeaderboard(c *gin.Context) {
 		})
 		return
 	}
+
+	// Users still working on an extended deadline
+	inProgress, err := usersWithRunningExtension(db, userIDs, questionIDs)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to get deadline extensions",
+		})
+		return
+	}
+
 	// Map to organize question scores by user
 	userQuestionScores := make(map[uint][]EnhancedQuestionScore)
 	for _, qs := range questionScores {
@@ -1013,6 +1061,7 @@ func GetExamLeaderboard(c *gin.Context) {
 			UserName:       userName,
 			TotalScore:     user.TotalScore,
 			QuestionScores: userQuestionScores[user.UserID],
+			InProgress:     inProgress[user.UserID],
 		})
 	}
 
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"OJ-API/database"
+	"OJ-API/models"
+	"OJ-API/services"
+	"OJ-API/utils"
+)
+
+type ExtensionData struct {
+	models.Extension
+	UserName string `json:"user_name" example:"student"`
+}
+
+// ListExtensions is a function to list deadline extensions
+//
+//	@Summary		List deadline extensions
+//	@Description	List the per-user deadline extensions and accommodations, optionally filtered by user, question or exam
+//	@Tags			Extension
+//	@Accept			json
+//	@Produce		json
+//	@Param			user_id		query	int	false	"filter by user ID"
+//	@Param			question_id	query	int	false	"filter by question ID"
+//	@Param			exam_id		query	int	false	"filter by exam ID"
+//	@Success		200	{object}	ResponseHTTP{data=[]ExtensionData}
+//	@Failure		401
+//	@Failure		503
+//	@Router			/api/extensions/admin [get]
+//	@Security		BearerAuth
+func ListExtensions(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	query := db.Model(&models.Extension{}).
+		Select("extensions.*, users.user_name").
+		Joins("JOIN users ON users.id = extensions.user_id")
+	for _, filter := range []string{"user_id", "question_id", "exam_id"} {
+		if value := c.Query(filter); value != "" {
+			query = query.Where("extensions."+filter+" = ?", value)
+		}
+	}
+
+	var extensions []ExtensionData
+	if err := query.Order("extensions.id").Scan(&extensions).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch extensions",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Extensions fetched successfully",
+		Data:    extensions,
+	})
+}
+
+type PutExtensionRequest struct {
+	UserID          uint       `json:"user_id" validate:"required" example:"1"`
+	QuestionID      *uint      `json:"question_id" example:"1" description:"Question to extend, exclusive with exam_id"`
+	ExamID          *uint      `json:"exam_id" example:"1" description:"Exam to extend, applies to all of its questions"`
+	EndTime         *time.Time `json:"end_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339" description:"End time for this user, omit to keep the original end time"`
+	LimitMultiplier *float64   `json:"limit_multiplier" example:"1.5" description:"Multiplier applied to the time limits when judging this user's submissions"`
+	Reason          string     `json:"reason" example:"Accommodation"`
+}
+
+// PutExtension is a function to create or replace a deadline extension
+//
+//	@Summary		Create or replace a deadline extension
+//	@Description	Give a user a new end time and/or extra time on the judge limits for a question or an exam. An existing extension for the same user and question or exam is replaced.
+//	@Tags			Extension
+//	@Accept			json
+//	@Produce		json
+//	@Param			extension	body	PutExtensionRequest	true	"Extension"
+//	@Success		200	{object}	ResponseHTTP{data=models.Extension}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/extensions/admin [put]
+//	@Security		BearerAuth
+func PutExtension(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req PutExtensionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Failed to parse request",
+		})
+		return
+	}
+	if (req.QuestionID == nil) == (req.ExamID == nil) {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Exactly one of question_id and exam_id is required",
+		})
+		return
+	}
+	if req.EndTime == nil && req.LimitMultiplier == nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Either end_time or limit_multiplier is required",
+		})
+		return
+	}
+	multiplier := 1.0
+	if req.LimitMultiplier != nil {
+		multiplier = *req.LimitMultiplier
+	}
+	if multiplier < 1 || multiplier > 10 {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Limit multiplier must be between 1 and 10",
+		})
+		return
+	}
+
+	if err := db.First(&models.User{}, req.UserID).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "User not found",
+		})
+		return
+	}
+	target := db.Where("user_id = ?", req.UserID)
+	if req.QuestionID != nil {
+		if err := db.First(&models.Question{}, *req.QuestionID).Error; err != nil {
+			c.JSON(404, ResponseHTTP{
+				Success: false,
+				Message: "Question not found",
+			})
+			return
+		}
+		target = target.Where("question_id = ?", *req.QuestionID)
+	} else {
+		if err := db.First(&models.Exam{}, *req.ExamID).Error; err != nil {
+			c.JSON(404, ResponseHTTP{
+				Success: false,
+				Message: "Exam not found",
+			})
+			return
+		}
+		target = target.Where("exam_id = ?", *req.ExamID)
+	}
+
+	var extension models.Extension
+	if err := target.Take(&extension).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch extension",
+		})
+		return
+	}
+	extension.UserID = req.UserID
+	extension.QuestionID = req.QuestionID
+	extension.ExamID = req.ExamID
+	extension.EndTime = req.EndTime
+	extension.LimitMultiplier = multiplier
+	extension.Reason = req.Reason
+	extension.CreatedBy = jwtClaims.UserID
+	if err := db.Save(&extension).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to save extension",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Extension saved successfully",
+		Data:    extension,
+	})
+}
+
+// DeleteExtension is a function to delete a deadline extension
+//
+//	@Summary		Delete a deadline extension
+//	@Description	Delete a deadline extension, the user falls back to the original end time and limits
+//	@Tags			Extension
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"extension ID"
+//	@Success		200	{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/extensions/admin/{id} [delete]
+//	@Security		BearerAuth
+func DeleteExtension(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid extension ID",
+		})
+		return
+	}
+
+	result := db.Delete(&models.Extension{}, id)
+	if result.Error != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to delete extension",
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Extension not found",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Extension deleted successfully",
+	})
+}
+
+// usersWithRunningExtension returns which of the users still have time left on one of
+// the given questions only because their end time was extended
+func usersWithRunningExtension(db *gorm.DB, userIDs []uint, questionIDs *gorm.DB) (map[uint]bool, error) {
+	now := time.Now().UTC()
+	var ids []uint
+	if err := db.Table("user_question_relations UQR").
+		Joins("JOIN questions ON questions.id = UQR.question_id").
+		Where("UQR.user_id IN ? AND UQR.question_id IN (?)", userIDs, questionIDs).
+		Where("questions.end_time < ? AND "+services.EffectiveEndTimeSQL("UQR.user_id", "questions")+" >= ?", now, now).
+		Distinct().Pluck("UQR.user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	running := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		running[id] = true
+	}
+	return running, nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"math"
+	"time"
+
+	"OJ-API/models"
+)
+
+// validateLatePolicy returns an error message if the late policy of the script is invalid
+func validateLatePolicy(script *models.QuestionTestScript) string {
+	switch script.LatePolicy {
+	case models.LatePolicyNone:
+	case models.LatePolicyLinear, models.LatePolicyStep:
+		if script.LatePenalty <= 0 || script.LatePenalty > 100 {
+			return "Late penalty must be between 0 and 100 percent"
+		}
+		if script.LatePenaltyInterval == 0 {
+			return "Late penalty interval must be greater than 0"
+		}
+	default:
+		return "Unknown late policy"
+	}
+	return ""
+}
+
+// latePenalty returns the percentage deducted from a submission pushed at now,
+// and false if the push is no longer accepted.
+//
+// Pushes within the grace period after EndTime are not penalized. After that the
+// penalty grows by LatePenalty percent per LatePenaltyInterval minutes, either
+// continuously (linear) or per started interval (step), until the late cutoff
+// or until nothing can be scored any more.
+func latePenalty(question *models.Question, script *models.QuestionTestScript, now time.Time) (float64, bool) {
+	if question.EndTime.IsZero() || !now.After(question.EndTime) {
+		return 0, true
+	}
+
+	late := now.Sub(question.EndTime)
+	grace := time.Duration(script.LateGracePeriod) * time.Minute
+	if late <= grace {
+		return 0, true
+	}
+	if script.LatePolicy != models.LatePolicyLinear && script.LatePolicy != models.LatePolicyStep {
+		return 0, false
+	}
+	if script.LateCutoff > 0 && late > time.Duration(script.LateCutoff)*time.Minute {
+		return 0, false
+	}
+
+	intervals := float64(late-grace) / float64(time.Duration(script.LatePenaltyInterval)*time.Minute)
+	if script.LatePolicy == models.LatePolicyStep {
+		intervals = math.Ceil(intervals)
+	}
+	penalty := intervals * script.LatePenalty
+	if penalty >= 100 {
+		return 0, false
+	}
+	return math.Round(penalty*100) / 100, true
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"OJ-API/models"
+)
+
+func TestLatePenalty(t *testing.T) {
+	deadline := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	linear := models.QuestionTestScript{LatePolicy: models.LatePolicyLinear, LatePenalty: 10, LatePenaltyInterval: 60}
+	step := models.QuestionTestScript{LatePolicy: models.LatePolicyStep, LatePenalty: 10, LatePenaltyInterval: 60}
+
+	cases := []struct {
+		name         string
+		endTime      time.Time
+		script       models.QuestionTestScript
+		late         time.Duration
+		wantPenalty  float64
+		wantAccepted bool
+	}{
+		{name: "before the deadline", endTime: deadline, script: linear, late: -time.Minute, wantAccepted: true},
+		{name: "no deadline", script: models.QuestionTestScript{LatePolicy: models.LatePolicyNone}, late: time.Hour, wantAccepted: true},
+		{
+			name:         "within the grace period",
+			endTime:      deadline,
+			script:       models.QuestionTestScript{LatePolicy: models.LatePolicyNone, LateGracePeriod: 10},
+			late:         5 * time.Minute,
+			wantAccepted: true,
+		},
+		{
+			name:    "late without a late policy",
+			endTime: deadline,
+			script:  models.QuestionTestScript{LatePolicy: models.LatePolicyNone, LateGracePeriod: 10},
+			late:    11 * time.Minute,
+		},
+		{name: "linear", endTime: deadline, script: linear, late: 90 * time.Minute, wantPenalty: 15, wantAccepted: true},
+		{name: "linear is rounded to two decimals", endTime: deadline, script: linear, late: 7 * time.Minute, wantPenalty: 1.17, wantAccepted: true},
+		{
+			name:         "linear counts from the end of the grace period",
+			endTime:      deadline,
+			script:       models.QuestionTestScript{LatePolicy: models.LatePolicyLinear, LatePenalty: 10, LatePenaltyInterval: 60, LateGracePeriod: 30},
+			late:         90 * time.Minute,
+			wantPenalty:  10,
+			wantAccepted: true,
+		},
+		{name: "step per started interval", endTime: deadline, script: step, late: 61 * time.Minute, wantPenalty: 20, wantAccepted: true},
+		{name: "step at the end of an interval", endTime: deadline, script: step, late: 60 * time.Minute, wantPenalty: 10, wantAccepted: true},
+		{
+			name:    "after the late cutoff",
+			endTime: deadline,
+			script:  models.QuestionTestScript{LatePolicy: models.LatePolicyLinear, LatePenalty: 10, LatePenaltyInterval: 60, LateCutoff: 120},
+			late:    121 * time.Minute,
+		},
+		{
+			name:    "nothing left to score",
+			endTime: deadline,
+			script:  models.QuestionTestScript{LatePolicy: models.LatePolicyLinear, LatePenalty: 50, LatePenaltyInterval: 60},
+			late:    120 * time.Minute,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			question := &models.Question{EndTime: tc.endTime}
+			penalty, accepted := latePenalty(question, &tc.script, deadline.Add(tc.late))
+			if penalty != tc.wantPenalty || accepted != tc.wantAccepted {
+				t.Errorf("latePenalty() = %v, %t, want %v, %t", penalty, accepted, tc.wantPenalty, tc.wantAccepted)
+			}
+		})
+	}
+}
+
+func TestValidateLatePolicy(t *testing.T) {
+	cases := []struct {
+		name   string
+		script models.QuestionTestScript
+		want   string
+	}{
+		{name: "none", script: models.QuestionTestScript{LatePolicy: models.LatePolicyNone}},
+		{name: "linear", script: models.QuestionTestScript{LatePolicy: models.LatePolicyLinear, LatePenalty: 10, LatePenaltyInterval: 60}},
+		{name: "no penalty", script: models.QuestionTestScript{LatePolicy: models.LatePolicyStep, LatePenaltyInterval: 60}, want: "Late penalty must be between 0 and 100 percent"},
+		{name: "penalty above 100", script: models.QuestionTestScript{LatePolicy: models.LatePolicyStep, LatePenalty: 150, LatePenaltyInterval: 60}, want: "Late penalty must be between 0 and 100 percent"},
+		{name: "no interval", script: models.QuestionTestScript{LatePolicy: models.LatePolicyLinear, LatePenalty: 10}, want: "Late penalty interval must be greater than 0"},
+		{name: "unknown policy", script: models.QuestionTestScript{LatePolicy: "exponential"}, want: "Unknown late policy"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := validateLatePolicy(&tc.script); got != tc.want {
+				t.Errorf("validateLatePolicy() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"OJ-API/database"
+	"OJ-API/models"
+	"OJ-API/utils"
+)
+
+type ScoreOverrideData struct {
+	models.ScoreOverride
+	UserName string `json:"user_name" example:"student"`
+}
+
+// ListScoreOverrides is a function to list score overrides
+//
+//	@Summary		List score overrides
+//	@Description	List the manual score overrides, optionally filtered by user or question. Revoked overrides are kept as audit trail and only listed with include_revoked.
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			user_id			query	int		false	"filter by user ID"
+//	@Param			question_id		query	int		false	"filter by question ID"
+//	@Param			include_revoked	query	bool	false	"include revoked overrides"
+//	@Success		200	{object}	ResponseHTTP{data=[]ScoreOverrideData}
+//	@Failure		401
+//	@Failure		503
+//	@Router			/api/score/admin/overrides [get]
+//	@Security		BearerAuth
+func ListScoreOverrides(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	query := db.Model(&models.ScoreOverride{}).
+		Select("score_overrides.*, users.user_name").
+		Joins("JOIN users ON users.id = score_overrides.user_id")
+	for _, filter := range []string{"user_id", "question_id"} {
+		if value := c.Query(filter); value != "" {
+			query = query.Where("score_overrides."+filter+" = ?", value)
+		}
+	}
+	if includeRevoked, _ := strconv.ParseBool(c.Query("include_revoked")); !includeRevoked {
+		query = query.Where("score_overrides.revoked_at IS NULL")
+	}
+
+	var overrides []ScoreOverrideData
+	if err := query.Order("score_overrides.id").Scan(&overrides).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch score overrides",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Score overrides fetched successfully",
+		Data:    overrides,
+	})
+}
+
+type CreateScoreOverrideRequest struct {
+	UserID     uint     `json:"user_id" validate:"required" example:"1"`
+	QuestionID uint     `json:"question_id" validate:"required" example:"1"`
+	Score      *float64 `json:"score" example:"100" description:"Final score replacing the judged score, exclusive with delta"`
+	Delta      *float64 `json:"delta" example:"10" description:"Points added to the judged score, negative to deduct"`
+	Reason     string   `json:"reason" validate:"required" example:"Test case 3 had a wrong expected output"`
+}
+
+// CreateScoreOverride is a function to override the final score of a user
+//
+//	@Summary		Override the final score of a user
+//	@Description	Replace or adjust the final score of a user on a question. The judged score is kept and still reported, an existing override of the same user and question is revoked.
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			override	body	CreateScoreOverrideRequest	true	"Score override"
+//	@Success		200	{object}	ResponseHTTP{data=models.ScoreOverride}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/score/admin/overrides [post]
+//	@Security		BearerAuth
+func CreateScoreOverride(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req CreateScoreOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Failed to parse request",
+		})
+		return
+	}
+	if (req.Score == nil) == (req.Delta == nil) {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Exactly one of score and delta is required",
+		})
+		return
+	}
+	if req.Score != nil && *req.Score < 0 {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Score must not be negative",
+		})
+		return
+	}
+	if req.Reason == "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Reason is required",
+		})
+		return
+	}
+
+	if err := db.First(&models.User{}, req.UserID).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "User not found",
+		})
+		return
+	}
+	if err := db.First(&models.Question{}, req.QuestionID).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Question not found",
+		})
+		return
+	}
+
+	override := models.ScoreOverride{
+		UserID:     req.UserID,
+		QuestionID: req.QuestionID,
+		Score:      req.Score,
+		Reason:     req.Reason,
+		CreatedBy:  jwtClaims.UserID,
+	}
+	if req.Delta != nil {
+		override.Delta = *req.Delta
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Only one override per user and question is in effect, the previous one stays as audit trail
+		if err := tx.Model(&models.ScoreOverride{}).
+			Where("user_id = ? AND question_id = ? AND revoked_at IS NULL", req.UserID, req.QuestionID).
+			Updates(map[string]interface{}{
+				"revoked_at": time.Now().UTC(),
+				"revoked_by": jwtClaims.UserID,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&override).Error
+	})
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to save score override",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Score override saved successfully",
+		Data:    override,
+	})
+}
+
+// RevokeScoreOverride is a function to revoke a score override
+//
+//	@Summary		Revoke a score override
+//	@Description	Revoke a score override, the user falls back to the judged score. The override is kept as audit trail.
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"score override ID"
+//	@Success		200	{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/score/admin/overrides/{id} [delete]
+//	@Security		BearerAuth
+func RevokeScoreOverride(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid score override ID",
+		})
+		return
+	}
+
+	result := db.Model(&models.ScoreOverride{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": time.Now().UTC(),
+			"revoked_by": jwtClaims.UserID,
+		})
+	if result.Error != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to revoke score override",
+		})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Score override not found or already revoked",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Score override revoked successfully",
+	})
+}
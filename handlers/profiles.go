@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"OJ-API/models"
+	"OJ-API/profiles"
+	"OJ-API/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetJudgeProfiles is a function to list the built-in judge profiles
+// @Summary		List built-in judge profiles
+// @Description	List the built-in judge profiles with their generated compile, execute and score scripts. A profile can be selected per question, and non-empty raw scripts override the generated ones.
+// @Tags			Question
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	ResponseHTTP{data=[]profiles.Profile}
+// @Failure		401
+// @Router			/api/questions/admin/profiles [get]
+// @Security		BearerAuth
+func GetJudgeProfiles(c *gin.Context) {
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Judge profiles fetched successfully",
+		Data:    profiles.List(),
+	})
+}
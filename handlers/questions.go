@@ -4,6 +4,9 @@ import (
 	"OJ-API/config"
 	"OJ-API/database"
 	"OJ-API/models"
+	"OJ-API/profiles"
+	"OJ-API/sandbox"
+	"OJ-API/services"
 	"OJ-API/utils"
 	"strconv"
 	"strings"
@@ -17,6 +20,8 @@ type _GetQuestionListQuestionData struct {
 	models.Question
 	HasQuestion bool     `json:"has_question"`
 	TopScore    *float64 `json:"top_score,omitempty"`
+
+	ExtendedEndTime *time.Time `json:"extended_end_time,omitempty" example:"2006-01-02T15:04:05Z" time_format:"RFC3339" description:"End time extended for the current user"`
 }
 
 type GetQuestionListResponseData struct {
@@ -65,13 +70,19 @@ func GetQuestionList(c *gin.Context) {
 		baseQuery = baseQuery.Where("is_active = ?", true)
 	}
 
+	// The status of a question follows the end time extended for the current user
+	endTime := "end_time"
+	if userID != 0 {
+		endTime = services.EffectiveEndTimeSQL(strconv.FormatUint(uint64(userID), 10), "questions")
+	}
+
 	// Add status filter
 	now := time.Now().UTC()
 	switch status {
 	case "active":
-		baseQuery = baseQuery.Where("start_time <= ? AND end_time >= ?", now, now)
+		baseQuery = baseQuery.Where("start_time <= ? AND "+endTime+" >= ?", now, now)
 	case "expired":
-		baseQuery = baseQuery.Where("end_time < ?", now)
+		baseQuery = baseQuery.Where(endTime+" < ?", now)
 		// "all" doesn't add any additional filter
 	}
 
@@ -81,7 +92,7 @@ func GetQuestionList(c *gin.Context) {
 	var questions []models.Question
 
 	// Sort by status: active questions first, then expired
-	orderClause := "CASE WHEN start_time <= '" + now.Format("2006-01-02 15:04:05") + "' AND end_time >= '" + now.Format("2006-01-02 15:04:05") + "' THEN 0 ELSE 1 END, start_time DESC, end_time ASC"
+	orderClause := "CASE WHEN start_time <= '" + now.Format("2006-01-02 15:04:05") + "' AND " + endTime + " >= '" + now.Format("2006-01-02 15:04:05") + "' THEN 0 ELSE 1 END, start_time DESC, end_time ASC"
 
 	// Get questions first
 	query := db.Model(&models.Question{}).
@@ -94,9 +105,9 @@ func GetQuestionList(c *gin.Context) {
 	// Add status filter
 	switch status {
 	case "active":
-		query = query.Where("start_time <= ? AND end_time >= ?", now, now)
+		query = query.Where("start_time <= ? AND "+endTime+" >= ?", now, now)
 	case "expired":
-		query = query.Where("end_time < ?", now)
+		query = query.Where(endTime+" < ?", now)
 	}
 
 	// Get questions with proper ordering
@@ -168,6 +179,26 @@ func GetQuestionList(c *gin.Context) {
 			scoreMap[score.QuestionID] = score.TopScore
 		}
 
+		// Get end times extended for this user
+		var endTimes []struct {
+			ID      uint
+			EndTime time.Time
+		}
+		if err := db.Model(&models.Question{}).
+			Select("id, "+endTime+" AS end_time").
+			Where("id IN ?", questionIDs).
+			Scan(&endTimes).Error; err != nil {
+			c.JSON(503, ResponseHTTP{
+				Success: false,
+				Message: "Failed to fetch extended end times",
+			})
+			return
+		}
+		endTimeMap := make(map[uint]time.Time)
+		for _, e := range endTimes {
+			endTimeMap[e.ID] = e.EndTime
+		}
+
 		// Convert to response format and add user-specific data
 		var responseQuestions []_GetQuestionListQuestionData
 		for _, q := range questions {
@@ -176,6 +207,9 @@ func GetQuestionList(c *gin.Context) {
 				HasQuestion: userQuestionMap[q.ID],
 				TopScore:    scoreMap[q.ID],
 			}
+			if end, ok := endTimeMap[q.ID]; ok && !end.Equal(q.EndTime) {
+				responseQ.ExtendedEndTime = &end
+			}
 			responseQuestions = append(responseQuestions, responseQ)
 		}
 
@@ -408,6 +442,19 @@ type GetQuestionLimitByIDResponseData struct {
 	FileSize    uint   `json:"file_size" example:"10240" description:"Output file size limit in KB"`
 	Processes   uint   `json:"processes" example:"10" description:"process count"`
 	OpenFiles   uint   `json:"open_files" example:"64" description:"Counts can open"`
+
+	CompileTime      uint `json:"compile_time" example:"20000" description:"CPU time limit of the compile stage in ms"`
+	CompileWallTime  uint `json:"compile_wall_time" example:"30000" description:"Wall clock time limit of the compile stage in ms"`
+	CompileMemory    uint `json:"compile_memory" example:"2097152" description:"Memory limit of the compile stage in KB"`
+	CompileProcesses uint `json:"compile_processes" example:"64" description:"Process count of the compile stage"`
+	CompileFileSize  uint `json:"compile_file_size" example:"10240" description:"File size limit of the compile stage in KB"`
+	ScoreTime        uint `json:"score_time" example:"10000" description:"CPU time limit of the score stage in ms"`
+	ScoreWallTime    uint `json:"score_wall_time" example:"20000" description:"Wall clock time limit of the score stage in ms"`
+	ScoreMemory      uint `json:"score_memory" example:"1048576" description:"Memory limit of the score stage in KB"`
+	ScoreProcesses   uint `json:"score_processes" example:"100" description:"Process count of the score stage"`
+	ScoreFileSize    uint `json:"score_file_size" example:"10240" description:"File size limit of the score stage in KB"`
+
+	JudgeTimeout uint `json:"judge_timeout" example:"60000" description:"Overall judge deadline of a submission in ms"`
 }
 
 // GetQuestionLimitByID is a function to get a question limitation by ID
@@ -475,6 +522,19 @@ func GetQuestionLimitByID(c *gin.Context) {
 			FileSize:    questionTestScript.FileSize,
 			Processes:   questionTestScript.Processes,
 			OpenFiles:   questionTestScript.OpenFiles,
+
+			CompileTime:      questionTestScript.CompileTime,
+			CompileWallTime:  questionTestScript.CompileWallTime,
+			CompileMemory:    questionTestScript.CompileMemory,
+			CompileProcesses: questionTestScript.CompileProcesses,
+			CompileFileSize:  questionTestScript.CompileFileSize,
+			ScoreTime:        questionTestScript.ScoreTime,
+			ScoreWallTime:    questionTestScript.ScoreWallTime,
+			ScoreMemory:      questionTestScript.ScoreMemory,
+			ScoreProcesses:   questionTestScript.ScoreProcesses,
+			ScoreFileSize:    questionTestScript.ScoreFileSize,
+
+			JudgeTimeout: questionTestScript.JudgeTimeout,
 		},
 	})
 }
@@ -567,10 +627,34 @@ func GetUserQuestionByID(c *gin.Context) {
 }
 
 type AddQuestionScript struct {
-	CompileScript string `json:"compile_script" example:"script example"`
-	ExecuteScript string `json:"execute_script" example:"script example"`
-	ScoreScript   string `json:"score_script" example:"script example"`
-	ScoreMap      string `json:"score_map" example:"script example"`
+	Profile        string   `json:"profile" example:"cpp-gtest" description:"Built-in judge profile, scripts left empty are generated from it and limits left empty use its suggested limits"`
+	JudgeMode      string   `json:"judge_mode" example:"unit" description:"unit for repo-based unit tests, io for stdin/stdout test cases"`
+	Checker        string   `json:"checker" example:"exact" description:"Output checker for io mode: exact, whitespace, float or custom"`
+	FloatTolerance *float64 `json:"float_tolerance" example:"0.000001" description:"Absolute or relative tolerance of the float checker"`
+	CheckerScript  string   `json:"checker_script" example:"script example" description:"Custom checker, run as: bash checker <input> <answer> <output>"`
+	CompileScript  string   `json:"compile_script" example:"script example"`
+	ExecuteScript  string   `json:"execute_script" example:"script example"`
+	ScoreScript    string   `json:"score_script" example:"script example"`
+	ScoreMap       string   `json:"score_map" example:"script example"`
+
+	CancelSuperseded bool `json:"cancel_superseded" example:"false" description:"Abort the in-flight judge of older pushes when a newer push of the same user arrives"`
+	CoalesceQueued   bool `json:"coalesce_queued" example:"false" description:"Mark older pushes of the same user that are still queued as SUPERSEDED when a newer push arrives"`
+
+	MinSubmitInterval uint `json:"min_submit_interval" example:"0" description:"Minimum seconds between two judged pushes of the same user, 0 for no limit"`
+	MaxSubmitsPerHour uint `json:"max_submits_per_hour" example:"0" description:"Maximum judged pushes of the same user in the last hour, 0 for no limit"`
+	MaxSubmitsPerDay  uint `json:"max_submits_per_day" example:"0" description:"Maximum judged pushes of the same user in the last 24 hours, 0 for no limit"`
+	SubmitQuota       uint `json:"submit_quota" example:"0" description:"Total judged pushes allowed for the same user, 0 for no limit"`
+
+	LatePolicy          string  `json:"late_policy" example:"none" description:"Late submission policy: none rejects pushes after the grace period, linear or step deducts late_penalty percent per interval"`
+	LateGracePeriod     uint    `json:"late_grace_period" example:"0" description:"Minutes after the end time during which pushes are not penalized"`
+	LatePenalty         float64 `json:"late_penalty" example:"10" description:"Percent deducted per late_penalty_interval"`
+	LatePenaltyInterval *uint   `json:"late_penalty_interval" example:"60" description:"Minutes per penalty step"`
+	LateCutoff          uint    `json:"late_cutoff" example:"0" description:"Minutes after the end time after which pushes are rejected, 0 for no cutoff"`
+
+	ScorePolicy     string `json:"score_policy" example:"best" description:"Final score policy: best, last, last_before_deadline or top_k_average, overridden by the policy of the exam"`
+	ScorePolicyTopK *uint  `json:"score_policy_top_k" example:"1" description:"Number of best scores averaged by top_k_average"`
+
+	RequiredLabels []string `json:"required_labels" example:"java,big-memory" description:"Labels a sandbox must advertise to judge this question"`
 }
 
 type AddQuestionLimit struct {
@@ -581,6 +665,43 @@ type AddQuestionLimit struct {
 	FileSize    *uint `json:"file_size" example:"10240" description:"Output file size limit in KB"`
 	Processes   *uint `json:"processes" example:"10" description:"process count"`
 	OpenFiles   *uint `json:"open_files" example:"64" description:"Counts can open"`
+
+	CompileTime      *uint `json:"compile_time" example:"20000" description:"CPU time limit of the compile stage in ms"`
+	CompileWallTime  *uint `json:"compile_wall_time" example:"30000" description:"Wall clock time limit of the compile stage in ms"`
+	CompileMemory    *uint `json:"compile_memory" example:"2097152" description:"Memory limit of the compile stage in KB"`
+	CompileProcesses *uint `json:"compile_processes" example:"64" description:"Process count of the compile stage"`
+	CompileFileSize  *uint `json:"compile_file_size" example:"10240" description:"File size limit of the compile stage in KB"`
+	ScoreTime        *uint `json:"score_time" example:"10000" description:"CPU time limit of the score stage in ms"`
+	ScoreWallTime    *uint `json:"score_wall_time" example:"20000" description:"Wall clock time limit of the score stage in ms"`
+	ScoreMemory      *uint `json:"score_memory" example:"1048576" description:"Memory limit of the score stage in KB"`
+	ScoreProcesses   *uint `json:"score_processes" example:"100" description:"Process count of the score stage"`
+	ScoreFileSize    *uint `json:"score_file_size" example:"10240" description:"File size limit of the score stage in KB"`
+
+	JudgeTimeout *uint `json:"judge_timeout" example:"60000" description:"Overall judge deadline of a submission in ms"`
+}
+
+// applySuggestedLimits fills the limits left unset with the ones suggested by the judge profile.
+func applySuggestedLimits(limit *AddQuestionLimit, suggested profiles.Limits) {
+	for _, l := range []struct {
+		field **uint
+		value uint
+	}{
+		{&limit.Memory, suggested.Memory},
+		{&limit.Time, suggested.Time},
+		{&limit.WallTime, suggested.WallTime},
+		{&limit.Processes, suggested.Processes},
+		{&limit.OpenFiles, suggested.OpenFiles},
+		{&limit.CompileTime, suggested.CompileTime},
+		{&limit.CompileWallTime, suggested.CompileWallTime},
+		{&limit.CompileMemory, suggested.CompileMemory},
+		{&limit.CompileProcesses, suggested.CompileProcesses},
+		{&limit.JudgeTimeout, suggested.JudgeTimeout},
+	} {
+		if *l.field == nil && l.value != 0 {
+			value := l.value
+			*l.field = &value
+		}
+	}
 }
 
 type AddQuestionRequest struct {
@@ -638,6 +759,81 @@ func AddQuestion(c *gin.Context) {
 		return
 	}
 
+	if req.Profile != "" {
+		profile, ok := profiles.Get(req.Profile)
+		if !ok {
+			c.JSON(400, ResponseHTTP{
+				Success: false,
+				Message: "Unknown judge profile",
+			})
+			return
+		}
+		applySuggestedLimits(&req.AddQuestionLimit, profile.Limits)
+	}
+
+	if req.JudgeMode == "" {
+		req.JudgeMode = string(models.JudgeModeUnit)
+	}
+	if req.Checker == "" {
+		req.Checker = string(models.CheckerExact)
+	}
+	if msg := validateJudgeMode(models.JudgeMode(req.JudgeMode), models.CheckerType(req.Checker), req.CheckerScript); msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+	if err := sandbox.ValidateScoreMap(req.ScoreMap); err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid score map: " + err.Error(),
+		})
+		return
+	}
+	if req.LatePolicy == "" {
+		req.LatePolicy = string(models.LatePolicyNone)
+	}
+	latePolicy := models.QuestionTestScript{
+		LatePolicy:          models.LatePolicy(req.LatePolicy),
+		LateGracePeriod:     req.LateGracePeriod,
+		LatePenalty:         req.LatePenalty,
+		LatePenaltyInterval: 60,
+		LateCutoff:          req.LateCutoff,
+	}
+	if req.LatePenaltyInterval != nil {
+		latePolicy.LatePenaltyInterval = *req.LatePenaltyInterval
+	}
+	if msg := validateLatePolicy(&latePolicy); msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+	if req.ScorePolicy == "" {
+		req.ScorePolicy = string(models.ScorePolicyBest)
+	}
+	scorePolicyTopK := uint(1)
+	if req.ScorePolicyTopK != nil {
+		scorePolicyTopK = *req.ScorePolicyTopK
+	}
+	if msg := validateScorePolicy(models.ScorePolicy(req.ScorePolicy), scorePolicyTopK); msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+	requiredLabels, msg := joinLabels(req.RequiredLabels)
+	if msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+
 	newquestion := models.Question{
 		Title:       req.Title,
 		Description: req.Description,
@@ -729,10 +925,39 @@ func AddQuestion(c *gin.Context) {
 
 	questionInfo := models.QuestionTestScript{
 		QuestionID:    response.Id,
+		Profile:       req.Profile,
+		JudgeMode:     models.JudgeMode(req.JudgeMode),
+		Checker:       models.CheckerType(req.Checker),
+		CheckerScript: req.CheckerScript,
 		CompileScript: req.CompileScript,
 		ExecuteScript: req.ExecuteScript,
 		ScoreScript:   req.ScoreScript,
 		ScoreMap:      req.ScoreMap,
+
+		CancelSuperseded: req.CancelSuperseded,
+		CoalesceQueued:   req.CoalesceQueued,
+
+		MinSubmitInterval: req.MinSubmitInterval,
+		MaxSubmitsPerHour: req.MaxSubmitsPerHour,
+		MaxSubmitsPerDay:  req.MaxSubmitsPerDay,
+		SubmitQuota:       req.SubmitQuota,
+
+		LatePolicy:          latePolicy.LatePolicy,
+		LateGracePeriod:     latePolicy.LateGracePeriod,
+		LatePenalty:         latePolicy.LatePenalty,
+		LatePenaltyInterval: latePolicy.LatePenaltyInterval,
+		LateCutoff:          latePolicy.LateCutoff,
+
+		ScorePolicy:     models.ScorePolicy(req.ScorePolicy),
+		ScorePolicyTopK: scorePolicyTopK,
+
+		RequiredLabels: requiredLabels,
+	}
+
+	if req.FloatTolerance != nil {
+		questionInfo.FloatTolerance = *req.FloatTolerance
+	} else {
+		questionInfo.FloatTolerance = 0.000001
 	}
 
 	if req.Memory != nil {
@@ -777,6 +1002,72 @@ func AddQuestion(c *gin.Context) {
 		questionInfo.OpenFiles = 64
 	}
 
+	if req.CompileTime != nil {
+		questionInfo.CompileTime = *req.CompileTime
+	} else {
+		questionInfo.CompileTime = 20000
+	}
+
+	if req.CompileWallTime != nil {
+		questionInfo.CompileWallTime = *req.CompileWallTime
+	} else {
+		questionInfo.CompileWallTime = 30000
+	}
+
+	if req.CompileMemory != nil {
+		questionInfo.CompileMemory = *req.CompileMemory
+	} else {
+		questionInfo.CompileMemory = 2097152
+	}
+
+	if req.CompileProcesses != nil {
+		questionInfo.CompileProcesses = *req.CompileProcesses
+	} else {
+		questionInfo.CompileProcesses = 64
+	}
+
+	if req.CompileFileSize != nil {
+		questionInfo.CompileFileSize = *req.CompileFileSize
+	} else {
+		questionInfo.CompileFileSize = 10240
+	}
+
+	if req.ScoreTime != nil {
+		questionInfo.ScoreTime = *req.ScoreTime
+	} else {
+		questionInfo.ScoreTime = 10000
+	}
+
+	if req.ScoreWallTime != nil {
+		questionInfo.ScoreWallTime = *req.ScoreWallTime
+	} else {
+		questionInfo.ScoreWallTime = 20000
+	}
+
+	if req.ScoreMemory != nil {
+		questionInfo.ScoreMemory = *req.ScoreMemory
+	} else {
+		questionInfo.ScoreMemory = 1048576
+	}
+
+	if req.ScoreProcesses != nil {
+		questionInfo.ScoreProcesses = *req.ScoreProcesses
+	} else {
+		questionInfo.ScoreProcesses = 100
+	}
+
+	if req.ScoreFileSize != nil {
+		questionInfo.ScoreFileSize = *req.ScoreFileSize
+	} else {
+		questionInfo.ScoreFileSize = 10240
+	}
+
+	if req.JudgeTimeout != nil {
+		questionInfo.JudgeTimeout = *req.JudgeTimeout
+	} else {
+		questionInfo.JudgeTimeout = 60000
+	}
+
 	if err := db.Create(&questionInfo).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
@@ -800,17 +1091,53 @@ type PatchQuestionRequest struct {
 	EndTime     *time.Time `json:"end_time" example:"2006-01-02T15:04:05Z" time_format:"RFC3339"`
 	IsActive    *bool      `json:"is_active" example:"true"`
 
-	CompileScript *string `json:"compile_script" example:"script example"`
-	ExecuteScript *string `json:"execute_script" example:"script example"`
-	ScoreScript   *string `json:"score_script" example:"script example"`
-	ScoreMap      *string `json:"score_map" example:"score map for task score"`
-	Memory        *uint   `json:"memory" example:"262144" description:"Memory limit in KB"`
-	StackMemory   *uint   `json:"stack_memory" example:"8192" description:"Stack memory limit in KB"`
-	Time          *uint   `json:"time" example:"1000" description:"CPU time limit in ms"`
-	WallTime      *uint   `json:"wall_time" example:"3000" description:"Wall clock time limit in ms"`
-	FileSize      *uint   `json:"file_size" example:"10240" description:"Output file size limit in KB"`
-	Processes     *uint   `json:"processes" example:"10" description:"process count"`
-	OpenFiles     *uint   `json:"open_files" example:"64" description:"Counts can open"`
+	Profile        *string  `json:"profile" example:"cpp-gtest" description:"Built-in judge profile, empty string to use raw scripts only"`
+	JudgeMode      *string  `json:"judge_mode" example:"unit" description:"unit for repo-based unit tests, io for stdin/stdout test cases"`
+	Checker        *string  `json:"checker" example:"exact" description:"Output checker for io mode: exact, whitespace, float or custom"`
+	FloatTolerance *float64 `json:"float_tolerance" example:"0.000001" description:"Absolute or relative tolerance of the float checker"`
+	CheckerScript  *string  `json:"checker_script" example:"script example" description:"Custom checker, run as: bash checker <input> <answer> <output>"`
+	CompileScript  *string  `json:"compile_script" example:"script example"`
+	ExecuteScript  *string  `json:"execute_script" example:"script example"`
+	ScoreScript    *string  `json:"score_script" example:"script example"`
+	ScoreMap       *string  `json:"score_map" example:"score map for task score"`
+	Memory         *uint    `json:"memory" example:"262144" description:"Memory limit in KB"`
+	StackMemory    *uint    `json:"stack_memory" example:"8192" description:"Stack memory limit in KB"`
+	Time           *uint    `json:"time" example:"1000" description:"CPU time limit in ms"`
+	WallTime       *uint    `json:"wall_time" example:"3000" description:"Wall clock time limit in ms"`
+	FileSize       *uint    `json:"file_size" example:"10240" description:"Output file size limit in KB"`
+	Processes      *uint    `json:"processes" example:"10" description:"process count"`
+	OpenFiles      *uint    `json:"open_files" example:"64" description:"Counts can open"`
+
+	CompileTime      *uint `json:"compile_time" example:"20000" description:"CPU time limit of the compile stage in ms"`
+	CompileWallTime  *uint `json:"compile_wall_time" example:"30000" description:"Wall clock time limit of the compile stage in ms"`
+	CompileMemory    *uint `json:"compile_memory" example:"2097152" description:"Memory limit of the compile stage in KB"`
+	CompileProcesses *uint `json:"compile_processes" example:"64" description:"Process count of the compile stage"`
+	CompileFileSize  *uint `json:"compile_file_size" example:"10240" description:"File size limit of the compile stage in KB"`
+	ScoreTime        *uint `json:"score_time" example:"10000" description:"CPU time limit of the score stage in ms"`
+	ScoreWallTime    *uint `json:"score_wall_time" example:"20000" description:"Wall clock time limit of the score stage in ms"`
+	ScoreMemory      *uint `json:"score_memory" example:"1048576" description:"Memory limit of the score stage in KB"`
+	ScoreProcesses   *uint `json:"score_processes" example:"100" description:"Process count of the score stage"`
+	ScoreFileSize    *uint `json:"score_file_size" example:"10240" description:"File size limit of the score stage in KB"`
+
+	JudgeTimeout     *uint `json:"judge_timeout" example:"60000" description:"Overall judge deadline of a submission in ms"`
+	CancelSuperseded *bool `json:"cancel_superseded" example:"false" description:"Abort the in-flight judge of older pushes when a newer push of the same user arrives"`
+	CoalesceQueued   *bool `json:"coalesce_queued" example:"false" description:"Mark older pushes of the same user that are still queued as SUPERSEDED when a newer push arrives"`
+
+	MinSubmitInterval *uint `json:"min_submit_interval" example:"0" description:"Minimum seconds between two judged pushes of the same user, 0 for no limit"`
+	MaxSubmitsPerHour *uint `json:"max_submits_per_hour" example:"0" description:"Maximum judged pushes of the same user in the last hour, 0 for no limit"`
+	MaxSubmitsPerDay  *uint `json:"max_submits_per_day" example:"0" description:"Maximum judged pushes of the same user in the last 24 hours, 0 for no limit"`
+	SubmitQuota       *uint `json:"submit_quota" example:"0" description:"Total judged pushes allowed for the same user, 0 for no limit"`
+
+	LatePolicy          *string  `json:"late_policy" example:"none" description:"Late submission policy: none rejects pushes after the grace period, linear or step deducts late_penalty percent per interval"`
+	LateGracePeriod     *uint    `json:"late_grace_period" example:"0" description:"Minutes after the end time during which pushes are not penalized"`
+	LatePenalty         *float64 `json:"late_penalty" example:"10" description:"Percent deducted per late_penalty_interval"`
+	LatePenaltyInterval *uint    `json:"late_penalty_interval" example:"60" description:"Minutes per penalty step"`
+	LateCutoff          *uint    `json:"late_cutoff" example:"0" description:"Minutes after the end time after which pushes are rejected, 0 for no cutoff"`
+
+	ScorePolicy     *string `json:"score_policy" example:"best" description:"Final score policy: best, last, last_before_deadline or top_k_average, overridden by the policy of the exam"`
+	ScorePolicyTopK *uint   `json:"score_policy_top_k" example:"1" description:"Number of best scores averaged by top_k_average"`
+
+	RequiredLabels *[]string `json:"required_labels" example:"java,big-memory" description:"Labels a sandbox must advertise to judge this question"`
 }
 
 // PatchQuestion is a function to update a question
@@ -895,6 +1222,37 @@ func PatchQuestion(c *gin.Context) {
 		question.IsActive = *updateQuestion.IsActive
 	}
 
+	if updateQuestion.Profile != nil {
+		if *updateQuestion.Profile != "" {
+			if _, ok := profiles.Get(*updateQuestion.Profile); !ok {
+				c.JSON(400, ResponseHTTP{
+					Success: false,
+					Message: "Unknown judge profile",
+				})
+				return
+			}
+		}
+		questionscript.Profile = *updateQuestion.Profile
+	}
+	if updateQuestion.JudgeMode != nil {
+		questionscript.JudgeMode = models.JudgeMode(*updateQuestion.JudgeMode)
+	}
+	if updateQuestion.Checker != nil {
+		questionscript.Checker = models.CheckerType(*updateQuestion.Checker)
+	}
+	if updateQuestion.FloatTolerance != nil {
+		questionscript.FloatTolerance = *updateQuestion.FloatTolerance
+	}
+	if updateQuestion.CheckerScript != nil {
+		questionscript.CheckerScript = *updateQuestion.CheckerScript
+	}
+	if msg := validateJudgeMode(questionscript.JudgeMode, questionscript.Checker, questionscript.CheckerScript); msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
 	if updateQuestion.CompileScript != nil {
 		questionscript.CompileScript = *updateQuestion.CompileScript
 	}
@@ -904,7 +1262,14 @@ func PatchQuestion(c *gin.Context) {
 	if updateQuestion.ExecuteScript != nil {
 		questionscript.ExecuteScript = *updateQuestion.ExecuteScript
 	}
-	if updateQuestion.ExecuteScript != nil {
+	if updateQuestion.ScoreMap != nil {
+		if err := sandbox.ValidateScoreMap(*updateQuestion.ScoreMap); err != nil {
+			c.JSON(400, ResponseHTTP{
+				Success: false,
+				Message: "Invalid score map: " + err.Error(),
+			})
+			return
+		}
 		questionscript.ScoreMap = *updateQuestion.ScoreMap
 	}
 	if updateQuestion.Time != nil {
@@ -928,6 +1293,106 @@ func PatchQuestion(c *gin.Context) {
 	if updateQuestion.OpenFiles != nil {
 		questionscript.OpenFiles = *updateQuestion.OpenFiles
 	}
+	if updateQuestion.CompileTime != nil {
+		questionscript.CompileTime = *updateQuestion.CompileTime
+	}
+	if updateQuestion.CompileWallTime != nil {
+		questionscript.CompileWallTime = *updateQuestion.CompileWallTime
+	}
+	if updateQuestion.CompileMemory != nil {
+		questionscript.CompileMemory = *updateQuestion.CompileMemory
+	}
+	if updateQuestion.CompileProcesses != nil {
+		questionscript.CompileProcesses = *updateQuestion.CompileProcesses
+	}
+	if updateQuestion.CompileFileSize != nil {
+		questionscript.CompileFileSize = *updateQuestion.CompileFileSize
+	}
+	if updateQuestion.ScoreTime != nil {
+		questionscript.ScoreTime = *updateQuestion.ScoreTime
+	}
+	if updateQuestion.ScoreWallTime != nil {
+		questionscript.ScoreWallTime = *updateQuestion.ScoreWallTime
+	}
+	if updateQuestion.ScoreMemory != nil {
+		questionscript.ScoreMemory = *updateQuestion.ScoreMemory
+	}
+	if updateQuestion.ScoreProcesses != nil {
+		questionscript.ScoreProcesses = *updateQuestion.ScoreProcesses
+	}
+	if updateQuestion.ScoreFileSize != nil {
+		questionscript.ScoreFileSize = *updateQuestion.ScoreFileSize
+	}
+	if updateQuestion.JudgeTimeout != nil {
+		questionscript.JudgeTimeout = *updateQuestion.JudgeTimeout
+	}
+	if updateQuestion.CancelSuperseded != nil {
+		questionscript.CancelSuperseded = *updateQuestion.CancelSuperseded
+	}
+	if updateQuestion.CoalesceQueued != nil {
+		questionscript.CoalesceQueued = *updateQuestion.CoalesceQueued
+	}
+	if updateQuestion.MinSubmitInterval != nil {
+		questionscript.MinSubmitInterval = *updateQuestion.MinSubmitInterval
+	}
+	if updateQuestion.MaxSubmitsPerHour != nil {
+		questionscript.MaxSubmitsPerHour = *updateQuestion.MaxSubmitsPerHour
+	}
+	if updateQuestion.MaxSubmitsPerDay != nil {
+		questionscript.MaxSubmitsPerDay = *updateQuestion.MaxSubmitsPerDay
+	}
+	if updateQuestion.SubmitQuota != nil {
+		questionscript.SubmitQuota = *updateQuestion.SubmitQuota
+	}
+	if updateQuestion.LatePolicy != nil {
+		questionscript.LatePolicy = models.LatePolicy(*updateQuestion.LatePolicy)
+	}
+	if updateQuestion.LateGracePeriod != nil {
+		questionscript.LateGracePeriod = *updateQuestion.LateGracePeriod
+	}
+	if updateQuestion.LatePenalty != nil {
+		questionscript.LatePenalty = *updateQuestion.LatePenalty
+	}
+	if updateQuestion.LatePenaltyInterval != nil {
+		questionscript.LatePenaltyInterval = *updateQuestion.LatePenaltyInterval
+	}
+	if updateQuestion.LateCutoff != nil {
+		questionscript.LateCutoff = *updateQuestion.LateCutoff
+	}
+	if msg := validateLatePolicy(&questionscript); msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+	if updateQuestion.ScorePolicy != nil {
+		questionscript.ScorePolicy = models.ScorePolicy(*updateQuestion.ScorePolicy)
+		if questionscript.ScorePolicy == "" {
+			questionscript.ScorePolicy = models.ScorePolicyBest
+		}
+	}
+	if updateQuestion.ScorePolicyTopK != nil {
+		questionscript.ScorePolicyTopK = *updateQuestion.ScorePolicyTopK
+	}
+	if msg := validateScorePolicy(questionscript.ScorePolicy, questionscript.ScorePolicyTopK); msg != "" {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: msg,
+		})
+		return
+	}
+	if updateQuestion.RequiredLabels != nil {
+		requiredLabels, msg := joinLabels(*updateQuestion.RequiredLabels)
+		if msg != "" {
+			c.JSON(400, ResponseHTTP{
+				Success: false,
+				Message: msg,
+			})
+			return
+		}
+		questionscript.RequiredLabels = requiredLabels
+	}
 
 	if err := db.Save(&question).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
@@ -1012,10 +1477,34 @@ func DeleteQuestion(c *gin.Context) {
 }
 
 type QuestionScripts struct {
-	CompileScript string `json:"compile_script" example:"script example"`
-	ExecuteScript string `json:"execute_script" example:"script example"`
-	ScoreScript   string `json:"score_script" example:"script example"`
-	ScoreMap      string `json:"score_map" example:"score map for task score"`
+	Profile        string  `json:"profile" example:"cpp-gtest"`
+	JudgeMode      string  `json:"judge_mode" example:"unit"`
+	Checker        string  `json:"checker" example:"exact"`
+	FloatTolerance float64 `json:"float_tolerance" example:"0.000001"`
+	CheckerScript  string  `json:"checker_script" example:"script example"`
+	CompileScript  string  `json:"compile_script" example:"script example"`
+	ExecuteScript  string  `json:"execute_script" example:"script example"`
+	ScoreScript    string  `json:"score_script" example:"script example"`
+	ScoreMap       string  `json:"score_map" example:"score map for task score"`
+
+	CancelSuperseded bool `json:"cancel_superseded" example:"false"`
+	CoalesceQueued   bool `json:"coalesce_queued" example:"false"`
+
+	MinSubmitInterval uint `json:"min_submit_interval" example:"0"`
+	MaxSubmitsPerHour uint `json:"max_submits_per_hour" example:"0"`
+	MaxSubmitsPerDay  uint `json:"max_submits_per_day" example:"0"`
+	SubmitQuota       uint `json:"submit_quota" example:"0"`
+
+	LatePolicy          string  `json:"late_policy" example:"none"`
+	LateGracePeriod     uint    `json:"late_grace_period" example:"0"`
+	LatePenalty         float64 `json:"late_penalty" example:"10"`
+	LatePenaltyInterval uint    `json:"late_penalty_interval" example:"60"`
+	LateCutoff          uint    `json:"late_cutoff" example:"0"`
+
+	ScorePolicy     string `json:"score_policy" example:"best"`
+	ScorePolicyTopK uint   `json:"score_policy_top_k" example:"1"`
+
+	RequiredLabels []string `json:"required_labels" example:"java,big-memory"`
 }
 
 // GetQuestionScripts is a function to get the scripts for a question
@@ -1066,10 +1555,64 @@ func GetQuestionScripts(c *gin.Context) {
 		Success: true,
 		Message: "Question test script fetched successfully",
 		Data: QuestionScripts{
-			CompileScript: questionTestScript.CompileScript,
-			ExecuteScript: questionTestScript.ExecuteScript,
-			ScoreScript:   questionTestScript.ScoreScript,
-			ScoreMap:      questionTestScript.ScoreMap,
+			Profile:        questionTestScript.Profile,
+			JudgeMode:      string(questionTestScript.JudgeMode),
+			Checker:        string(questionTestScript.Checker),
+			FloatTolerance: questionTestScript.FloatTolerance,
+			CheckerScript:  questionTestScript.CheckerScript,
+			CompileScript:  questionTestScript.CompileScript,
+			ExecuteScript:  questionTestScript.ExecuteScript,
+			ScoreScript:    questionTestScript.ScoreScript,
+			ScoreMap:       questionTestScript.ScoreMap,
+
+			CancelSuperseded: questionTestScript.CancelSuperseded,
+			CoalesceQueued:   questionTestScript.CoalesceQueued,
+
+			MinSubmitInterval: questionTestScript.MinSubmitInterval,
+			MaxSubmitsPerHour: questionTestScript.MaxSubmitsPerHour,
+			MaxSubmitsPerDay:  questionTestScript.MaxSubmitsPerDay,
+			SubmitQuota:       questionTestScript.SubmitQuota,
+
+			LatePolicy:          string(questionTestScript.LatePolicy),
+			LateGracePeriod:     questionTestScript.LateGracePeriod,
+			LatePenalty:         questionTestScript.LatePenalty,
+			LatePenaltyInterval: questionTestScript.LatePenaltyInterval,
+			LateCutoff:          questionTestScript.LateCutoff,
+
+			ScorePolicy:     string(questionTestScript.ScorePolicy),
+			ScorePolicyTopK: questionTestScript.ScorePolicyTopK,
+
+			RequiredLabels: splitLabels(questionTestScript.RequiredLabels),
 		},
 	})
 }
+
+// joinLabels stores the required sandbox labels as a comma separated list
+func joinLabels(labels []string) (string, string) {
+	seen := make(map[string]bool, len(labels))
+	cleaned := make([]string, 0, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		if strings.Contains(label, ",") {
+			return "", "Labels must not contain commas"
+		}
+		seen[label] = true
+		cleaned = append(cleaned, label)
+	}
+	joined := strings.Join(cleaned, ",")
+	if len(joined) > 500 {
+		return "", "Required labels are too long"
+	}
+	return joined, ""
+}
+
+// splitLabels returns the required sandbox labels stored by joinLabels
+func splitLabels(labels string) []string {
+	if labels == "" {
+		return []string{}
+	}
+	return strings.Split(labels, ",")
+}
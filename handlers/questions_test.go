@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"testing"
+
+	"OJ-API/profiles"
+)
+
+func TestApplySuggestedLimits(t *testing.T) {
+	memory := uint(65536)
+	limit := AddQuestionLimit{Memory: &memory}
+	applySuggestedLimits(&limit, profiles.Limits{Memory: 1048576, Time: 10000, CompileTime: 60000})
+
+	cases := []struct {
+		name  string
+		field *uint
+		want  uint // 0 if the limit stays unset
+	}{
+		{name: "given limit is kept", field: limit.Memory, want: 65536},
+		{name: "suggested limit", field: limit.Time, want: 10000},
+		{name: "suggested compile limit", field: limit.CompileTime, want: 60000},
+		{name: "no suggestion", field: limit.WallTime},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			switch {
+			case tc.want == 0 && tc.field != nil:
+				t.Errorf("limit = %d, want unset", *tc.field)
+			case tc.want != 0 && (tc.field == nil || *tc.field != tc.want):
+				t.Errorf("limit = %v, want %d", tc.field, tc.want)
+			}
+		})
+	}
+}
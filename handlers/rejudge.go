@@ -0,0 +1,498 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"OJ-API/config"
+	"OJ-API/database"
+	"OJ-API/models"
+	"OJ-API/services"
+	"OJ-API/utils"
+)
+
+// rejudgeSubmission queues a new judge of the commit of source, or of the repository
+// HEAD if source has no commit, as part of an admin rejudge batch. The new entry keeps
+// the push time and late penalty of source, so score policies and deadlines treat it as
+// the same push.
+func rejudgeSubmission(db *gorm.DB, question *models.Question, uqr *models.UserQuestionRelation, username string, source models.UserQuestionTable, batchID uint) (models.UserQuestionTable, error) {
+	newScore := models.UserQuestionTable{
+		UQRID:          uqr.ID,
+		Score:          -3,
+		JudgeTime:      time.Now().UTC(),
+		Message:        "Waiting for judging...",
+		Commit:         source.Commit,
+		CreatedAt:      source.CreatedAt,
+		LatePenalty:    source.LatePenalty,
+		IsRejudge:      true,
+		RejudgeBatchID: &batchID,
+	}
+	if err := db.Create(&newScore).Error; err != nil {
+		return newScore, err
+	}
+
+	// 構建 Git 倉庫 URL
+	gitRepoURL := config.GetGiteaBaseURL() + "/" + uqr.GitUserRepoURL
+
+	// 將任務寫入持久化隊列，Git clone 將在沙箱端完成
+	if err := services.GetSandboxClientManager().ReserveRejudgeJob(
+		question.GitRepoURL, // parentGitFullName
+		gitRepoURL,          // gitRepoURL
+		uqr.GitUserRepoURL,  // gitFullName
+		source.Commit,       // gitAfterHash (空字符串表示使用 HEAD)
+		username,            // gitUsername
+		uint64(newScore.ID), // userQuestionTableID
+	); err != nil {
+		db.Model(&newScore).Updates(models.UserQuestionTable{
+			Score:   -2,
+			Message: fmt.Sprintf("Failed to queue job: %v", err),
+		})
+		return newScore, err
+	}
+	return newScore, nil
+}
+
+type RejudgeRequest struct {
+	UQTIDs     []uint   `json:"uqt_ids" example:"1,2" description:"Submissions to judge again at their recorded commit"`
+	Commits    []string `json:"commits" example:"0123456789abcdef0123456789abcdef01234567" description:"Commits to judge again, matched against the recorded commit of the submissions"`
+	QuestionID *uint    `json:"question_id" example:"1" description:"Only match commits of this question"`
+}
+
+type RejudgedSubmission struct {
+	SourceUQTID uint   `json:"source_uqt_id" example:"1"`
+	UQTID       uint   `json:"uqt_id" example:"3"`
+	Commit      string `json:"commit" example:"0123456789abcdef0123456789abcdef01234567"`
+	Error       string `json:"error,omitempty" example:""`
+}
+
+type RejudgeResponseData struct {
+	Batch       models.RejudgeBatch  `json:"batch"`
+	Submissions []RejudgedSubmission `json:"submissions"`
+}
+
+// RejudgeSubmissions is a function to judge specific submissions again
+//
+//	@Summary		Rejudge specific submissions
+//	@Description	Judge the given submissions or commits again exactly at their recorded commit, tracked as one rejudge batch. Each new result keeps the push time and late penalty of the original submission.
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			rejudge	body	RejudgeRequest	true	"Submissions to rejudge"
+//	@Success		200	{object}	ResponseHTTP{data=RejudgeResponseData}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/score/admin/rejudge [post]
+//	@Security		BearerAuth
+func RejudgeSubmissions(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req RejudgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Failed to parse request",
+		})
+		return
+	}
+	if len(req.UQTIDs) == 0 && len(req.Commits) == 0 {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Either uqt_ids or commits is required",
+		})
+		return
+	}
+
+	query := db.Model(&models.UserQuestionTable{}).
+		Joins("JOIN user_question_relations UQR ON UQR.id = user_question_tables.uqr_id")
+	switch {
+	case len(req.UQTIDs) > 0 && len(req.Commits) > 0:
+		query = query.Where("user_question_tables.id IN ? OR user_question_tables.commit IN ?", req.UQTIDs, req.Commits)
+	case len(req.UQTIDs) > 0:
+		query = query.Where("user_question_tables.id IN ?", req.UQTIDs)
+	default:
+		query = query.Where("user_question_tables.commit IN ?", req.Commits)
+	}
+	if req.QuestionID != nil {
+		query = query.Where("UQR.question_id = ?", *req.QuestionID)
+	}
+	var sources []models.UserQuestionTable
+	if err := query.Order("user_question_tables.id").Find(&sources).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch submissions",
+		})
+		return
+	}
+	if len(sources) == 0 {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "No submissions found",
+		})
+		return
+	}
+
+	// Earlier rejudges of the same commit share the push, judge it only once
+	type pushKey struct {
+		uqrID  uint
+		commit string
+	}
+	seen := make(map[pushKey]bool)
+	pushes := make([]models.UserQuestionTable, 0, len(sources))
+	for _, source := range sources {
+		key := pushKey{source.UQRID, source.Commit}
+		if !seen[key] {
+			seen[key] = true
+			pushes = append(pushes, source)
+		}
+	}
+
+	batch := models.RejudgeBatch{
+		QuestionID: req.QuestionID,
+		Mode:       "submissions",
+		Total:      len(pushes),
+		CreatedBy:  jwtClaims.UserID,
+	}
+	if err := db.Create(&batch).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to create rejudge batch",
+		})
+		return
+	}
+
+	uqrs := make(map[uint]*models.UserQuestionRelation)
+	questions := make(map[uint]*models.Question)
+	usernames := make(map[uint]string)
+	rejudged := make([]RejudgedSubmission, 0, len(pushes))
+	for _, source := range pushes {
+		result := RejudgedSubmission{SourceUQTID: source.ID, Commit: source.Commit}
+
+		uqr, ok := uqrs[source.UQRID]
+		if !ok {
+			uqr = &models.UserQuestionRelation{}
+			if err := db.First(uqr, source.UQRID).Error; err != nil {
+				result.Error = "User question relation not found"
+				rejudged = append(rejudged, result)
+				continue
+			}
+			uqrs[source.UQRID] = uqr
+		}
+		question, ok := questions[uqr.QuestionID]
+		if !ok {
+			question = &models.Question{}
+			if err := db.First(question, uqr.QuestionID).Error; err != nil {
+				result.Error = "Question not found"
+				rejudged = append(rejudged, result)
+				continue
+			}
+			questions[uqr.QuestionID] = question
+		}
+		username, ok := usernames[uqr.UserID]
+		if !ok {
+			var user models.User
+			if err := db.First(&user, uqr.UserID).Error; err != nil {
+				result.Error = "User not found"
+				rejudged = append(rejudged, result)
+				continue
+			}
+			username = user.UserName
+			usernames[uqr.UserID] = username
+		}
+
+		newScore, err := rejudgeSubmission(db, question, uqr, username, source, batch.ID)
+		result.UQTID = newScore.ID
+		if err != nil {
+			result.Error = err.Error()
+		}
+		rejudged = append(rejudged, result)
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Rejudging the submissions",
+		Data: RejudgeResponseData{
+			Batch:       batch,
+			Submissions: rejudged,
+		},
+	})
+}
+
+type RejudgeBatchData struct {
+	models.RejudgeBatch
+	Status    string `json:"status" example:"running" description:"running, done or cancelled"`
+	Queued    int    `json:"queued" example:"10"`
+	Running   int    `json:"running" example:"2"`
+	Done      int    `json:"done" example:"280"`
+	Failed    int    `json:"failed" example:"1"`
+	Cancelled int    `json:"cancelled" example:"0"`
+}
+
+type RejudgeBatchSubmission struct {
+	UQTID          uint    `json:"uqt_id" example:"3"`
+	UserName       string  `json:"user_name" example:"student"`
+	GitUserRepoURL string  `json:"git_user_repo_url" example:"student/question"`
+	Commit         string  `json:"commit" example:"0123456789abcdef0123456789abcdef01234567"`
+	Score          float64 `json:"score" example:"100"`
+	Verdict        string  `json:"verdict" example:"ACCEPTED"`
+	Message        string  `json:"message" example:"Judged successfully"`
+}
+
+type RejudgeBatchDetail struct {
+	RejudgeBatchData
+	Submissions []RejudgeBatchSubmission `json:"submissions"`
+}
+
+// rejudgeBatches returns the batches with the progress of their submissions
+func rejudgeBatches(db *gorm.DB) *gorm.DB {
+	return db.Model(&models.RejudgeBatch{}).
+		Select("rejudge_batches.*, " +
+			"COUNT(UQT.id) FILTER (WHERE UQT.score = -3) AS queued, " +
+			"COUNT(UQT.id) FILTER (WHERE UQT.score = -1) AS running, " +
+			"COUNT(UQT.id) FILTER (WHERE UQT.score >= 0) AS done, " +
+			"COUNT(UQT.id) FILTER (WHERE UQT.score = -2) AS failed, " +
+			"COUNT(UQT.id) FILTER (WHERE UQT.score < -3) AS cancelled").
+		Joins("LEFT JOIN user_question_tables UQT ON UQT.rejudge_batch_id = rejudge_batches.id").
+		Group("rejudge_batches.id")
+}
+
+func (b *RejudgeBatchData) fillStatus() {
+	switch {
+	case b.CancelledAt != nil:
+		b.Status = "cancelled"
+	case b.Queued+b.Running > 0:
+		b.Status = "running"
+	default:
+		b.Status = "done"
+	}
+}
+
+// ListRejudgeBatches is a function to list rejudge batches
+//
+//	@Summary		List rejudge batches
+//	@Description	List the rejudge batches with the number of queued, running, done, failed and cancelled submissions
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			question_id	query	int	false	"filter by question ID"
+//	@Success		200	{object}	ResponseHTTP{data=[]RejudgeBatchData}
+//	@Failure		401
+//	@Failure		503
+//	@Router			/api/score/admin/rejudge [get]
+//	@Security		BearerAuth
+func ListRejudgeBatches(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	query := rejudgeBatches(db)
+	if questionID := c.Query("question_id"); questionID != "" {
+		query = query.Where("rejudge_batches.question_id = ?", questionID)
+	}
+
+	var batches []RejudgeBatchData
+	if err := query.Order("rejudge_batches.id DESC").Scan(&batches).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch rejudge batches",
+		})
+		return
+	}
+	for i := range batches {
+		batches[i].fillStatus()
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Rejudge batches fetched successfully",
+		Data:    batches,
+	})
+}
+
+// GetRejudgeBatch is a function to get the progress of a rejudge batch
+//
+//	@Summary		Get a rejudge batch
+//	@Description	Get the progress of a rejudge batch and the state of each of its submissions
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"rejudge batch ID"
+//	@Success		200	{object}	ResponseHTTP{data=RejudgeBatchDetail}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/score/admin/rejudge/{id} [get]
+//	@Security		BearerAuth
+func GetRejudgeBatch(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid rejudge batch ID",
+		})
+		return
+	}
+
+	var batches []RejudgeBatchData
+	if err := rejudgeBatches(db).Where("rejudge_batches.id = ?", id).Scan(&batches).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch rejudge batch",
+		})
+		return
+	}
+	if len(batches) == 0 {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Rejudge batch not found",
+		})
+		return
+	}
+	detail := RejudgeBatchDetail{RejudgeBatchData: batches[0]}
+	detail.fillStatus()
+
+	if err := db.Table("user_question_tables UQT").
+		Select("UQT.id AS uqt_id, U.user_name, UQR.git_user_repo_url, UQT.commit, UQT.score, UQT.verdict, UQT.message").
+		Joins("JOIN user_question_relations UQR ON UQR.id = UQT.uqr_id").
+		Joins("JOIN users U ON U.id = UQR.user_id").
+		Where("UQT.rejudge_batch_id = ?", id).
+		Order("UQT.id").
+		Scan(&detail.Submissions).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch rejudge batch submissions",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Rejudge batch fetched successfully",
+		Data:    detail,
+	})
+}
+
+// CancelRejudgeBatch is a function to cancel a rejudge batch
+//
+//	@Summary		Cancel a rejudge batch
+//	@Description	Cancel the submissions of a rejudge batch that are still queued or being judged. Finished submissions keep their result.
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path	int	true	"rejudge batch ID"
+//	@Success		200	{object}	ResponseHTTP{data=int}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		409
+//	@Failure		503
+//	@Router			/api/score/admin/rejudge/{id}/cancel [post]
+//	@Security		BearerAuth
+func CancelRejudgeBatch(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid rejudge batch ID",
+		})
+		return
+	}
+
+	var batch models.RejudgeBatch
+	if err := db.First(&batch, id).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Rejudge batch not found",
+		})
+		return
+	}
+	if batch.CancelledAt != nil {
+		c.JSON(409, ResponseHTTP{
+			Success: false,
+			Message: "Rejudge batch is already cancelled",
+		})
+		return
+	}
+
+	// Record the cancellation, then cancel the submissions that are still pending
+	now := time.Now().UTC()
+	if err := db.Model(&batch).Updates(map[string]interface{}{
+		"cancelled_at": now,
+		"cancelled_by": jwtClaims.UserID,
+	}).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to cancel rejudge batch",
+		})
+		return
+	}
+
+	var pending []uint
+	if err := db.Model(&models.UserQuestionTable{}).
+		Where("rejudge_batch_id = ? AND score IN ?", batch.ID, []float64{-3, -1}).
+		Pluck("id", &pending).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch pending submissions",
+		})
+		return
+	}
+
+	clientManager := services.GetSandboxClientManager()
+	cancelled := 0
+	reason := fmt.Sprintf("Rejudge batch %d cancelled", batch.ID)
+	for _, uqtID := range pending {
+		n, err := clientManager.CancelJob(uqtID, reason)
+		if err != nil {
+			utils.Warnf("Failed to cancel submission %d of rejudge batch %d: %v", uqtID, batch.ID, err)
+			continue
+		}
+		cancelled += n
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Rejudge batch cancelled",
+		Data:    cancelled,
+	})
+}
@@ -91,12 +91,14 @@ type StatusResponse struct {
 	AvailableCount  int `json:"available_count"`
 	WaitingCount    int `json:"waiting_count"`
 	ProcessingCount int `json:"processing_count"`
+
+	Queue []services.QueuePriorityStats `json:"queue"`
 }
 
 // GetSandboxStatus godoc
 //
 // @Summary Get the current available sandbox count and waiting count
-// @Description Get the current available sandbox count and waiting count
+// @Description Get the current available sandbox count and waiting count, with the queued jobs per priority class (exam, practice, rejudge)
 // @Tags Sandbox
 // @Produce json
 // @Success		200		{object}	ResponseHTTP{data=StatusResponse}
@@ -118,6 +120,7 @@ func GetSandboxStatus(c *gin.Context) {
 		AvailableCount:  int(statusResp.AvailableCount),
 		WaitingCount:    int(statusResp.WaitingCount),
 		ProcessingCount: int(statusResp.ProcessingCount),
+		Queue:           clientManager.GetQueueStats(),
 	}
 
 	c.JSON(200, ResponseHTTP{
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"OJ-API/models"
+	"OJ-API/services"
+	"OJ-API/utils"
+)
+
+type SandboxActionRequest struct {
+	Reason string `json:"reason" example:"Host maintenance"`
+}
+
+// ListSandboxInstances is a function to list the connected sandbox instances
+//
+//	@Summary		List sandbox instances
+//	@Description	List the sandbox instances connected to this API server with their labels, version, active jobs and judge totals since they connected
+//	@Tags			Sandbox
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	ResponseHTTP{data=[]services.SandboxInstanceInfo}
+//	@Failure		401
+//	@Router			/api/sandbox/admin/instances [get]
+//	@Security		BearerAuth
+func ListSandboxInstances(c *gin.Context) {
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Sandbox instances fetched successfully",
+		Data:    services.GetSandboxClientManager().ListInstances(),
+	})
+}
+
+// CordonSandbox is a function to stop sending new jobs to a sandbox
+//
+//	@Summary		Cordon a sandbox
+//	@Description	Stop sending new jobs to a sandbox, its running jobs are not affected
+//	@Tags			Sandbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string					true	"sandbox ID"
+//	@Param			action	body	SandboxActionRequest	false	"Reason"
+//	@Success		200	{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/sandbox/admin/instances/{id}/cordon [post]
+//	@Security		BearerAuth
+func CordonSandbox(c *gin.Context) {
+	sandboxAction(c, "Sandbox cordoned successfully", func(m *services.SandboxClientManager, id string, reason string) error {
+		return m.CordonSandbox(id, true, reason)
+	})
+}
+
+// UncordonSandbox is a function to resume sending new jobs to a sandbox
+//
+//	@Summary		Uncordon a sandbox
+//	@Description	Resume sending new jobs to a cordoned sandbox, a draining sandbox cannot be uncordoned
+//	@Tags			Sandbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string					true	"sandbox ID"
+//	@Param			action	body	SandboxActionRequest	false	"Reason"
+//	@Success		200	{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		409
+//	@Failure		503
+//	@Router			/api/sandbox/admin/instances/{id}/uncordon [post]
+//	@Security		BearerAuth
+func UncordonSandbox(c *gin.Context) {
+	sandboxAction(c, "Sandbox uncordoned successfully", func(m *services.SandboxClientManager, id string, reason string) error {
+		return m.CordonSandbox(id, false, reason)
+	})
+}
+
+// DrainSandbox is a function to drain a sandbox
+//
+//	@Summary		Drain a sandbox
+//	@Description	Stop sending new jobs to a sandbox, the sandbox finishes its current jobs, reports their results and then disconnects and shuts down
+//	@Tags			Sandbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string					true	"sandbox ID"
+//	@Param			action	body	SandboxActionRequest	false	"Reason"
+//	@Success		200	{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/sandbox/admin/instances/{id}/drain [post]
+//	@Security		BearerAuth
+func DrainSandbox(c *gin.Context) {
+	sandboxAction(c, "Sandbox draining", func(m *services.SandboxClientManager, id string, reason string) error {
+		return m.DrainSandbox(id, reason)
+	})
+}
+
+// EvictSandbox is a function to evict a sandbox
+//
+//	@Summary		Evict a sandbox
+//	@Description	Remove a sandbox immediately, it aborts its jobs and shuts down. Its unfinished jobs are queued again for other sandboxes.
+//	@Tags			Sandbox
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	string					true	"sandbox ID"
+//	@Param			action	body	SandboxActionRequest	false	"Reason"
+//	@Success		200	{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/sandbox/admin/instances/{id}/evict [post]
+//	@Security		BearerAuth
+func EvictSandbox(c *gin.Context) {
+	sandboxAction(c, "Sandbox evicted successfully", func(m *services.SandboxClientManager, id string, reason string) error {
+		return m.EvictSandbox(id, reason)
+	})
+}
+
+// sandboxAction checks the permission, parses the optional reason and runs a fleet action on the sandbox in the path
+func sandboxAction(c *gin.Context, message string, action func(m *services.SandboxClientManager, id string, reason string) error) {
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	var req SandboxActionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, ResponseHTTP{
+				Success: false,
+				Message: "Failed to parse request",
+			})
+			return
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = "Requested by " + jwtClaims.Username
+	}
+
+	err := action(services.GetSandboxClientManager(), c.Param("id"), req.Reason)
+	switch {
+	case errors.Is(err, services.ErrSandboxNotFound):
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Sandbox not found on this server",
+		})
+		return
+	case errors.Is(err, services.ErrSandboxDraining):
+		c.JSON(409, ResponseHTTP{
+			Success: false,
+			Message: "Sandbox is draining",
+		})
+		return
+	case err != nil:
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to notify sandbox: " + err.Error(),
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: message,
+	})
+}
@@ -4,23 +4,69 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
-	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"OJ-API/config"
 	"OJ-API/database"
 	"OJ-API/models"
+	"OJ-API/sandbox"
 	"OJ-API/services"
 	"OJ-API/utils"
 )
 
 type Score struct {
-	Score     float64   `json:"score" example:"100" validate:"required"`
-	Message   string    `json:"message" example:"Scored successfully" validate:"required"`
-	JudgeTime time.Time `json:"judge_time" example:"2006-01-02T15:04:05Z07:00" time_format:"RFC3339" validate:"required"`
+	ID        uint                     `json:"id" example:"1"`
+	Score     float64                  `json:"score" example:"100" validate:"required"`
+	Message   string                   `json:"message" example:"Scored successfully" validate:"required"`
+	JudgeTime time.Time                `json:"judge_time" example:"2006-01-02T15:04:05Z07:00" time_format:"RFC3339" validate:"required"`
+	Verdict   string                   `json:"verdict" example:"ACCEPTED"`
+	CPUTimeMs int64                    `json:"cpu_time_ms" example:"120" description:"Largest CPU time among targets"`
+	MemoryKB  int64                    `json:"memory_kb" example:"2048" description:"Largest peak memory among targets"`
+	Metrics   []models.ExecutionMetric `json:"metrics" description:"Per-target resource usage"`
+
+	RawScore    float64 `json:"raw_score" example:"100" description:"Score before the late penalty"`
+	LatePenalty float64 `json:"late_penalty" example:"0" description:"Late penalty in percent"`
+}
+
+// toScores converts submissions to Score with their per-target execution metrics
+func toScores(db *gorm.DB, uqts []models.UserQuestionTable) ([]Score, error) {
+	ids := make([]uint, 0, len(uqts))
+	for _, uqt := range uqts {
+		ids = append(ids, uqt.ID)
+	}
+
+	metrics := make(map[uint][]models.ExecutionMetric)
+	if len(ids) > 0 {
+		var rows []models.ExecutionMetric
+		if err := db.Where("uqt_id IN ?", ids).Order("id").Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			metrics[row.UQTID] = append(metrics[row.UQTID], row)
+		}
+	}
+
+	var scores []Score
+	for _, uqt := range uqts {
+		scores = append(scores, Score{
+			ID:        uqt.ID,
+			Score:     uqt.Score,
+			Message:   uqt.Message,
+			JudgeTime: uqt.CreatedAt,
+			Verdict:   uqt.Verdict,
+			CPUTimeMs: uqt.CPUTimeMs,
+			MemoryKB:  uqt.MemoryKB,
+			Metrics:   metrics[uqt.ID],
+
+			RawScore:    uqt.RawScore,
+			LatePenalty: uqt.LatePenalty,
+		})
+	}
+	return scores, nil
 }
 
 type GetScoreResponseData struct {
@@ -113,13 +159,13 @@ func GetScoreByRepo(c *gin.Context) {
 		return
 	}
 
-	var scores []Score
-	for _, score := range _scores {
-		scores = append(scores, Score{
-			Score:     score.Score,
-			Message:   score.Message,
-			JudgeTime: score.CreatedAt,
+	scores, err := toScores(db, _scores)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to get execution metrics",
 		})
+		return
 	}
 	c.JSON(200, ResponseHTTP{
 		Success: true,
@@ -237,13 +283,13 @@ func GetScoreByUQRID(c *gin.Context) {
 		return
 	}
 
-	var scores []Score
-	for _, score := range _scores {
-		scores = append(scores, Score{
-			Score:     score.Score,
-			Message:   score.Message,
-			JudgeTime: score.CreatedAt,
+	scores, err := toScores(db, _scores)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to get execution metrics",
 		})
+		return
 	}
 	c.JSON(200, ResponseHTTP{
 		Success: true,
@@ -255,6 +301,194 @@ func GetScoreByUQRID(c *gin.Context) {
 	})
 }
 
+// GetScoreStream streams the judge status of a submission
+//
+//	@Summary		Stream judge status of a submission
+//	@Description	Push state transitions and per-target compile/execute/score progress of a submission as Server-Sent Events. The stream ends after the finished event.
+//	@Tags			Score
+//	@Produce		text/event-stream
+//	@Param			id	path	int	true	"submission (user question table) ID"
+//	@Success		200	{object}	services.JudgeEvent
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/score/uqt/{id}/stream [get]
+//	@Security		BearerAuth
+func GetScoreStream(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+
+	uqtID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid submission ID",
+		})
+		return
+	}
+
+	var uqt models.UserQuestionTable
+	if err := db.Preload("UQR").First(&uqt, uqtID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.JSON(404, ResponseHTTP{
+				Success: false,
+				Message: "Submission not found",
+			})
+			return
+		}
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to get submission",
+		})
+		return
+	}
+	if uqt.UQR.UserID != jwtClaims.UserID && !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	events, unsubscribe := services.SubscribeJudgeEvents(uqt.ID)
+	defer unsubscribe()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	// Send the current state first
+	current := services.JudgeEventFromScore(uqt)
+	c.SSEvent("judge", current)
+	c.Writer.Flush()
+	if current.Type == services.JudgeEventFinished {
+		return
+	}
+	lastScore := current.Score
+
+	// The sandbox may be connected to another API replica, so poll the
+	// database as well to make sure the final result is always delivered
+	poll := time.NewTicker(2 * time.Second)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(15 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event := <-events:
+			c.SSEvent("judge", event)
+			c.Writer.Flush()
+			if event.Type == services.JudgeEventFinished {
+				return
+			}
+			lastScore = event.Score
+		case <-poll.C:
+			var latest models.UserQuestionTable
+			if err := db.First(&latest, uqt.ID).Error; err != nil || latest.Score == lastScore {
+				continue
+			}
+			event := services.JudgeEventFromScore(latest)
+			c.SSEvent("judge", event)
+			c.Writer.Flush()
+			if event.Type == services.JudgeEventFinished {
+				return
+			}
+			lastScore = event.Score
+		case <-keepAlive.C:
+			c.SSEvent("ping", time.Now().UTC())
+			c.Writer.Flush()
+		}
+	}
+}
+
+type CancelSubmissionRequest struct {
+	Reason string `json:"reason" example:"Cancelled by administrator"`
+}
+
+// CancelSubmission cancels the judge of a submission
+//
+//	@Summary		Cancel the judge of a submission
+//	@Description	Cancel a queued or in-flight judge job of a submission. A running job is aborted on its sandbox, the box is released and the submission is marked CANCELLED with score -4.
+//	@Tags			Score
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path	int						true	"submission (user question table) ID"
+//	@Param			request	body	CancelSubmissionRequest	false	"Cancel reason"
+//	@Success		200		{object}	ResponseHTTP{}
+//	@Failure		400
+//	@Failure		401
+//	@Failure		404
+//	@Failure		503
+//	@Router			/api/score/admin/uqt/{id}/cancel [post]
+//	@Security		BearerAuth
+func CancelSubmission(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	uqtID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Invalid submission ID",
+		})
+		return
+	}
+
+	var req CancelSubmissionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(400, ResponseHTTP{
+				Success: false,
+				Message: "Failed to parse request",
+			})
+			return
+		}
+	}
+	if req.Reason == "" {
+		req.Reason = "Cancelled by administrator"
+	}
+
+	var uqt models.UserQuestionTable
+	if err := db.First(&uqt, uqtID).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Submission not found",
+		})
+		return
+	}
+
+	cancelled, err := services.GetSandboxClientManager().CancelJob(uqt.ID, req.Reason)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: fmt.Sprintf("Failed to cancel submission: %v", err),
+		})
+		return
+	}
+	if cancelled == 0 {
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Submission is not being judged",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Submission cancelled successfully",
+	})
+}
+
 // GetScoreByQuestionID is a function to get a score by question ID
 //
 //	@Summary		Get a score by question ID
@@ -339,13 +573,13 @@ func GetScoreByQuestionID(c *gin.Context) {
 		})
 		return
 	}
-	var scores []Score
-	for _, score := range _scores {
-		scores = append(scores, Score{
-			Score:     score.Score,
-			Message:   score.Message,
-			JudgeTime: score.CreatedAt,
+	scores, err := toScores(db, _scores)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to get execution metrics",
 		})
+		return
 	}
 	if len(scores) == 0 {
 		c.JSON(404, ResponseHTTP{
@@ -367,7 +601,7 @@ func GetScoreByQuestionID(c *gin.Context) {
 // ReScoreUserQuestion is a function to re-score a specific user's question by question ID
 //
 //	@Summary		Re-score a specific user's question
-//	@Description	Re-score a specific user's question by question ID
+//	@Description	Judge the latest push of the user again at its commit as a new submission, queued and scored like a push
 //	@Tags			Score
 //	@Accept			json
 //	@Produce		json
@@ -376,6 +610,7 @@ func GetScoreByQuestionID(c *gin.Context) {
 //	@Failure		400
 //	@Failure		401
 //	@Failure		404
+//	@Failure		429		{object}	ResponseHTTP{}
 //	@Failure		503
 //	@Router			/api/score/{question_id}/question/user_rescore [post]
 //	@Security		BearerAuth
@@ -400,77 +635,127 @@ func ReScoreUserQuestion(c *gin.Context) {
 		})
 		return
 	}
-	// Check question active time
-	if question.StartTime.After(time.Now().UTC()) || question.EndTime.Before(time.Now().UTC()) {
+	var uqr models.UserQuestionRelation
+	if err := db.Model(&models.UserQuestionRelation{}).
+		Where("question_id = ? AND user_id = ?", questionID, jwtClaims.UserID).
+		First(&uqr).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to re-score the question",
+		})
+		return
+	}
+
+	var script models.QuestionTestScript
+	if err := db.Where("question_id = ?", question.ID).First(&script).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "No test script found for this question",
+		})
+		return
+	}
+
+	// Check question active time, the end time may be extended for this user
+	now := time.Now().UTC()
+	if question.StartTime.After(now) {
 		c.JSON(410, ResponseHTTP{
 			Success: false,
 			Message: "Question is not in active time range",
 		})
 		return
 	}
-
-	var uqr models.UserQuestionRelation
-	if err := db.Model(&models.UserQuestionRelation{}).
-		Where("question_id = ? AND user_id = ?", questionID, jwtClaims.UserID).
-		First(&uqr).Error; err != nil {
+	extension, err := services.FindExtension(db, jwtClaims.UserID, question.ID)
+	if err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
-			Message: "Failed to re-score the question",
+			Message: "Failed to load deadline extension",
+		})
+		return
+	}
+	deadline := question
+	deadline.EndTime = services.EffectiveEndTime(&question, extension)
+
+	// Late rescores are accepted with a penalty like late pushes, see PostGiteaHook
+	penalty, accepted := latePenalty(&deadline, &script, now)
+	if !accepted {
+		c.JSON(410, ResponseHTTP{
+			Success: false,
+			Message: "Question is not in active time range",
 		})
 		return
 	}
 
+	// Judge the latest push again at its commit, HEAD if nothing was pushed yet.
+	// It is a new submission of the user, not a rejudge: it is queued like a push and scored at the current time.
+	var latest models.UserQuestionTable
+	db.Where("uqr_id = ? AND commit <> '' AND rejudge_batch_id IS NULL", uqr.ID).Order("created_at DESC, id DESC").Limit(1).Find(&latest)
 	newScore := models.UserQuestionTable{
-		UQR:       uqr,
-		Score:     -3,
-		JudgeTime: time.Now().UTC(),
-		Commit:    "",
-		Message:   "Waiting for judging...",
+		UQRID:       uqr.ID,
+		Score:       -3,
+		JudgeTime:   now,
+		Commit:      latest.Commit,
+		Message:     "Waiting for judging...",
+		LatePenalty: penalty,
 	}
-	if err := db.Create(&newScore).Error; err != nil {
+	// Rescores count against the submission policy like pushes, see PostGiteaHook
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&models.UserQuestionRelation{}, uqr.ID).Error; err != nil {
+			return err
+		}
+		reason, err := checkSubmissionPolicy(tx, &script, uqr.ID, now)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			newScore.Score = -6
+			newScore.Verdict = string(sandbox.RATE_LIMITED)
+			newScore.Message = reason
+		}
+		return tx.Create(&newScore).Error
+	}); err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
 			Message: "Failed to create new score entry",
 		})
 		return
 	}
+	if newScore.Score == -6 {
+		c.JSON(429, ResponseHTTP{
+			Success: false,
+			Message: newScore.Message,
+		})
+		return
+	}
+
+	// 構建 Git 倉庫 URL
+	gitRepoURL := config.GetGiteaBaseURL() + "/" + uqr.GitUserRepoURL
+
+	// 將任務寫入持久化隊列，Git clone 將在沙箱端完成
+	clientManager := services.GetSandboxClientManager()
+	if err := clientManager.ReserveJob(
+		question.GitRepoURL, // parentGitFullName
+		gitRepoURL,          // gitRepoURL
+		uqr.GitUserRepoURL,  // gitFullName
+		latest.Commit,       // gitAfterHash (空字符串表示使用 HEAD)
+		jwtClaims.Username,  // gitUsername
+		uint64(newScore.ID), // userQuestionTableID
+	); err != nil {
+		db.Model(&newScore).Updates(models.UserQuestionTable{
+			Score:   -2,
+			Message: fmt.Sprintf("Failed to queue job: %v", err),
+		})
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to queue judge job",
+		})
+		return
+	}
 
 	c.JSON(200, ResponseHTTP{
 		Success: true,
 		Message: "Re-scoring the question",
 	})
-
-	go func() {
-		// 獲取用戶 token
-		token, err := utils.GetToken(jwtClaims.UserID)
-		if err != nil {
-			db.Model(&newScore).Updates(models.UserQuestionTable{
-				Score:   -2,
-				Message: fmt.Sprintf("Failed to get token: %v", err),
-			})
-			return
-		}
-
-		// 構建 Git 倉庫 URL
-		gitRepoURL := config.GetGiteaBaseURL() + "/" + uqr.GitUserRepoURL
-
-		// 使用 gRPC 客戶端添加任務，Git clone 將在沙箱端完成
-		clientManager := services.GetSandboxClientManager()
-		if err := clientManager.ReserveJob(
-			question.GitRepoURL, // parentGitFullName
-			gitRepoURL,          // gitRepoURL
-			uqr.GitUserRepoURL,  // gitFullName
-			"",                  // gitAfterHash (空字符串表示使用 HEAD)
-			jwtClaims.Username,  // gitUsername
-			token,               // gitToken
-			uint64(newScore.ID), // userQuestionTableID
-		); err != nil {
-			db.Model(&newScore).Updates(models.UserQuestionTable{
-				Score:   -2,
-				Message: fmt.Sprintf("Failed to queue job: %v", err),
-			})
-		}
-	}()
 }
 
 type TopScore struct {
@@ -573,12 +858,13 @@ func GetTopScore(c *gin.Context) {
 // ReScoreQuestion is a function to re-score a specific question by question ID
 //
 //	@Summary		Re-score a specific question
-//	@Description	Re-score a specific question by question ID
+//	@Description	Re-score every user of a question. By default the latest push of each user is judged again at its recorded commit, mode=best judges the best-scored push instead and mode=head judges the current repository HEAD.
 //	@Tags			Score
 //	@Accept			json
 //	@Produce		json
-//	@Param			question_id	path	int	true	"question ID"
-//	@Success		200		{object}	ResponseHTTP{}
+//	@Param			question_id	path	int		true	"question ID"
+//	@Param			mode		query	string	false	"submission to judge again: last, best or head"	Enums(last, best, head)	default(last)
+//	@Success		200		{object}	ResponseHTTP{data=models.RejudgeBatch}
 //	@Failure		400
 //	@Failure		401
 //	@Failure		404
@@ -614,8 +900,24 @@ func ReScoreQuestion(c *gin.Context) {
 		return
 	}
 
+	mode := c.DefaultQuery("mode", "last")
+	var order string
+	switch mode {
+	case "last", "head":
+		order = "created_at DESC, id DESC"
+	case "best":
+		order = "score DESC, created_at ASC, id ASC"
+	default:
+		c.JSON(400, ResponseHTTP{
+			Success: false,
+			Message: "Mode must be one of last, best and head",
+		})
+		return
+	}
+
 	var uqr []models.UserQuestionRelation
 	if err := db.Model(&models.UserQuestionRelation{}).
+		Preload("User").
 		Where("question_id = ?", questionID).
 		Find(&uqr).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
@@ -625,74 +927,41 @@ func ReScoreQuestion(c *gin.Context) {
 		return
 	}
 
-	newScores := []models.UserQuestionTable{}
-	for _, u := range uqr {
-		newScores = append(newScores, models.UserQuestionTable{
-			UQR:       u,
-			Score:     -3,
-			JudgeTime: time.Now().UTC(),
-			Commit:    "",
-			Message:   "Waiting for judging...",
-		})
+	// Track the re-score as a batch so its progress can be followed and cancelled
+	questionRef := question.ID
+	batch := models.RejudgeBatch{
+		QuestionID: &questionRef,
+		Mode:       mode,
+		Total:      len(uqr),
+		CreatedBy:  jwtClaims.UserID,
 	}
-
-	if err := db.Create(&newScores).Error; err != nil {
+	if err := db.Create(&batch).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
-			Message: "Failed to create new score entry",
+			Message: "Failed to create rejudge batch",
 		})
 		return
 	}
 
+	for _, u := range uqr {
+		// Pick the push to judge again at its recorded commit, users without a recorded
+		// commit fall back to HEAD
+		var source models.UserQuestionTable
+		db.Where("uqr_id = ? AND commit <> ''", u.ID).Order(order).Limit(1).Find(&source)
+		if mode == "head" {
+			// The repository HEAD is the latest push, so it keeps that push's late penalty
+			source = models.UserQuestionTable{LatePenalty: source.LatePenalty}
+		}
+		if _, err := rejudgeSubmission(db, &question, &u, u.User.UserName, source, batch.ID); err != nil {
+			utils.Warnf("Failed to re-score UQR %d: %v", u.ID, err)
+		}
+	}
+
 	c.JSON(200, ResponseHTTP{
 		Success: true,
 		Message: "Re-scoring the question",
+		Data:    batch,
 	})
-
-	go func() {
-		var wg sync.WaitGroup
-
-		for i, u := range uqr {
-			var existingUser models.User
-			db.Where(&models.User{ID: u.UserID}).First(&existingUser)
-
-			// 獲取用戶 token
-			token, err := utils.GetToken(existingUser.ID)
-			if err != nil {
-				db.Model(&newScores[i]).Updates(models.UserQuestionTable{
-					Score:   -2,
-					Message: fmt.Sprintf("Failed to get token: %v", err),
-				})
-				continue
-			}
-
-			// 構建 Git 倉庫 URL
-			gitRepoURL := config.GetGiteaBaseURL() + "/" + u.GitUserRepoURL
-
-			wg.Add(1)
-			go func(i int, gitRepoURL string, gitFullName string, username string, token string) {
-				defer wg.Done()
-				// 使用 gRPC 客戶端添加任務，Git clone 將在沙箱端完成
-				clientManager := services.GetSandboxClientManager()
-				if err := clientManager.ReserveJob(
-					question.GitRepoURL,     // parentGitFullName
-					gitRepoURL,              // gitRepoURL
-					gitFullName,             // gitFullName
-					"",                      // gitAfterHash (空字符串表示使用 HEAD)
-					username,                // gitUsername
-					token,                   // gitToken
-					uint64(newScores[i].ID), // userQuestionTableID
-				); err != nil {
-					db.Model(&newScores[i]).Updates(models.UserQuestionTable{
-						Score:   -2,
-						Message: fmt.Sprintf("Failed to queue job: %v", err),
-					})
-				}
-			}(i, gitRepoURL, u.GitUserRepoURL, existingUser.UserName, token)
-		}
-
-		wg.Wait()
-	}()
 }
 
 // GetAllScore is a function to get all scores for the user
@@ -787,6 +1056,7 @@ type LeaderboardScore struct {
 	UserName       string          `json:"user_name" example:"owner" validate:"required"`
 	TotalScore     float64         `json:"total_score" example:"200" validate:"required"`
 	QuestionScores []QuestionScore `json:"question_scores" validate:"required"`
+	InProgress     bool            `json:"in_progress" example:"false" description:"The user still has time left on an extended deadline"`
 }
 
 type GetLeaderboardResponseData struct {
@@ -821,34 +1091,19 @@ func GetLeaderboard(c *gin.Context) {
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 	offset := (page - 1) * limit
 
+	// Final scores of active questions that are not part of an exam, resolved by their score policy
+	questionIDs := db.Model(&models.Question{}).
+		Select("id").
+		Where("is_active = ?", true).
+		Where("id NOT IN (SELECT question_id FROM exam_questions)")
+	subquery := db.Table("(?) AS fs", services.FinalScores(db, questionIDs)).
+		Select("fs.user_id, fs.question_id, fs.uqr_id, fs.submitted_at AS created_at, GREATEST(fs.score, 0) AS max_score").
+		Joins("JOIN users ON users.id = fs.user_id").
+		Where("users.is_admin = ?", false)
+
 	// Get total count of users who have scores
 	var totalCount int64
-	subquery := db.Raw(`
-	SELECT DISTINCT ON (UQR.user_id, UQR.question_id)
-		UQR.user_id AS user_id,
-		UQR.question_id,
-		uqt.created_at,
-		GREATEST(uqt.score, 0) AS max_score
-	FROM user_question_tables uqt
-	JOIN user_question_relations UQR ON uqt.uqr_id = UQR.id
-	JOIN questions Q ON UQR.question_id = Q.id
-	JOIN users ON users.id = UQR.user_id
-	WHERE Q.is_active = TRUE
-		AND UQR.question_id NOT IN (SELECT question_id FROM exam_questions)
-		AND users.is_admin = FALSE
-	ORDER BY UQR.user_id, UQR.question_id, uqt.score DESC, uqt.created_at ASC
-	`)
-	subquery4count := db.Table("user_question_tables").
-		Select("UQR.user_id AS user_id, GREATEST(MAX(score), 0) AS max_score, UQR.question_id").
-		Joins("JOIN user_question_relations UQR ON user_question_tables.uqr_id = UQR.id").
-		Joins("JOIN questions Q ON UQR.question_id = Q.id").
-		Joins("JOIN users ON users.id = UQR.user_id").
-		Where("Q.is_active = ?", true).
-		Where("question_id NOT IN (SELECT question_id FROM exam_questions)").
-		Where("users.is_admin = false").
-		Group("UQR.user_id, UQR.question_id")
-
-	if err := db.Table("(?) AS t", subquery4count).
+	if err := db.Table("(?) AS t", subquery).
 		Select("COUNT(DISTINCT user_id)").
 		Scan(&totalCount).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
@@ -906,18 +1161,11 @@ func GetLeaderboard(c *gin.Context) {
 	}
 
 	var questionScores []QuestionScoreDetail
-	subquery2 := db.Model(&models.UserQuestionTable{}).
-		Select("UQR.user_id, UQR.question_id, GREATEST(MAX(user_question_tables.score), 0) AS score, MAX(UQR.git_user_repo_url) AS git_user_repo_url").
-		Joins("JOIN user_question_relations UQR ON user_question_tables.uqr_id = UQR.id").
-		Joins("JOIN questions Q ON UQR.question_id = Q.id").
-		Where("Q.is_active = ?", true).
-		Where("UQR.user_id IN ?", userIDs).
-		Where("question_id NOT IN (SELECT question_id FROM exam_questions)").
-		Group("UQR.user_id, UQR.question_id")
-
-	if err := db.Table("(?) AS sq", subquery2).
+	if err := db.Table("(?) AS sq", subquery).
 		Joins("JOIN questions ON questions.id = sq.question_id").
-		Select("sq.user_id, sq.question_id, questions.title AS question_title, sq.git_user_repo_url AS git_user_repo_url, sq.score").
+		Joins("JOIN user_question_relations UQR ON UQR.id = sq.uqr_id").
+		Select("sq.user_id, sq.question_id, questions.title AS question_title, UQR.git_user_repo_url AS git_user_repo_url, sq.max_score AS score").
+		Where("sq.user_id IN ?", userIDs).
 		Find(&questionScores).Error; err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
@@ -926,6 +1174,16 @@ func GetLeaderboard(c *gin.Context) {
 		return
 	}
 
+	// Users still working on an extended deadline
+	inProgress, err := usersWithRunningExtension(db, userIDs, questionIDs)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to get deadline extensions",
+		})
+		return
+	}
+
 	// Map to organize question scores by user
 	userQuestionScores := make(map[uint][]QuestionScore)
 	for _, qs := range questionScores {
@@ -954,6 +1212,7 @@ func GetLeaderboard(c *gin.Context) {
 			UserName:       userName,
 			TotalScore:     user.TotalScore,
 			QuestionScores: userQuestionScores[user.UserID],
+			InProgress:     inProgress[user.UserID],
 		})
 	}
 
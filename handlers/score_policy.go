@@ -0,0 +1,18 @@
+package handlers
+
+import "OJ-API/models"
+
+// validateScorePolicy returns an error message if the final score policy is invalid.
+// An empty policy is only meaningful for exams, where it defers to the questions.
+func validateScorePolicy(policy models.ScorePolicy, topK uint) string {
+	switch policy {
+	case "", models.ScorePolicyBest, models.ScorePolicyLast, models.ScorePolicyLastBeforeDeadline:
+	case models.ScorePolicyTopKAverage:
+		if topK == 0 {
+			return "Score policy top k must be greater than 0"
+		}
+	default:
+		return "Unknown score policy"
+	}
+	return ""
+}
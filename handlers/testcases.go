@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"OJ-API/database"
+	"OJ-API/models"
+	"OJ-API/utils"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type TestCaseData struct {
+	Name   string  `json:"name" example:"sample_1"`
+	Input  string  `json:"input" example:"1 2\n"`
+	Output string  `json:"output" example:"3\n"`
+	Weight float64 `json:"weight" example:"1" description:"Relative weight of the case in the 100-point score, defaults to 1"`
+	Time   uint    `json:"time" example:"0" description:"CPU time limit in ms, 0 to use the question limit"`
+	Memory uint    `json:"memory" example:"0" description:"Memory limit in KB, 0 to use the question limit"`
+}
+
+type PutTestCasesRequest struct {
+	TestCases []TestCaseData `json:"test_cases"`
+}
+
+// validateJudgeMode checks the judge mode and checker settings of a question script
+func validateJudgeMode(mode models.JudgeMode, checker models.CheckerType, checkerScript string) string {
+	switch mode {
+	case models.JudgeModeUnit, models.JudgeModeIO:
+	default:
+		return "Unknown judge mode"
+	}
+
+	switch checker {
+	case models.CheckerExact, models.CheckerWhitespace, models.CheckerFloat:
+	case models.CheckerCustom:
+		if checkerScript == "" {
+			return "Custom checker requires a checker script"
+		}
+	default:
+		return "Unknown checker"
+	}
+	return ""
+}
+
+// GetQuestionTestCases is a function to get the stdin/stdout test cases of a question
+// @Summary		Get the test cases of a question
+// @Description	Get the stdin/stdout test cases used when the question is judged in io mode. When none are stored, the sandbox reads <name>.in/<name>.out pairs from the testcases directory of the question repository.
+// @Tags			Question
+// @Accept			json
+// @Produce		json
+// @Param			ID	path	int	true	"ID of the Question"
+// @Success		200		{object}	ResponseHTTP{data=[]models.QuestionTestCase}
+// @Failure		401
+// @Failure		404
+// @Failure		503
+// @Router			/api/questions/admin/{ID}/testcases [get]
+// @Security		BearerAuth
+func GetQuestionTestCases(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	ID, err := strconv.Atoi(c.Param("ID"))
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Invalid ID",
+		})
+		return
+	}
+
+	var question models.Question
+	if err := db.Where("id = ?", ID).First(&question).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Question not found",
+		})
+		return
+	}
+
+	var testCases []models.QuestionTestCase
+	if err := db.Where("question_id = ?", ID).Order("ordinal, id").Find(&testCases).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to fetch test cases",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Test cases fetched successfully",
+		Data:    testCases,
+	})
+}
+
+// PutQuestionTestCases is a function to replace the stdin/stdout test cases of a question
+// @Summary		Replace the test cases of a question
+// @Description	Replace all stdin/stdout test cases of a question. The order of the list is the judging order. Send an empty list to fall back to the testcases directory of the question repository.
+// @Tags			Question
+// @Accept			json
+// @Produce		json
+// @Param			ID		path	int					true	"ID of the Question"
+// @Param			testcases	body	PutTestCasesRequest	true	"Test cases"
+// @Success		200		{object}	ResponseHTTP{data=[]models.QuestionTestCase}
+// @Failure		401
+// @Failure		404
+// @Failure		503
+// @Router			/api/questions/admin/{ID}/testcases [put]
+// @Security		BearerAuth
+func PutQuestionTestCases(c *gin.Context) {
+	db := database.DBConn
+	jwtClaims := c.Request.Context().Value(models.JWTClaimsKey).(*utils.JWTClaims)
+	if !jwtClaims.IsAdmin {
+		c.JSON(401, ResponseHTTP{
+			Success: false,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	ID, err := strconv.Atoi(c.Param("ID"))
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Invalid ID",
+		})
+		return
+	}
+
+	var question models.Question
+	if err := db.Where("id = ?", ID).First(&question).Error; err != nil {
+		c.JSON(404, ResponseHTTP{
+			Success: false,
+			Message: "Question not found",
+		})
+		return
+	}
+
+	var req PutTestCasesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to parse test cases",
+		})
+		return
+	}
+
+	testCases := make([]models.QuestionTestCase, 0, len(req.TestCases))
+	for i, tc := range req.TestCases {
+		weight := tc.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		testCases = append(testCases, models.QuestionTestCase{
+			QuestionID: question.ID,
+			Ordinal:    i,
+			Name:       tc.Name,
+			Input:      tc.Input,
+			Output:     tc.Output,
+			Weight:     weight,
+			Time:       tc.Time,
+			Memory:     tc.Memory,
+		})
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("question_id = ?", question.ID).Delete(&models.QuestionTestCase{}).Error; err != nil {
+			return err
+		}
+		if len(testCases) == 0 {
+			return nil
+		}
+		return tx.Create(&testCases).Error
+	})
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to save test cases",
+		})
+		return
+	}
+
+	c.JSON(200, ResponseHTTP{
+		Success: true,
+		Message: "Test cases saved successfully",
+		Data:    testCases,
+	})
+}
@@ -6,10 +6,13 @@ import (
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"OJ-API/config"
 	"OJ-API/database"
 	"OJ-API/models"
+	"OJ-API/sandbox"
 	"OJ-API/services"
 	"OJ-API/utils"
 )
@@ -36,6 +39,7 @@ type WebhookPayload struct {
 //	@Success		200		{object}	ResponseHTTP{type=WebhookPayload}
 //	@Failure		403		{object}	ResponseHTTP{}
 //	@Failure		410		{object}	ResponseHTTP{}
+//	@Failure		429		{object}	ResponseHTTP{}
 //	@Failure		503		{object}	ResponseHTTP{}
 //	@Router			/api/gitea [post]
 func PostGiteaHook(c *gin.Context) {
@@ -70,6 +74,15 @@ func PostGiteaHook(c *gin.Context) {
 		return
 	}
 
+	var script models.QuestionTestScript
+	if err := db.Where("question_id = ?", existingQuestion.ID).First(&script).Error; err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "No test script found for this question",
+		})
+		return
+	}
+
 	// Check if current time is within the allowed testing period
 	now := time.Now().UTC()
 	if !existingQuestion.StartTime.IsZero() && now.Before(existingQuestion.StartTime) {
@@ -79,7 +92,21 @@ func PostGiteaHook(c *gin.Context) {
 		})
 		return
 	}
-	if !existingQuestion.EndTime.IsZero() && now.After(existingQuestion.EndTime) {
+	// The deadline may be extended for this user, late pushes are then measured from the extended deadline
+	extension, err := services.FindExtension(db, existingUserQuestionRelation.UserID, existingQuestion.ID)
+	if err != nil {
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to load deadline extension",
+		})
+		return
+	}
+	deadline := existingQuestion
+	deadline.EndTime = services.EffectiveEndTime(&existingQuestion, extension)
+
+	// Late pushes are accepted with a penalty when the question has a late policy
+	penalty, accepted := latePenalty(&deadline, &script, now)
+	if !accepted {
 		c.JSON(410, ResponseHTTP{
 			Success: false,
 			Message: "Testing period has ended",
@@ -97,56 +124,135 @@ func PostGiteaHook(c *gin.Context) {
 	}
 
 	newScore := models.UserQuestionTable{
-		UQR:       existingUserQuestionRelation,
-		Score:     -3,
-		JudgeTime: time.Now().UTC(),
-		Commit:    payload.After,
-		Message:   "Waiting for judging...",
+		UQR:         existingUserQuestionRelation,
+		Score:       -3,
+		JudgeTime:   now,
+		Commit:      payload.After,
+		Message:     "Waiting for judging...",
+		LatePenalty: penalty,
 	}
-	if err := db.Create(&newScore).Error; err != nil {
+	// Lock the relation so concurrent pushes of the same user are counted one at a time
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&models.UserQuestionRelation{}, existingUserQuestionRelation.ID).Error; err != nil {
+			return err
+		}
+		reason, err := checkSubmissionPolicy(tx, &script, existingUserQuestionRelation.ID, now)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			newScore.Score = -6
+			newScore.Verdict = string(sandbox.RATE_LIMITED)
+			newScore.Message = reason
+		}
+		return tx.Create(&newScore).Error
+	}); err != nil {
 		c.JSON(503, ResponseHTTP{
 			Success: false,
 			Message: "Failed to create new score entry",
 		})
 		return
 	}
+	if newScore.Score == -6 {
+		c.JSON(429, ResponseHTTP{
+			Success: false,
+			Message: newScore.Message,
+		})
+		return
+	}
+
+	// 構建 Git 倉庫 URL
+	gitRepoURL := config.GetGiteaBaseURL() + "/" + payload.Repository.FullName
+
+	// 將任務寫入持久化隊列，Git clone 將在沙箱端完成
+	clientManager := services.GetSandboxClientManager()
+	if err := clientManager.ReserveJob(
+		existingQuestion.GitRepoURL, // parentGitFullName
+		gitRepoURL,                  // gitRepoURL
+		payload.Repository.FullName, // gitFullName
+		payload.After,               // gitAfterHash
+		existingUser.UserName,       // gitUsername
+		uint64(newScore.ID),         // userQuestionTableID
+	); err != nil {
+		db.Model(&newScore).Updates(models.UserQuestionTable{
+			Score:   -2,
+			Message: fmt.Sprintf("Failed to queue job: %v", err),
+		})
+		c.JSON(503, ResponseHTTP{
+			Success: false,
+			Message: "Failed to queue judge job",
+		})
+		return
+	}
 
 	c.JSON(200, ResponseHTTP{
 		Success: true,
 		Message: "Successfully received hook",
 		Data:    payload,
 	})
+}
 
-	go func() {
-		// 獲取用戶 token
-		token, err := utils.GetToken(existingUser.ID)
-		if err != nil {
-			utils.Errorf("Failed to get token: %v", err)
-			db.Model(&newScore).Updates(models.UserQuestionTable{
-				Score:   -2,
-				Message: fmt.Sprintf("Failed to get token: %v", err),
-			})
-			return
+// checkSubmissionPolicy returns the reason a push is rejected by the question's
+// submission policy, or an empty string if it may be judged.
+// Pushes and rescores of the user count: admin rejudges, and rate limited, superseded,
+// cancelled and system error entries do not count as judged pushes.
+func checkSubmissionPolicy(tx *gorm.DB, script *models.QuestionTestScript, uqrID uint, now time.Time) (string, error) {
+	if script.MinSubmitInterval == 0 && script.MaxSubmitsPerHour == 0 &&
+		script.MaxSubmitsPerDay == 0 && script.SubmitQuota == 0 {
+		return "", nil
+	}
+
+	judged := func() *gorm.DB {
+		return tx.Model(&models.UserQuestionTable{}).
+			Where("uqr_id = ? AND score NOT IN ?", uqrID, []float64{-2, -4, -5, -6}).
+			Where("rejudge_batch_id IS NULL")
+	}
+
+	if script.MinSubmitInterval > 0 {
+		var last models.UserQuestionTable
+		err := judged().Order("created_at DESC").Take(&last).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return "", err
+		}
+		if err == nil {
+			next := last.CreatedAt.Add(time.Duration(script.MinSubmitInterval) * time.Second)
+			if now.Before(next) {
+				return fmt.Sprintf("Rate limited: please wait %d seconds between submissions, next submission allowed after %s",
+					script.MinSubmitInterval, next.UTC().Format(time.RFC3339)), nil
+			}
 		}
+	}
 
-		// 構建 Git 倉庫 URL
-		gitRepoURL := config.GetGiteaBaseURL() + "/" + payload.Repository.FullName
-
-		// 使用 gRPC 客戶端添加任務，Git clone 將在沙箱端完成
-		clientManager := services.GetSandboxClientManager()
-		if err := clientManager.ReserveJob(
-			existingQuestion.GitRepoURL, // parentGitFullName
-			gitRepoURL,                  // gitRepoURL
-			payload.Repository.FullName, // gitFullName
-			payload.After,               // gitAfterHash
-			existingUser.UserName,       // gitUsername
-			token,                       // gitToken
-			uint64(newScore.ID),         // userQuestionTableID
-		); err != nil {
-			db.Model(&newScore).Updates(models.UserQuestionTable{
-				Score:   -2,
-				Message: fmt.Sprintf("Failed to queue job: %v", err),
-			})
+	for _, limit := range []struct {
+		max    uint
+		window time.Duration
+		name   string
+	}{
+		{script.MaxSubmitsPerHour, time.Hour, "hour"},
+		{script.MaxSubmitsPerDay, 24 * time.Hour, "day"},
+	} {
+		if limit.max == 0 {
+			continue
 		}
-	}()
+		var count int64
+		if err := judged().Where("created_at > ?", now.Add(-limit.window)).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count >= int64(limit.max) {
+			return fmt.Sprintf("Rate limited: at most %d submissions per %s", limit.max, limit.name), nil
+		}
+	}
+
+	if script.SubmitQuota > 0 {
+		var count int64
+		if err := judged().Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count >= int64(script.SubmitQuota) {
+			return fmt.Sprintf("Rate limited: submission quota of %d used up", script.SubmitQuota), nil
+		}
+	}
+
+	return "", nil
 }
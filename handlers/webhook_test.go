@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"OJ-API/database/dbtest"
+	"OJ-API/models"
+)
+
+func TestCheckSubmissionPolicy(t *testing.T) {
+	tx := dbtest.Open(t,
+		&models.User{},
+		&models.Question{},
+		&models.UserQuestionRelation{},
+		&models.RejudgeBatch{},
+		&models.UserQuestionTable{},
+	)
+
+	type push struct {
+		score float64
+		ago   time.Duration
+	}
+	cases := []struct {
+		name     string
+		script   models.QuestionTestScript
+		pushes   []push
+		rejudges []push
+		wantErr  string // substring of the rejection, empty if the push is accepted
+	}{
+		{name: "no policy", pushes: []push{{80, time.Second}, {90, 2 * time.Second}}},
+		{name: "within the minimum interval", script: models.QuestionTestScript{MinSubmitInterval: 60}, pushes: []push{{80, 30 * time.Second}}, wantErr: "please wait 60 seconds"},
+		{name: "after the minimum interval", script: models.QuestionTestScript{MinSubmitInterval: 60}, pushes: []push{{80, 2 * time.Minute}}},
+		{
+			name:    "hourly limit reached",
+			script:  models.QuestionTestScript{MaxSubmitsPerHour: 2},
+			pushes:  []push{{80, 10 * time.Minute}, {90, 50 * time.Minute}},
+			wantErr: "at most 2 submissions per hour",
+		},
+		{name: "hourly window slides", script: models.QuestionTestScript{MaxSubmitsPerHour: 2}, pushes: []push{{80, 10 * time.Minute}, {90, 70 * time.Minute}}},
+		{
+			name:    "daily limit reached",
+			script:  models.QuestionTestScript{MaxSubmitsPerHour: 5, MaxSubmitsPerDay: 2},
+			pushes:  []push{{80, 2 * time.Hour}, {90, 20 * time.Hour}},
+			wantErr: "at most 2 submissions per day",
+		},
+		{name: "daily window slides", script: models.QuestionTestScript{MaxSubmitsPerDay: 2}, pushes: []push{{80, 2 * time.Hour}, {90, 25 * time.Hour}}},
+		{
+			name:    "quota used up",
+			script:  models.QuestionTestScript{SubmitQuota: 2},
+			pushes:  []push{{80, 48 * time.Hour}, {90, 72 * time.Hour}},
+			wantErr: "submission quota of 2 used up",
+		},
+		{
+			name:   "rejected, superseded and failed pushes do not count",
+			script: models.QuestionTestScript{MinSubmitInterval: 60, MaxSubmitsPerHour: 1, SubmitQuota: 1},
+			pushes: []push{{-6, time.Second}, {-5, 2 * time.Second}, {-2, 3 * time.Second}},
+		},
+		{
+			name:     "cancelled pushes and admin rejudges do not count",
+			script:   models.QuestionTestScript{MinSubmitInterval: 60, SubmitQuota: 1},
+			pushes:   []push{{-4, time.Second}},
+			rejudges: []push{{90, 2 * time.Second}},
+		},
+		{name: "queued pushes count", script: models.QuestionTestScript{SubmitQuota: 1}, pushes: []push{{-3, time.Second}}, wantErr: "quota"},
+	}
+
+	now := time.Now().UTC()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			user := models.User{UserName: "student", Email: "student@example.com"}
+			question := models.Question{Title: "Two Sum", Description: "Two Sum"}
+			dbtest.Create(t, tx, &user, &question)
+			uqr := models.UserQuestionRelation{UserID: user.ID, QuestionID: question.ID, GitUserRepoURL: "student/two-sum"}
+			dbtest.Create(t, tx, &uqr)
+			for _, p := range tc.pushes {
+				dbtest.Create(t, tx, &models.UserQuestionTable{UQRID: uqr.ID, Score: p.score, CreatedAt: now.Add(-p.ago)})
+			}
+			for _, p := range tc.rejudges {
+				batch := models.RejudgeBatch{QuestionID: &question.ID, CreatedBy: user.ID}
+				dbtest.Create(t, tx, &batch)
+				dbtest.Create(t, tx, &models.UserQuestionTable{
+					UQRID:          uqr.ID,
+					Score:          p.score,
+					CreatedAt:      now.Add(-p.ago),
+					IsRejudge:      true,
+					RejudgeBatchID: &batch.ID,
+				})
+			}
+
+			reason, err := checkSubmissionPolicy(tx, &tc.script, uqr.ID, now)
+			if err != nil {
+				t.Fatalf("checkSubmissionPolicy() error = %v", err)
+			}
+			if tc.wantErr == "" && reason != "" || !strings.Contains(reason, tc.wantErr) {
+				t.Errorf("checkSubmissionPolicy() = %q, want %q", reason, tc.wantErr)
+			}
+		})
+	}
+}
@@ -66,26 +66,8 @@ func main() {
 		utils.Fatal("Can't connect database:", err.Error())
 	}
 
-	// 初始化沙箱調度器
-	scheduler := services.GetSandboxScheduler()
-	defer scheduler.Close()
-
-	// 創建 gRPC 服務器
-	var grpcServer *grpc.Server
-	if useTLS {
-		// 載入 TLS 憑證
-		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
-		if err != nil {
-			utils.Fatal("Failed to load TLS certificates:", err)
-		}
-
-		// 創建 TLS 憑證
-		creds := credentials.NewServerTLSFromCert(&cert)
-		grpcServer = grpc.NewServer(grpc.Creds(creds))
-	} else {
-		grpcServer = grpc.NewServer()
-	}
-	pb.RegisterSchedulerServiceServer(grpcServer, scheduler)
+	// Submissions judged before raw scores were kept need a one-time backfill
+	backfillRawScore := !database.DBConn.Migrator().HasColumn(&models.UserQuestionTable{}, "RawScore")
 
 	// Database migrations
 	models := []interface{}{
@@ -95,10 +77,16 @@ func main() {
 		&models.Question{},
 		&models.ExamQuestion{},
 		&models.QuestionTestScript{},
+		&models.QuestionTestCase{},
 		&models.Tag{},
 		&models.TagAndQuestion{},
 		&models.UserQuestionRelation{},
+		&models.RejudgeBatch{},
 		&models.UserQuestionTable{},
+		&models.ExecutionMetric{},
+		&models.JudgeJob{},
+		&models.Extension{},
+		&models.ScoreOverride{},
 	}
 
 	for _, m := range models {
@@ -107,6 +95,34 @@ func main() {
 		}
 	}
 
+	if backfillRawScore {
+		// 舊的提交沒有遲交懲罰，原始分數即為分數
+		if err := database.DBConn.Exec("UPDATE user_question_tables SET raw_score = score WHERE raw_score = 0 AND score > 0").Error; err != nil {
+			utils.Errorf("Backfill raw_score failed: %v", err)
+		}
+	}
+
+	// 初始化沙箱調度器
+	scheduler := services.GetSandboxScheduler()
+	defer scheduler.Close()
+
+	// 創建 gRPC 服務器
+	var grpcServer *grpc.Server
+	if useTLS {
+		// 載入 TLS 憑證
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			utils.Fatal("Failed to load TLS certificates:", err)
+		}
+
+		// 創建 TLS 憑證
+		creds := credentials.NewServerTLSFromCert(&cert)
+		grpcServer = grpc.NewServer(grpc.Creds(creds))
+	} else {
+		grpcServer = grpc.NewServer()
+	}
+	pb.RegisterSchedulerServiceServer(grpcServer, scheduler)
+
 	// Initialize Gin router
 	r := gin.Default()
 	routes.RegisterRoutes(r)
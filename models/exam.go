@@ -10,4 +10,7 @@ type Exam struct {
 	Description string    `gorm:"size:500" json:"description"`
 	StartTime   time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"start_time" example:"2006-01-02T15:04:05Z07:00" time_format:"RFC3339"`
 	EndTime     time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"end_time" example:"2006-01-02T15:04:05Z07:00" time_format:"RFC3339"`
+
+	ScorePolicy     ScorePolicy `gorm:"size:30;not null;default:''" json:"score_policy"`
+	ScorePolicyTopK uint        `gorm:"not null;default:1" json:"score_policy_top_k"`
 }
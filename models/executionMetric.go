@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type ExecutionMetric struct {
+	ID         uint              `gorm:"primaryKey" json:"-"`
+	UQTID      uint              `gorm:"not null;index" json:"-"`
+	UQT        UserQuestionTable `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Target     string            `gorm:"size:200;not null" json:"target"`
+	Verdict    string            `gorm:"size:30;not null;default:''" json:"verdict"`
+	CPUTimeMs  int64             `gorm:"not null;default:0" json:"cpu_time_ms"`
+	WallTimeMs int64             `gorm:"not null;default:0" json:"wall_time_ms"`
+	MemoryKB   int64             `gorm:"not null;default:0" json:"memory_kb"`
+	ExitCode   int               `gorm:"not null;default:0" json:"exit_code"`
+	ExitSignal int               `gorm:"not null;default:0" json:"exit_signal"`
+	CreatedAt  time.Time         `gorm:"autoCreateTime" json:"-"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+type Extension struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	UserID          uint       `gorm:"not null;uniqueIndex:idx_extension_user_question;uniqueIndex:idx_extension_user_exam" json:"user_id"`
+	User            User       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	QuestionID      *uint      `gorm:"uniqueIndex:idx_extension_user_question" json:"question_id"`
+	Question        *Question  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	ExamID          *uint      `gorm:"uniqueIndex:idx_extension_user_exam" json:"exam_id"`
+	Exam            *Exam      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	EndTime         *time.Time `json:"end_time" example:"2006-01-02T15:04:05Z07:00" time_format:"RFC3339"`
+	LimitMultiplier float64    `gorm:"not null;default:1" json:"limit_multiplier"`
+	Reason          string     `gorm:"size:500;not null;default:''" json:"reason"`
+	CreatedBy       uint       `gorm:"not null" json:"created_by"`
+	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+type JudgeJobStatus string
+
+const (
+	JudgeJobQueued     JudgeJobStatus = "queued"
+	JudgeJobDispatched JudgeJobStatus = "dispatched"
+	JudgeJobRunning    JudgeJobStatus = "running"
+	JudgeJobDone       JudgeJobStatus = "done"
+	JudgeJobFailed     JudgeJobStatus = "failed"
+	JudgeJobCancelled  JudgeJobStatus = "cancelled"
+	JudgeJobSuperseded JudgeJobStatus = "superseded"
+)
+
+type JudgeJobPriority string
+
+const (
+	JudgeJobPriorityExam     JudgeJobPriority = "exam"
+	JudgeJobPriorityPractice JudgeJobPriority = "practice"
+	JudgeJobPriorityRejudge  JudgeJobPriority = "rejudge"
+)
+
+type JudgeJob struct {
+	ID                uint              `gorm:"primaryKey" json:"id"`
+	UQTID             uint              `gorm:"not null;index" json:"uqt_id"`
+	UQT               UserQuestionTable `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	ParentGitFullName string            `gorm:"size:250;not null" json:"parent_git_full_name"`
+	GitRepoURL        string            `gorm:"size:500;not null" json:"git_repo_url"`
+	GitFullName       string            `gorm:"size:150;not null" json:"git_full_name"`
+	GitAfterHash      string            `gorm:"size:150;not null;default:''" json:"git_after_hash"`
+	GitUsername       string            `gorm:"size:100;not null" json:"git_username"`
+	Status            JudgeJobStatus    `gorm:"size:20;not null;default:queued;index:idx_judge_jobs_status_lease,priority:1" json:"status"`
+	Attempts          int               `gorm:"not null;default:0" json:"attempts"`
+	SandboxID         string            `gorm:"size:64;not null;default:''" json:"sandbox_id"`
+	LeaseExpiresAt    *time.Time        `gorm:"index:idx_judge_jobs_status_lease,priority:2" json:"lease_expires_at"`
+	LastError         string            `gorm:"size:1000;not null;default:''" json:"last_error"`
+	StartedAt         *time.Time        `json:"started_at"`
+	FinishedAt        *time.Time        `json:"finished_at"`
+	CompileTimeMs     int64             `gorm:"not null;default:0" json:"compile_time_ms"`
+	ExecuteTimeMs     int64             `gorm:"not null;default:0" json:"execute_time_ms"`
+	ScoreTimeMs       int64             `gorm:"not null;default:0" json:"score_time_ms"`
+	CreatedAt         time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+
+	Rejudge       bool   `gorm:"not null;default:false" json:"rejudge"`
+	PendingReason string `gorm:"size:500;not null;default:''" json:"pending_reason"`
+
+	Priority JudgeJobPriority `gorm:"size:20;not null;default:practice" json:"priority"`
+}
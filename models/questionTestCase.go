@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type QuestionTestCase struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	QuestionID uint      `gorm:"not null;index" json:"question_id"`
+	Question   Question  `gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Ordinal    int       `gorm:"not null;default:0" json:"ordinal"`
+	Name       string    `gorm:"size:100;not null;default:''" json:"name"`
+	Input      string    `gorm:"type:text;not null" json:"input"`
+	Output     string    `gorm:"type:text;not null" json:"output"`
+	Weight     float64   `gorm:"not null;default:1" json:"weight"`
+	Time       uint      `gorm:"not null;default:0" json:"time"`
+	Memory     uint      `gorm:"not null;default:0" json:"memory"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
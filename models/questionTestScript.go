@@ -1,18 +1,87 @@
 package models
 
+type JudgeMode string
+
+const (
+	JudgeModeUnit JudgeMode = "unit"
+	JudgeModeIO   JudgeMode = "io"
+)
+
+type CheckerType string
+
+const (
+	CheckerExact      CheckerType = "exact"
+	CheckerWhitespace CheckerType = "whitespace"
+	CheckerFloat      CheckerType = "float"
+	CheckerCustom     CheckerType = "custom"
+)
+
+type LatePolicy string
+
+const (
+	LatePolicyNone   LatePolicy = "none"
+	LatePolicyLinear LatePolicy = "linear"
+	LatePolicyStep   LatePolicy = "step"
+)
+
+type ScorePolicy string
+
+const (
+	ScorePolicyBest               ScorePolicy = "best"
+	ScorePolicyLast               ScorePolicy = "last"
+	ScorePolicyLastBeforeDeadline ScorePolicy = "last_before_deadline"
+	ScorePolicyTopKAverage        ScorePolicy = "top_k_average"
+)
+
 type QuestionTestScript struct {
-	ID            uint     `gorm:"primaryKey" json:"id"`
-	QuestionID    uint     `gorm:"not null" json:"question_id"`
-	Question      Question `gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"question"`
-	CompileScript string   `gorm:"size:4000;not null" json:"compile_script"`
-	ExecuteScript string   `gorm:"size:4000;not null" json:"execute_script"`
-	ScoreScript   string   `gorm:"size:8000;not null" json:"score_script"`
-	Memory        uint     `gorm:"not null;default:262144" json:"memory"`
-	StackMemory   uint     `gorm:"not null;default:8192" json:"stack_memory"`
-	Time          uint     `gorm:"not null;default:1000" json:"time"`
-	WallTime      uint     `gorm:"not null;default:3000" json:"wall_time"`
-	FileSize      uint     `gorm:"not null;default:10240" json:"file_size"`
-	Processes     uint     `gorm:"not null;default:10" json:"processes"`
-	OpenFiles     uint     `gorm:"not null;default:64" json:"open_files"`
-	ScoreMap      string   `gorm:"size:8000;not null" json:"score_map"`
+	ID             uint        `gorm:"primaryKey" json:"id"`
+	QuestionID     uint        `gorm:"not null" json:"question_id"`
+	Question       Question    `gorm:"foreignKey:QuestionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"question"`
+	Profile        string      `gorm:"size:50;not null;default:''" json:"profile"`
+	JudgeMode      JudgeMode   `gorm:"size:20;not null;default:unit" json:"judge_mode"`
+	Checker        CheckerType `gorm:"size:20;not null;default:exact" json:"checker"`
+	FloatTolerance float64     `gorm:"not null;default:0.000001" json:"float_tolerance"`
+	CheckerScript  string      `gorm:"size:8000;not null;default:''" json:"checker_script"`
+	CompileScript  string      `gorm:"size:4000;not null" json:"compile_script"`
+	ExecuteScript  string      `gorm:"size:4000;not null" json:"execute_script"`
+	ScoreScript    string      `gorm:"size:8000;not null" json:"score_script"`
+	Memory         uint        `gorm:"not null;default:262144" json:"memory"`
+	StackMemory    uint        `gorm:"not null;default:8192" json:"stack_memory"`
+	Time           uint        `gorm:"not null;default:1000" json:"time"`
+	WallTime       uint        `gorm:"not null;default:3000" json:"wall_time"`
+	FileSize       uint        `gorm:"not null;default:10240" json:"file_size"`
+	Processes      uint        `gorm:"not null;default:10" json:"processes"`
+	OpenFiles      uint        `gorm:"not null;default:64" json:"open_files"`
+	ScoreMap       string      `gorm:"size:8000;not null" json:"score_map"`
+
+	CompileTime      uint `gorm:"not null;default:20000" json:"compile_time"`
+	CompileWallTime  uint `gorm:"not null;default:30000" json:"compile_wall_time"`
+	CompileMemory    uint `gorm:"not null;default:2097152" json:"compile_memory"`
+	CompileProcesses uint `gorm:"not null;default:64" json:"compile_processes"`
+	CompileFileSize  uint `gorm:"not null;default:10240" json:"compile_file_size"`
+	ScoreTime        uint `gorm:"not null;default:10000" json:"score_time"`
+	ScoreWallTime    uint `gorm:"not null;default:20000" json:"score_wall_time"`
+	ScoreMemory      uint `gorm:"not null;default:1048576" json:"score_memory"`
+	ScoreProcesses   uint `gorm:"not null;default:100" json:"score_processes"`
+	ScoreFileSize    uint `gorm:"not null;default:10240" json:"score_file_size"`
+
+	JudgeTimeout     uint `gorm:"not null;default:60000" json:"judge_timeout"`
+	CancelSuperseded bool `gorm:"not null;default:false" json:"cancel_superseded"`
+	CoalesceQueued   bool `gorm:"not null;default:false" json:"coalesce_queued"`
+
+	MinSubmitInterval uint `gorm:"not null;default:0" json:"min_submit_interval"`
+	MaxSubmitsPerHour uint `gorm:"not null;default:0" json:"max_submits_per_hour"`
+	MaxSubmitsPerDay  uint `gorm:"not null;default:0" json:"max_submits_per_day"`
+	SubmitQuota       uint `gorm:"not null;default:0" json:"submit_quota"`
+
+	LatePolicy          LatePolicy `gorm:"size:20;not null;default:none" json:"late_policy"`
+	LateGracePeriod     uint       `gorm:"not null;default:0" json:"late_grace_period"`
+	LatePenalty         float64    `gorm:"not null;default:0" json:"late_penalty"`
+	LatePenaltyInterval uint       `gorm:"not null;default:60" json:"late_penalty_interval"`
+	LateCutoff          uint       `gorm:"not null;default:0" json:"late_cutoff"`
+
+	ScorePolicy     ScorePolicy `gorm:"size:30;not null;default:best" json:"score_policy"`
+	ScorePolicyTopK uint        `gorm:"not null;default:1" json:"score_policy_top_k"`
+
+	RequiredLabels string `gorm:"size:500;not null;default:''" json:"required_labels"`
 }
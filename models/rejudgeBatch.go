@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type RejudgeBatch struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	QuestionID  *uint      `gorm:"index" json:"question_id"`
+	Question    *Question  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Mode        string     `gorm:"size:20;not null;default:''" json:"mode"`
+	Total       int        `gorm:"not null;default:0" json:"total"`
+	CreatedBy   uint       `gorm:"not null" json:"created_by"`
+	CreatedAt   time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	CancelledBy *uint      `json:"cancelled_by"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type ScoreOverride struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;index;uniqueIndex:idx_score_override_active,where:revoked_at IS NULL" json:"user_id"`
+	User       User       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	QuestionID uint       `gorm:"not null;index;uniqueIndex:idx_score_override_active,where:revoked_at IS NULL" json:"question_id"`
+	Question   Question   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Score      *float64   `json:"score"`
+	Delta      float64    `gorm:"not null;default:0" json:"delta"`
+	Reason     string     `gorm:"size:500;not null" json:"reason"`
+	CreatedBy  uint       `gorm:"not null" json:"created_by"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	RevokedBy  *uint      `json:"revoked_by"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
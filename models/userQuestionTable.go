@@ -9,6 +9,18 @@ type UserQuestionTable struct {
 	Score     float64              `gorm:"not null;index:idx_uqt_uqr_score_created,priority:2" json:"score"`
 	JudgeTime time.Time            `gorm:"not null;default:CURRENT_TIMESTAMP" json:"judge_time" example:"2006-01-02T15:04:05Z07:00" time_format:"RFC3339"`
 	Message   string               `gorm:"not null" json:"message"`
+	Verdict   string               `gorm:"size:30;not null;default:''" json:"verdict"`
+	CPUTimeMs int64                `gorm:"not null;default:0" json:"cpu_time_ms"`
+	MemoryKB  int64                `gorm:"not null;default:0" json:"memory_kb"`
 	Commit    string               `gorm:"size:150;not null;default:''" json:"commit"`
 	CreatedAt time.Time            `gorm:"autoCreateTime;index:idx_uqt_uqr_score_created,priority:3" json:"created_at"`
+
+	RawScore    float64 `gorm:"not null;default:0" json:"raw_score"`
+	LatePenalty float64 `gorm:"not null;default:0" json:"late_penalty"`
+
+	IsRejudge      bool          `gorm:"not null;default:false" json:"is_rejudge"`
+	RejudgeBatchID *uint         `gorm:"index" json:"rejudge_batch_id"`
+	RejudgeBatch   *RejudgeBatch `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	SupersededBy *uint `gorm:"index" json:"superseded_by"`
 }
@@ -0,0 +1,174 @@
+package profiles
+
+func init() {
+	register(Profile{
+		Name:         "cpp-gtest",
+		Language:     "C++",
+		Description:  "CMake project tested with GoogleTest, each target is a test executable",
+		ResultFormat: FormatGTest,
+		Requires:     []string{"cmake", "g++", "googletest"},
+		CompileScript: `#!/bin/sh
+set -e
+cmake -B build -DCMAKE_BUILD_TYPE=Debug > /dev/null
+cmake --build build --target "$1"
+`,
+		ExecuteScript: `#!/bin/bash
+mkdir -p build/grp
+test_bin=$(find build -type f -name "$1" -perm -u+x | head -n 1)
+"$test_bin" --gtest_output=json:"build/grp/$1.json"
+`,
+		ScoreScript: `#!/bin/bash
+./utils/grp_parser -format=gtest -target="$1" "build/grp/$1.json" utils/score.json
+`,
+		Limits: Limits{
+			Memory:          262144,
+			Time:            5000,
+			WallTime:        10000,
+			CompileTime:     60000,
+			CompileWallTime: 120000,
+			JudgeTimeout:    150000,
+		},
+	})
+
+	register(Profile{
+		Name:         "python-pytest",
+		Language:     "Python",
+		Description:  "pytest suite, each target is a test file or directory",
+		ResultFormat: FormatPytest,
+		Requires:     []string{"python3", "pytest", "pytest-json-report"},
+		CompileScript: `#!/bin/sh
+set -e
+python3 -m compileall -q .
+`,
+		ExecuteScript: `#!/bin/bash
+mkdir -p build/grp
+name=$(echo "$1" | tr '/.' '__')
+python3 -m pytest "$1" -p no:cacheprovider --json-report --json-report-file="build/grp/$name.json"
+status=$?
+# pytest 以 1 表示有測試失敗，只要報告已產生即交由計分階段處理
+[ $status -eq 1 ] && [ -s "build/grp/$name.json" ] && exit 0
+exit $status
+`,
+		ScoreScript: `#!/bin/bash
+name=$(echo "$1" | tr '/.' '__')
+./utils/grp_parser -format=pytest -target="$1" "build/grp/$name.json" utils/score.json
+`,
+		Limits: Limits{
+			Memory:       524288,
+			Time:         10000,
+			WallTime:     20000,
+			Processes:    16,
+			OpenFiles:    256,
+			JudgeTimeout: 120000,
+		},
+	})
+
+	register(Profile{
+		Name:         "java-junit",
+		Language:     "Java",
+		Description:  "Maven project tested with JUnit, each target is a test class",
+		ResultFormat: FormatJUnit,
+		Requires:     []string{"java", "maven"},
+		CompileScript: `#!/bin/sh
+set -e
+mvn -q -o -DskipTests test-compile
+`,
+		ExecuteScript: `#!/bin/bash
+mvn -q -o test -Dtest="$1" -Dsurefire.failIfNoSpecifiedTests=false
+status=$?
+# 測試失敗時仍會產生 surefire 報告，交由計分階段處理
+ls target/surefire-reports/TEST-*"$1".xml > /dev/null 2>&1 && exit 0
+exit $status
+`,
+		ScoreScript: `#!/bin/bash
+report=$(ls target/surefire-reports/TEST-*"$1".xml | head -n 1)
+./utils/grp_parser -format=junit -target="$1" "$report" utils/score.json
+`,
+		Limits: Limits{
+			Memory:           2097152,
+			Time:             20000,
+			WallTime:         40000,
+			Processes:        64,
+			OpenFiles:        512,
+			CompileTime:      120000,
+			CompileWallTime:  180000,
+			CompileMemory:    4194304,
+			CompileProcesses: 128,
+			JudgeTimeout:     300000,
+		},
+	})
+
+	register(Profile{
+		Name:         "go-test",
+		Language:     "Go",
+		Description:  "Go module tested with go test, each target is a package path",
+		ResultFormat: FormatJUnit,
+		Requires:     []string{"go", "gotestsum"},
+		CompileScript: `#!/bin/sh
+set -e
+mkdir -p build/test
+name=$(echo "$1" | tr '/.' '__')
+go vet "$1"
+go test -c -o "build/test/$name.test" "$1"
+`,
+		ExecuteScript: `#!/bin/bash
+mkdir -p build/grp
+name=$(echo "$1" | tr '/.' '__')
+root=$(pwd)
+# 與 go test 相同，測試執行檔在套件目錄中執行
+cd "$1" && gotestsum --junitfile "$root/build/grp/$name.xml" --raw-command -- \
+	go tool test2json -t -p "$1" "$root/build/test/$name.test" -test.v -test.count=1
+status=$?
+[ -s "$root/build/grp/$name.xml" ] && exit 0
+exit $status
+`,
+		ScoreScript: `#!/bin/bash
+name=$(echo "$1" | tr '/.' '__')
+./utils/grp_parser -format=junit -target="$1" "build/grp/$name.xml" utils/score.json
+`,
+		Limits: Limits{
+			Memory:           1048576,
+			Time:             10000,
+			WallTime:         20000,
+			Processes:        64,
+			OpenFiles:        256,
+			CompileTime:      60000,
+			CompileWallTime:  120000,
+			CompileProcesses: 128,
+			JudgeTimeout:     200000,
+		},
+	})
+
+	register(Profile{
+		Name:         "rust-cargo",
+		Language:     "Rust",
+		Description:  "Cargo project tested with cargo test, each target is an integration test name",
+		ResultFormat: FormatJUnit,
+		Requires:     []string{"cargo"},
+		CompileScript: `#!/bin/sh
+set -e
+cargo test --offline --no-run --test "$1"
+`,
+		ExecuteScript: `#!/bin/bash
+mkdir -p build/grp
+RUSTC_BOOTSTRAP=1 cargo test --offline --test "$1" -- -Z unstable-options --format junit > "build/grp/$1.xml"
+status=$?
+[ -s "build/grp/$1.xml" ] && exit 0
+exit $status
+`,
+		ScoreScript: `#!/bin/bash
+./utils/grp_parser -format=junit -target="$1" "build/grp/$1.xml" utils/score.json
+`,
+		Limits: Limits{
+			Memory:           524288,
+			Time:             10000,
+			WallTime:         20000,
+			Processes:        32,
+			CompileTime:      180000,
+			CompileWallTime:  300000,
+			CompileMemory:    4194304,
+			CompileProcesses: 128,
+			JudgeTimeout:     400000,
+		},
+	})
+}
@@ -0,0 +1,110 @@
+package profiles
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ResultFormat 測試結果的輸出格式，對應 grp_parser 的 -format 參數
+type ResultFormat string
+
+const (
+	FormatGTest  ResultFormat = "gtest"  // GoogleTest --gtest_output=json
+	FormatJUnit  ResultFormat = "junit"  // JUnit XML
+	FormatPytest ResultFormat = "pytest" // pytest-json-report
+	FormatTAP    ResultFormat = "tap"    // Test Anything Protocol
+)
+
+// Profile 評測設定模板，依語言與測試框架產生編譯、執行與計分腳本
+//
+// 腳本在沙箱中以 score map 內每個 task 的 target 作為第一個參數執行，
+// 工作目錄為學生程式碼根目錄，grp_parser 與 score.json 位於 utils/ 下。
+type Profile struct {
+	Name          string       `json:"name" example:"cpp-gtest"`
+	Language      string       `json:"language" example:"C++"`
+	Description   string       `json:"description" example:"CMake project tested with GoogleTest"`
+	ResultFormat  ResultFormat `json:"result_format" example:"gtest"`
+	Requires      []string     `json:"requires" example:"cmake,g++"`
+	CompileScript string       `json:"compile_script"`
+	ExecuteScript string       `json:"execute_script"`
+	ScoreScript   string       `json:"score_script"`
+	Limits        Limits       `json:"limits"`
+}
+
+// Limits 評測設定模板建議的資源限制，建立題目時未指定的限制改用這些值，0 表示使用系統預設值
+//
+// 單元測試框架的執行階段需要啟動直譯器或虛擬機，預設給程式題的限制不足以執行測試。
+type Limits struct {
+	Memory    uint `json:"memory,omitempty" example:"262144"`   // KB
+	Time      uint `json:"time,omitempty" example:"5000"`       // ms
+	WallTime  uint `json:"wall_time,omitempty" example:"10000"` // ms
+	Processes uint `json:"processes,omitempty" example:"10"`
+	OpenFiles uint `json:"open_files,omitempty" example:"64"`
+
+	CompileTime      uint `json:"compile_time,omitempty" example:"60000"`       // ms
+	CompileWallTime  uint `json:"compile_wall_time,omitempty" example:"120000"` // ms
+	CompileMemory    uint `json:"compile_memory,omitempty" example:"2097152"`   // KB
+	CompileProcesses uint `json:"compile_processes,omitempty" example:"64"`
+
+	JudgeTimeout uint `json:"judge_timeout,omitempty" example:"150000"` // ms
+}
+
+var registry = map[string]Profile{}
+
+func register(p Profile) {
+	registry[p.Name] = p
+}
+
+// Get 依名稱取得評測設定模板
+func Get(name string) (Profile, bool) {
+	p, ok := registry[name]
+	return p, ok
+}
+
+// List 取得所有內建評測設定模板，依名稱排序
+func List() []Profile {
+	list := make([]Profile, 0, len(registry))
+	for _, p := range registry {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// Scripts 題目實際使用的評測腳本
+type Scripts struct {
+	CompileScript string
+	ExecuteScript string
+	ScoreScript   string
+}
+
+// Resolve 依評測設定模板產生腳本，非空的自訂腳本會覆寫模板內容。
+// name 為空時直接使用自訂腳本。
+func Resolve(name string, custom Scripts) (Scripts, error) {
+	if name == "" {
+		return custom, nil
+	}
+
+	p, ok := Get(name)
+	if !ok {
+		return Scripts{}, fmt.Errorf("unknown judge profile %q", name)
+	}
+
+	resolved := Scripts{
+		CompileScript: p.CompileScript,
+		ExecuteScript: p.ExecuteScript,
+		ScoreScript:   p.ScoreScript,
+	}
+	if custom.CompileScript != "" {
+		resolved.CompileScript = custom.CompileScript
+	}
+	if custom.ExecuteScript != "" {
+		resolved.ExecuteScript = custom.ExecuteScript
+	}
+	if custom.ScoreScript != "" {
+		resolved.ScoreScript = custom.ScoreScript
+	}
+	return resolved, nil
+}
@@ -65,10 +65,11 @@ type SandboxStatusResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AvailableCount  int32 `protobuf:"varint,1,opt,name=available_count,json=availableCount,proto3" json:"available_count,omitempty"`
-	WaitingCount    int32 `protobuf:"varint,2,opt,name=waiting_count,json=waitingCount,proto3" json:"waiting_count,omitempty"`
-	ProcessingCount int32 `protobuf:"varint,3,opt,name=processing_count,json=processingCount,proto3" json:"processing_count,omitempty"`
-	TotalCount      int32 `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	AvailableCount  int32    `protobuf:"varint,1,opt,name=available_count,json=availableCount,proto3" json:"available_count,omitempty"`
+	WaitingCount    int32    `protobuf:"varint,2,opt,name=waiting_count,json=waitingCount,proto3" json:"waiting_count,omitempty"`
+	ProcessingCount int32    `protobuf:"varint,3,opt,name=processing_count,json=processingCount,proto3" json:"processing_count,omitempty"`
+	TotalCount      int32    `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	RunningJobIds   []uint64 `protobuf:"varint,5,rep,packed,name=running_job_ids,json=runningJobIds,proto3" json:"running_job_ids,omitempty"` // 沙箱中尚未完成的任務，用於續約
 }
 
 func (x *SandboxStatusResponse) Reset() {
@@ -131,19 +132,28 @@ func (x *SandboxStatusResponse) GetTotalCount() int32 {
 	return 0
 }
 
+func (x *SandboxStatusResponse) GetRunningJobIds() []uint64 {
+	if x != nil {
+		return x.RunningJobIds
+	}
+	return nil
+}
+
 // 任務管理請求
 type AddJobRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ParentGitFullName   string `protobuf:"bytes,1,opt,name=parent_git_full_name,json=parentGitFullName,proto3" json:"parent_git_full_name,omitempty"`
-	GitRepoUrl          string `protobuf:"bytes,2,opt,name=git_repo_url,json=gitRepoUrl,proto3" json:"git_repo_url,omitempty"`       // Git 倉庫完整 URL
-	GitFullName         string `protobuf:"bytes,3,opt,name=git_full_name,json=gitFullName,proto3" json:"git_full_name,omitempty"`    // Git 倉庫完整名稱 (owner/repo)
-	GitAfterHash        string `protobuf:"bytes,4,opt,name=git_after_hash,json=gitAfterHash,proto3" json:"git_after_hash,omitempty"` // 要 checkout 的 commit hash
-	GitUsername         string `protobuf:"bytes,5,opt,name=git_username,json=gitUsername,proto3" json:"git_username,omitempty"`      // Git 用戶名
-	GitToken            string `protobuf:"bytes,6,opt,name=git_token,json=gitToken,proto3" json:"git_token,omitempty"`               // Git 訪問 token
-	UserQuestionTableId uint64 `protobuf:"varint,7,opt,name=user_question_table_id,json=userQuestionTableId,proto3" json:"user_question_table_id,omitempty"`
+	ParentGitFullName   string     `protobuf:"bytes,1,opt,name=parent_git_full_name,json=parentGitFullName,proto3" json:"parent_git_full_name,omitempty"`
+	GitRepoUrl          string     `protobuf:"bytes,2,opt,name=git_repo_url,json=gitRepoUrl,proto3" json:"git_repo_url,omitempty"`       // Git 倉庫完整 URL
+	GitFullName         string     `protobuf:"bytes,3,opt,name=git_full_name,json=gitFullName,proto3" json:"git_full_name,omitempty"`    // Git 倉庫完整名稱 (owner/repo)
+	GitAfterHash        string     `protobuf:"bytes,4,opt,name=git_after_hash,json=gitAfterHash,proto3" json:"git_after_hash,omitempty"` // 要 checkout 的 commit hash
+	GitUsername         string     `protobuf:"bytes,5,opt,name=git_username,json=gitUsername,proto3" json:"git_username,omitempty"`      // Git 用戶名
+	GitToken            string     `protobuf:"bytes,6,opt,name=git_token,json=gitToken,proto3" json:"git_token,omitempty"`               // Git 訪問 token
+	UserQuestionTableId uint64     `protobuf:"varint,7,opt,name=user_question_table_id,json=userQuestionTableId,proto3" json:"user_question_table_id,omitempty"`
+	JobId               uint64     `protobuf:"varint,8,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // 調度器任務 ID，用於確認與回報
+	Spec                *JudgeSpec `protobuf:"bytes,9,opt,name=spec,proto3" json:"spec,omitempty"`                 // 評測設定，沙箱不再讀取資料庫
 }
 
 func (x *AddJobRequest) Reset() {
@@ -227,19 +237,51 @@ func (x *AddJobRequest) GetUserQuestionTableId() uint64 {
 	return 0
 }
 
-// 任務管理回應
-type AddJobResponse struct {
+func (x *AddJobRequest) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *AddJobRequest) GetSpec() *JudgeSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+// 評測設定（對應 QuestionTestScript）
+type JudgeSpec struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	JobId   string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
-}
-
-func (x *AddJobResponse) Reset() {
-	*x = AddJobResponse{}
+	CompileScript      string          `protobuf:"bytes,1,opt,name=compile_script,json=compileScript,proto3" json:"compile_script,omitempty"`
+	ExecuteScript      string          `protobuf:"bytes,2,opt,name=execute_script,json=executeScript,proto3" json:"execute_script,omitempty"`
+	ScoreScript        string          `protobuf:"bytes,3,opt,name=score_script,json=scoreScript,proto3" json:"score_script,omitempty"`
+	ScoreMap           string          `protobuf:"bytes,4,opt,name=score_map,json=scoreMap,proto3" json:"score_map,omitempty"`
+	Memory             uint32          `protobuf:"varint,5,opt,name=memory,proto3" json:"memory,omitempty"`                              // KB
+	StackMemory        uint32          `protobuf:"varint,6,opt,name=stack_memory,json=stackMemory,proto3" json:"stack_memory,omitempty"` // KB
+	Time               uint32          `protobuf:"varint,7,opt,name=time,proto3" json:"time,omitempty"`                                  // ms
+	WallTime           uint32          `protobuf:"varint,8,opt,name=wall_time,json=wallTime,proto3" json:"wall_time,omitempty"`          // ms
+	FileSize           uint32          `protobuf:"varint,9,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`          // KB
+	Processes          uint32          `protobuf:"varint,10,opt,name=processes,proto3" json:"processes,omitempty"`
+	OpenFiles          uint32          `protobuf:"varint,11,opt,name=open_files,json=openFiles,proto3" json:"open_files,omitempty"`
+	JudgeMode          string          `protobuf:"bytes,12,opt,name=judge_mode,json=judgeMode,proto3" json:"judge_mode,omitempty"` // unit / io
+	Checker            string          `protobuf:"bytes,13,opt,name=checker,proto3" json:"checker,omitempty"`                      // exact / whitespace / float / custom
+	FloatTolerance     float64         `protobuf:"fixed64,14,opt,name=float_tolerance,json=floatTolerance,proto3" json:"float_tolerance,omitempty"`
+	CheckerScript      string          `protobuf:"bytes,15,opt,name=checker_script,json=checkerScript,proto3" json:"checker_script,omitempty"`
+	TestCases          []*TestCaseSpec `protobuf:"bytes,16,rep,name=test_cases,json=testCases,proto3" json:"test_cases,omitempty"` // 空則由題目倉庫 testcases/ 讀取
+	CompileLimits      *StageLimits    `protobuf:"bytes,17,opt,name=compile_limits,json=compileLimits,proto3" json:"compile_limits,omitempty"`
+	ScoreLimits        *StageLimits    `protobuf:"bytes,18,opt,name=score_limits,json=scoreLimits,proto3" json:"score_limits,omitempty"`
+	JudgeTimeout       uint32          `protobuf:"varint,19,opt,name=judge_timeout,json=judgeTimeout,proto3" json:"judge_timeout,omitempty"`                  // ms，整體評測時限，0 表示使用沙箱預設值
+	RequiredLabels     []string        `protobuf:"bytes,20,rep,name=required_labels,json=requiredLabels,proto3" json:"required_labels,omitempty"`             // 題目要求的沙箱標籤
+	RequiredToolchains []string        `protobuf:"bytes,21,rep,name=required_toolchains,json=requiredToolchains,proto3" json:"required_toolchains,omitempty"` // 評測設定模板需要的工具鏈
+}
+
+func (x *JudgeSpec) Reset() {
+	*x = JudgeSpec{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_proto_sandbox_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -247,13 +289,13 @@ func (x *AddJobResponse) Reset() {
 	}
 }
 
-func (x *AddJobResponse) String() string {
+func (x *JudgeSpec) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddJobResponse) ProtoMessage() {}
+func (*JudgeSpec) ProtoMessage() {}
 
-func (x *AddJobResponse) ProtoReflect() protoreflect.Message {
+func (x *JudgeSpec) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_sandbox_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -265,28 +307,778 @@ func (x *AddJobResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddJobResponse.ProtoReflect.Descriptor instead.
-func (*AddJobResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use JudgeSpec.ProtoReflect.Descriptor instead.
+func (*JudgeSpec) Descriptor() ([]byte, []int) {
 	return file_proto_sandbox_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *AddJobResponse) GetSuccess() bool {
+func (x *JudgeSpec) GetCompileScript() string {
 	if x != nil {
-		return x.Success
+		return x.CompileScript
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetExecuteScript() string {
+	if x != nil {
+		return x.ExecuteScript
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetScoreScript() string {
+	if x != nil {
+		return x.ScoreScript
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetScoreMap() string {
+	if x != nil {
+		return x.ScoreMap
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetStackMemory() uint32 {
+	if x != nil {
+		return x.StackMemory
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetWallTime() uint32 {
+	if x != nil {
+		return x.WallTime
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetFileSize() uint32 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetProcesses() uint32 {
+	if x != nil {
+		return x.Processes
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetOpenFiles() uint32 {
+	if x != nil {
+		return x.OpenFiles
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetJudgeMode() string {
+	if x != nil {
+		return x.JudgeMode
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetChecker() string {
+	if x != nil {
+		return x.Checker
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetFloatTolerance() float64 {
+	if x != nil {
+		return x.FloatTolerance
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetCheckerScript() string {
+	if x != nil {
+		return x.CheckerScript
+	}
+	return ""
+}
+
+func (x *JudgeSpec) GetTestCases() []*TestCaseSpec {
+	if x != nil {
+		return x.TestCases
+	}
+	return nil
+}
+
+func (x *JudgeSpec) GetCompileLimits() *StageLimits {
+	if x != nil {
+		return x.CompileLimits
+	}
+	return nil
+}
+
+func (x *JudgeSpec) GetScoreLimits() *StageLimits {
+	if x != nil {
+		return x.ScoreLimits
+	}
+	return nil
+}
+
+func (x *JudgeSpec) GetJudgeTimeout() uint32 {
+	if x != nil {
+		return x.JudgeTimeout
+	}
+	return 0
+}
+
+func (x *JudgeSpec) GetRequiredLabels() []string {
+	if x != nil {
+		return x.RequiredLabels
+	}
+	return nil
+}
+
+func (x *JudgeSpec) GetRequiredToolchains() []string {
+	if x != nil {
+		return x.RequiredToolchains
+	}
+	return nil
+}
+
+// 編譯與計分階段的資源限制，0 表示不限制
+type StageLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time      uint32 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`                         // ms
+	WallTime  uint32 `protobuf:"varint,2,opt,name=wall_time,json=wallTime,proto3" json:"wall_time,omitempty"` // ms
+	Memory    uint32 `protobuf:"varint,3,opt,name=memory,proto3" json:"memory,omitempty"`                     // KB
+	Processes uint32 `protobuf:"varint,4,opt,name=processes,proto3" json:"processes,omitempty"`
+	FileSize  uint32 `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"` // KB
+}
+
+func (x *StageLimits) Reset() {
+	*x = StageLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StageLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageLimits) ProtoMessage() {}
+
+func (x *StageLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageLimits.ProtoReflect.Descriptor instead.
+func (*StageLimits) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *StageLimits) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *StageLimits) GetWallTime() uint32 {
+	if x != nil {
+		return x.WallTime
+	}
+	return 0
+}
+
+func (x *StageLimits) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+func (x *StageLimits) GetProcesses() uint32 {
+	if x != nil {
+		return x.Processes
+	}
+	return 0
+}
+
+func (x *StageLimits) GetFileSize() uint32 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+// 輸入輸出模式的單一測資
+type TestCaseSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Input  string  `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	Output string  `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	Weight float64 `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Time   uint32  `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`     // ms，0 表示使用題目設定
+	Memory uint32  `protobuf:"varint,6,opt,name=memory,proto3" json:"memory,omitempty"` // KB，0 表示使用題目設定
+}
+
+func (x *TestCaseSpec) Reset() {
+	*x = TestCaseSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TestCaseSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestCaseSpec) ProtoMessage() {}
+
+func (x *TestCaseSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestCaseSpec.ProtoReflect.Descriptor instead.
+func (*TestCaseSpec) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TestCaseSpec) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TestCaseSpec) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *TestCaseSpec) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+func (x *TestCaseSpec) GetWeight() float64 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *TestCaseSpec) GetTime() uint32 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *TestCaseSpec) GetMemory() uint32 {
+	if x != nil {
+		return x.Memory
+	}
+	return 0
+}
+
+// 任務管理回應
+type AddJobResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Success bool   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	JobId   string `protobuf:"bytes,3,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+}
+
+func (x *AddJobResponse) Reset() {
+	*x = AddJobResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddJobResponse) ProtoMessage() {}
+
+func (x *AddJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddJobResponse.ProtoReflect.Descriptor instead.
+func (*AddJobResponse) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AddJobResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddJobResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AddJobResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// 任務確認（ACK/NACK）
+type JobAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId    uint64 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Accepted bool   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"` // false 表示沙箱退回任務，調度器需重新派發
+	Message  string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *JobAck) Reset() {
+	*x = JobAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobAck) ProtoMessage() {}
+
+func (x *JobAck) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobAck.ProtoReflect.Descriptor instead.
+func (*JobAck) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JobAck) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobAck) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *JobAck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// 任務評測進度
+type JobProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId     uint64 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	StartedAt int64  `protobuf:"varint,2,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // Unix 毫秒
+	Message   string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Stage     string `protobuf:"bytes,4,opt,name=stage,proto3" json:"stage,omitempty"`   // started / compile / execute / score
+	Target    string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"` // 目前處理的 target，started 時為空
+	Status    string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // 該 target 在此階段的結果
+}
+
+func (x *JobProgress) Reset() {
+	*x = JobProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProgress) ProtoMessage() {}
+
+func (x *JobProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProgress.ProtoReflect.Descriptor instead.
+func (*JobProgress) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JobProgress) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobProgress) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *JobProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *JobProgress) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *JobProgress) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *JobProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// 任務評測結果
+type JobResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId         uint64             `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Success       bool               `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"` // false 表示系統錯誤
+	Score         float64            `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	Message       string             `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                          // 合併後的 AllTests JSON 或錯誤訊息
+	StartedAt     int64              `protobuf:"varint,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`    // Unix 毫秒
+	FinishedAt    int64              `protobuf:"varint,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // Unix 毫秒
+	CompileTimeMs int64              `protobuf:"varint,7,opt,name=compile_time_ms,json=compileTimeMs,proto3" json:"compile_time_ms,omitempty"`
+	ExecuteTimeMs int64              `protobuf:"varint,8,opt,name=execute_time_ms,json=executeTimeMs,proto3" json:"execute_time_ms,omitempty"`
+	ScoreTimeMs   int64              `protobuf:"varint,9,opt,name=score_time_ms,json=scoreTimeMs,proto3" json:"score_time_ms,omitempty"`
+	Verdict       string             `protobuf:"bytes,10,opt,name=verdict,proto3" json:"verdict,omitempty"` // 整體評測結果，如 ACCEPTED / WRONG_ANSWER / TIME_LIMIT_EXCEEDED
+	Executions    []*ExecutionRecord `protobuf:"bytes,11,rep,name=executions,proto3" json:"executions,omitempty"`
+}
+
+func (x *JobResult) Reset() {
+	*x = JobResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobResult) ProtoMessage() {}
+
+func (x *JobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobResult.ProtoReflect.Descriptor instead.
+func (*JobResult) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JobResult) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *JobResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *JobResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *JobResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *JobResult) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *JobResult) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *JobResult) GetCompileTimeMs() int64 {
+	if x != nil {
+		return x.CompileTimeMs
+	}
+	return 0
+}
+
+func (x *JobResult) GetExecuteTimeMs() int64 {
+	if x != nil {
+		return x.ExecuteTimeMs
+	}
+	return 0
+}
+
+func (x *JobResult) GetScoreTimeMs() int64 {
+	if x != nil {
+		return x.ScoreTimeMs
+	}
+	return 0
+}
+
+func (x *JobResult) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *JobResult) GetExecutions() []*ExecutionRecord {
+	if x != nil {
+		return x.Executions
+	}
+	return nil
+}
+
+// 單一 target（輸入輸出模式為單筆測資）的執行紀錄，由 isolate meta 檔解析
+type ExecutionRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target     string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Verdict    string `protobuf:"bytes,2,opt,name=verdict,proto3" json:"verdict,omitempty"`
+	TimeMs     int64  `protobuf:"varint,3,opt,name=time_ms,json=timeMs,proto3" json:"time_ms,omitempty"` // CPU 時間
+	WallTimeMs int64  `protobuf:"varint,4,opt,name=wall_time_ms,json=wallTimeMs,proto3" json:"wall_time_ms,omitempty"`
+	MemoryKb   int64  `protobuf:"varint,5,opt,name=memory_kb,json=memoryKb,proto3" json:"memory_kb,omitempty"` // 峰值記憶體
+	ExitCode   int32  `protobuf:"varint,6,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	ExitSignal int32  `protobuf:"varint,7,opt,name=exit_signal,json=exitSignal,proto3" json:"exit_signal,omitempty"`
+	Status     string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`   // isolate status：RE / SG / TO / XX，正常結束為空
+	Message    string `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"` // isolate message
+}
+
+func (x *ExecutionRecord) Reset() {
+	*x = ExecutionRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecutionRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecutionRecord) ProtoMessage() {}
+
+func (x *ExecutionRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecutionRecord.ProtoReflect.Descriptor instead.
+func (*ExecutionRecord) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ExecutionRecord) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetVerdict() string {
+	if x != nil {
+		return x.Verdict
+	}
+	return ""
+}
+
+func (x *ExecutionRecord) GetTimeMs() int64 {
+	if x != nil {
+		return x.TimeMs
+	}
+	return 0
+}
+
+func (x *ExecutionRecord) GetWallTimeMs() int64 {
+	if x != nil {
+		return x.WallTimeMs
+	}
+	return 0
+}
+
+func (x *ExecutionRecord) GetMemoryKb() int64 {
+	if x != nil {
+		return x.MemoryKb
+	}
+	return 0
+}
+
+func (x *ExecutionRecord) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ExecutionRecord) GetExitSignal() int32 {
+	if x != nil {
+		return x.ExitSignal
 	}
-	return false
+	return 0
 }
 
-func (x *AddJobResponse) GetMessage() string {
+func (x *ExecutionRecord) GetStatus() string {
 	if x != nil {
-		return x.Message
+		return x.Status
 	}
 	return ""
 }
 
-func (x *AddJobResponse) GetJobId() string {
+func (x *ExecutionRecord) GetMessage() string {
 	if x != nil {
-		return x.JobId
+		return x.Message
 	}
 	return ""
 }
@@ -305,7 +1097,7 @@ type RegisterSandboxRequest struct {
 func (x *RegisterSandboxRequest) Reset() {
 	*x = RegisterSandboxRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[4]
+		mi := &file_proto_sandbox_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -318,7 +1110,7 @@ func (x *RegisterSandboxRequest) String() string {
 func (*RegisterSandboxRequest) ProtoMessage() {}
 
 func (x *RegisterSandboxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[4]
+	mi := &file_proto_sandbox_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -331,7 +1123,7 @@ func (x *RegisterSandboxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterSandboxRequest.ProtoReflect.Descriptor instead.
 func (*RegisterSandboxRequest) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{4}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RegisterSandboxRequest) GetSandboxId() string {
@@ -368,7 +1160,7 @@ type RegisterSandboxResponse struct {
 func (x *RegisterSandboxResponse) Reset() {
 	*x = RegisterSandboxResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[5]
+		mi := &file_proto_sandbox_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -381,7 +1173,7 @@ func (x *RegisterSandboxResponse) String() string {
 func (*RegisterSandboxResponse) ProtoMessage() {}
 
 func (x *RegisterSandboxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[5]
+	mi := &file_proto_sandbox_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,7 +1186,7 @@ func (x *RegisterSandboxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RegisterSandboxResponse.ProtoReflect.Descriptor instead.
 func (*RegisterSandboxResponse) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{5}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RegisterSandboxResponse) GetSuccess() bool {
@@ -423,7 +1215,7 @@ type UnregisterSandboxRequest struct {
 func (x *UnregisterSandboxRequest) Reset() {
 	*x = UnregisterSandboxRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[6]
+		mi := &file_proto_sandbox_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -436,7 +1228,7 @@ func (x *UnregisterSandboxRequest) String() string {
 func (*UnregisterSandboxRequest) ProtoMessage() {}
 
 func (x *UnregisterSandboxRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[6]
+	mi := &file_proto_sandbox_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +1241,7 @@ func (x *UnregisterSandboxRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnregisterSandboxRequest.ProtoReflect.Descriptor instead.
 func (*UnregisterSandboxRequest) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{6}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UnregisterSandboxRequest) GetSandboxId() string {
@@ -472,7 +1264,7 @@ type UnregisterSandboxResponse struct {
 func (x *UnregisterSandboxResponse) Reset() {
 	*x = UnregisterSandboxResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[7]
+		mi := &file_proto_sandbox_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -485,7 +1277,7 @@ func (x *UnregisterSandboxResponse) String() string {
 func (*UnregisterSandboxResponse) ProtoMessage() {}
 
 func (x *UnregisterSandboxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[7]
+	mi := &file_proto_sandbox_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -498,7 +1290,7 @@ func (x *UnregisterSandboxResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnregisterSandboxResponse.ProtoReflect.Descriptor instead.
 func (*UnregisterSandboxResponse) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{7}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UnregisterSandboxResponse) GetSuccess() bool {
@@ -528,7 +1320,7 @@ type HeartbeatRequest struct {
 func (x *HeartbeatRequest) Reset() {
 	*x = HeartbeatRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[8]
+		mi := &file_proto_sandbox_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -541,7 +1333,7 @@ func (x *HeartbeatRequest) String() string {
 func (*HeartbeatRequest) ProtoMessage() {}
 
 func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[8]
+	mi := &file_proto_sandbox_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,7 +1346,7 @@ func (x *HeartbeatRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatRequest.ProtoReflect.Descriptor instead.
 func (*HeartbeatRequest) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{8}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *HeartbeatRequest) GetSandboxId() string {
@@ -584,7 +1376,7 @@ type HeartbeatResponse struct {
 func (x *HeartbeatResponse) Reset() {
 	*x = HeartbeatResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[9]
+		mi := &file_proto_sandbox_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -597,7 +1389,7 @@ func (x *HeartbeatResponse) String() string {
 func (*HeartbeatResponse) ProtoMessage() {}
 
 func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[9]
+	mi := &file_proto_sandbox_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -610,7 +1402,7 @@ func (x *HeartbeatResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeartbeatResponse.ProtoReflect.Descriptor instead.
 func (*HeartbeatResponse) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{9}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *HeartbeatResponse) GetSuccess() bool {
@@ -633,27 +1425,309 @@ type SandboxConnectRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SandboxId string `protobuf:"bytes,1,opt,name=sandbox_id,json=sandboxId,proto3" json:"sandbox_id,omitempty"`
-	Capacity  int32  `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	SandboxId  string   `protobuf:"bytes,1,opt,name=sandbox_id,json=sandboxId,proto3" json:"sandbox_id,omitempty"`
+	Capacity   int32    `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Labels     []string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`         // 沙箱標籤，題目要求的標籤必須全部具備
+	Toolchains []string `protobuf:"bytes,4,rep,name=toolchains,proto3" json:"toolchains,omitempty"` // 已安裝的工具鏈，空表示未回報
+	Version    string   `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`       // 沙箱服務器版本
+}
+
+func (x *SandboxConnectRequest) Reset() {
+	*x = SandboxConnectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SandboxConnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxConnectRequest) ProtoMessage() {}
+
+func (x *SandboxConnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxConnectRequest.ProtoReflect.Descriptor instead.
+func (*SandboxConnectRequest) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SandboxConnectRequest) GetSandboxId() string {
+	if x != nil {
+		return x.SandboxId
+	}
+	return ""
+}
+
+func (x *SandboxConnectRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *SandboxConnectRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *SandboxConnectRequest) GetToolchains() []string {
+	if x != nil {
+		return x.Toolchains
+	}
+	return nil
+}
+
+func (x *SandboxConnectRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// 沙箱消息（從沙箱到調度器）
+type SandboxMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SandboxId string `protobuf:"bytes,1,opt,name=sandbox_id,json=sandboxId,proto3" json:"sandbox_id,omitempty"`
+	// Types that are assignable to MessageType:
+	//
+	//	*SandboxMessage_Connect
+	//	*SandboxMessage_Status
+	//	*SandboxMessage_JobResponse
+	//	*SandboxMessage_JobAck
+	//	*SandboxMessage_JobResult
+	//	*SandboxMessage_JobProgress
+	MessageType isSandboxMessage_MessageType `protobuf_oneof:"message_type"`
+}
+
+func (x *SandboxMessage) Reset() {
+	*x = SandboxMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SandboxMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SandboxMessage) ProtoMessage() {}
+
+func (x *SandboxMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SandboxMessage.ProtoReflect.Descriptor instead.
+func (*SandboxMessage) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SandboxMessage) GetSandboxId() string {
+	if x != nil {
+		return x.SandboxId
+	}
+	return ""
+}
+
+func (m *SandboxMessage) GetMessageType() isSandboxMessage_MessageType {
+	if m != nil {
+		return m.MessageType
+	}
+	return nil
+}
+
+func (x *SandboxMessage) GetConnect() *SandboxConnectRequest {
+	if x, ok := x.GetMessageType().(*SandboxMessage_Connect); ok {
+		return x.Connect
+	}
+	return nil
+}
+
+func (x *SandboxMessage) GetStatus() *SandboxStatusResponse {
+	if x, ok := x.GetMessageType().(*SandboxMessage_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *SandboxMessage) GetJobResponse() *AddJobResponse {
+	if x, ok := x.GetMessageType().(*SandboxMessage_JobResponse); ok {
+		return x.JobResponse
+	}
+	return nil
+}
+
+func (x *SandboxMessage) GetJobAck() *JobAck {
+	if x, ok := x.GetMessageType().(*SandboxMessage_JobAck); ok {
+		return x.JobAck
+	}
+	return nil
+}
+
+func (x *SandboxMessage) GetJobResult() *JobResult {
+	if x, ok := x.GetMessageType().(*SandboxMessage_JobResult); ok {
+		return x.JobResult
+	}
+	return nil
+}
+
+func (x *SandboxMessage) GetJobProgress() *JobProgress {
+	if x, ok := x.GetMessageType().(*SandboxMessage_JobProgress); ok {
+		return x.JobProgress
+	}
+	return nil
+}
+
+type isSandboxMessage_MessageType interface {
+	isSandboxMessage_MessageType()
+}
+
+type SandboxMessage_Connect struct {
+	Connect *SandboxConnectRequest `protobuf:"bytes,2,opt,name=connect,proto3,oneof"`
+}
+
+type SandboxMessage_Status struct {
+	Status *SandboxStatusResponse `protobuf:"bytes,3,opt,name=status,proto3,oneof"`
+}
+
+type SandboxMessage_JobResponse struct {
+	JobResponse *AddJobResponse `protobuf:"bytes,4,opt,name=job_response,json=jobResponse,proto3,oneof"`
+}
+
+type SandboxMessage_JobAck struct {
+	JobAck *JobAck `protobuf:"bytes,5,opt,name=job_ack,json=jobAck,proto3,oneof"`
+}
+
+type SandboxMessage_JobResult struct {
+	JobResult *JobResult `protobuf:"bytes,7,opt,name=job_result,json=jobResult,proto3,oneof"`
+}
+
+type SandboxMessage_JobProgress struct {
+	JobProgress *JobProgress `protobuf:"bytes,8,opt,name=job_progress,json=jobProgress,proto3,oneof"`
+}
+
+func (*SandboxMessage_Connect) isSandboxMessage_MessageType() {}
+
+func (*SandboxMessage_Status) isSandboxMessage_MessageType() {}
+
+func (*SandboxMessage_JobResponse) isSandboxMessage_MessageType() {}
+
+func (*SandboxMessage_JobAck) isSandboxMessage_MessageType() {}
+
+func (*SandboxMessage_JobResult) isSandboxMessage_MessageType() {}
+
+func (*SandboxMessage_JobProgress) isSandboxMessage_MessageType() {}
+
+// 取消任務，沙箱中止評測並釋放沙箱
+type CancelJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId  uint64 `protobuf:"varint,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CancelJob) Reset() {
+	*x = CancelJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelJob) ProtoMessage() {}
+
+func (x *CancelJob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelJob.ProtoReflect.Descriptor instead.
+func (*CancelJob) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CancelJob) GetJobId() uint64 {
+	if x != nil {
+		return x.JobId
+	}
+	return 0
+}
+
+func (x *CancelJob) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// 暫停或恢復派發新任務，執行中的任務不受影響
+type CordonSandbox struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cordoned bool   `protobuf:"varint,1,opt,name=cordoned,proto3" json:"cordoned,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *SandboxConnectRequest) Reset() {
-	*x = SandboxConnectRequest{}
+func (x *CordonSandbox) Reset() {
+	*x = CordonSandbox{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[10]
+		mi := &file_proto_sandbox_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SandboxConnectRequest) String() string {
+func (x *CordonSandbox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SandboxConnectRequest) ProtoMessage() {}
+func (*CordonSandbox) ProtoMessage() {}
 
-func (x *SandboxConnectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[10]
+func (x *CordonSandbox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,57 +1738,51 @@ func (x *SandboxConnectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SandboxConnectRequest.ProtoReflect.Descriptor instead.
-func (*SandboxConnectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CordonSandbox.ProtoReflect.Descriptor instead.
+func (*CordonSandbox) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *SandboxConnectRequest) GetSandboxId() string {
+func (x *CordonSandbox) GetCordoned() bool {
 	if x != nil {
-		return x.SandboxId
+		return x.Cordoned
 	}
-	return ""
+	return false
 }
 
-func (x *SandboxConnectRequest) GetCapacity() int32 {
+func (x *CordonSandbox) GetReason() string {
 	if x != nil {
-		return x.Capacity
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-// 沙箱消息（從沙箱到調度器）
-type SandboxMessage struct {
+// 完成目前的任務並回報結果後斷線結束
+type DrainSandbox struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SandboxId string `protobuf:"bytes,1,opt,name=sandbox_id,json=sandboxId,proto3" json:"sandbox_id,omitempty"`
-	// Types that are assignable to MessageType:
-	//
-	//	*SandboxMessage_Connect
-	//	*SandboxMessage_Status
-	//	*SandboxMessage_JobResponse
-	MessageType isSandboxMessage_MessageType `protobuf_oneof:"message_type"`
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *SandboxMessage) Reset() {
-	*x = SandboxMessage{}
+func (x *DrainSandbox) Reset() {
+	*x = DrainSandbox{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[11]
+		mi := &file_proto_sandbox_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SandboxMessage) String() string {
+func (x *DrainSandbox) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SandboxMessage) ProtoMessage() {}
+func (*DrainSandbox) ProtoMessage() {}
 
-func (x *SandboxMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[11]
+func (x *DrainSandbox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,68 +1793,66 @@ func (x *SandboxMessage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SandboxMessage.ProtoReflect.Descriptor instead.
-func (*SandboxMessage) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use DrainSandbox.ProtoReflect.Descriptor instead.
+func (*DrainSandbox) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *SandboxMessage) GetSandboxId() string {
+func (x *DrainSandbox) GetReason() string {
 	if x != nil {
-		return x.SandboxId
+		return x.Reason
 	}
 	return ""
 }
 
-func (m *SandboxMessage) GetMessageType() isSandboxMessage_MessageType {
-	if m != nil {
-		return m.MessageType
-	}
-	return nil
-}
+// 中止所有任務並立即斷線結束，未完成的任務由調度器重新派發
+type EvictSandbox struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *SandboxMessage) GetConnect() *SandboxConnectRequest {
-	if x, ok := x.GetMessageType().(*SandboxMessage_Connect); ok {
-		return x.Connect
-	}
-	return nil
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *SandboxMessage) GetStatus() *SandboxStatusResponse {
-	if x, ok := x.GetMessageType().(*SandboxMessage_Status); ok {
-		return x.Status
+func (x *EvictSandbox) Reset() {
+	*x = EvictSandbox{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sandbox_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *SandboxMessage) GetJobResponse() *AddJobResponse {
-	if x, ok := x.GetMessageType().(*SandboxMessage_JobResponse); ok {
-		return x.JobResponse
-	}
-	return nil
+func (x *EvictSandbox) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type isSandboxMessage_MessageType interface {
-	isSandboxMessage_MessageType()
-}
+func (*EvictSandbox) ProtoMessage() {}
 
-type SandboxMessage_Connect struct {
-	Connect *SandboxConnectRequest `protobuf:"bytes,2,opt,name=connect,proto3,oneof"`
+func (x *EvictSandbox) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sandbox_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type SandboxMessage_Status struct {
-	Status *SandboxStatusResponse `protobuf:"bytes,3,opt,name=status,proto3,oneof"`
+// Deprecated: Use EvictSandbox.ProtoReflect.Descriptor instead.
+func (*EvictSandbox) Descriptor() ([]byte, []int) {
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{22}
 }
 
-type SandboxMessage_JobResponse struct {
-	JobResponse *AddJobResponse `protobuf:"bytes,4,opt,name=job_response,json=jobResponse,proto3,oneof"`
+func (x *EvictSandbox) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
-func (*SandboxMessage_Connect) isSandboxMessage_MessageType() {}
-
-func (*SandboxMessage_Status) isSandboxMessage_MessageType() {}
-
-func (*SandboxMessage_JobResponse) isSandboxMessage_MessageType() {}
-
 // 調度器消息（從調度器到沙箱）
 type SchedulerMessage struct {
 	state         protoimpl.MessageState
@@ -799,13 +1865,17 @@ type SchedulerMessage struct {
 	//	*SchedulerMessage_ConnectResponse
 	//	*SchedulerMessage_JobRequest
 	//	*SchedulerMessage_StatusRequest
+	//	*SchedulerMessage_CancelJob
+	//	*SchedulerMessage_Cordon
+	//	*SchedulerMessage_Drain
+	//	*SchedulerMessage_Evict
 	MessageType isSchedulerMessage_MessageType `protobuf_oneof:"message_type"`
 }
 
 func (x *SchedulerMessage) Reset() {
 	*x = SchedulerMessage{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_sandbox_proto_msgTypes[12]
+		mi := &file_proto_sandbox_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -818,7 +1888,7 @@ func (x *SchedulerMessage) String() string {
 func (*SchedulerMessage) ProtoMessage() {}
 
 func (x *SchedulerMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_sandbox_proto_msgTypes[12]
+	mi := &file_proto_sandbox_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -831,7 +1901,7 @@ func (x *SchedulerMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchedulerMessage.ProtoReflect.Descriptor instead.
 func (*SchedulerMessage) Descriptor() ([]byte, []int) {
-	return file_proto_sandbox_proto_rawDescGZIP(), []int{12}
+	return file_proto_sandbox_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *SchedulerMessage) GetSandboxId() string {
@@ -869,6 +1939,34 @@ func (x *SchedulerMessage) GetStatusRequest() *SandboxStatusRequest {
 	return nil
 }
 
+func (x *SchedulerMessage) GetCancelJob() *CancelJob {
+	if x, ok := x.GetMessageType().(*SchedulerMessage_CancelJob); ok {
+		return x.CancelJob
+	}
+	return nil
+}
+
+func (x *SchedulerMessage) GetCordon() *CordonSandbox {
+	if x, ok := x.GetMessageType().(*SchedulerMessage_Cordon); ok {
+		return x.Cordon
+	}
+	return nil
+}
+
+func (x *SchedulerMessage) GetDrain() *DrainSandbox {
+	if x, ok := x.GetMessageType().(*SchedulerMessage_Drain); ok {
+		return x.Drain
+	}
+	return nil
+}
+
+func (x *SchedulerMessage) GetEvict() *EvictSandbox {
+	if x, ok := x.GetMessageType().(*SchedulerMessage_Evict); ok {
+		return x.Evict
+	}
+	return nil
+}
+
 type isSchedulerMessage_MessageType interface {
 	isSchedulerMessage_MessageType()
 }
@@ -885,19 +1983,43 @@ type SchedulerMessage_StatusRequest struct {
 	StatusRequest *SandboxStatusRequest `protobuf:"bytes,4,opt,name=status_request,json=statusRequest,proto3,oneof"`
 }
 
+type SchedulerMessage_CancelJob struct {
+	CancelJob *CancelJob `protobuf:"bytes,5,opt,name=cancel_job,json=cancelJob,proto3,oneof"`
+}
+
+type SchedulerMessage_Cordon struct {
+	Cordon *CordonSandbox `protobuf:"bytes,6,opt,name=cordon,proto3,oneof"`
+}
+
+type SchedulerMessage_Drain struct {
+	Drain *DrainSandbox `protobuf:"bytes,7,opt,name=drain,proto3,oneof"`
+}
+
+type SchedulerMessage_Evict struct {
+	Evict *EvictSandbox `protobuf:"bytes,8,opt,name=evict,proto3,oneof"`
+}
+
 func (*SchedulerMessage_ConnectResponse) isSchedulerMessage_MessageType() {}
 
 func (*SchedulerMessage_JobRequest) isSchedulerMessage_MessageType() {}
 
 func (*SchedulerMessage_StatusRequest) isSchedulerMessage_MessageType() {}
 
+func (*SchedulerMessage_CancelJob) isSchedulerMessage_MessageType() {}
+
+func (*SchedulerMessage_Cordon) isSchedulerMessage_MessageType() {}
+
+func (*SchedulerMessage_Drain) isSchedulerMessage_MessageType() {}
+
+func (*SchedulerMessage_Evict) isSchedulerMessage_MessageType() {}
+
 var File_proto_sandbox_proto protoreflect.FileDescriptor
 
 var file_proto_sandbox_proto_rawDesc = []byte{
 	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x22, 0x16,
 	0x0a, 0x14, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb1, 0x01, 0x0a, 0x15, 0x53, 0x61, 0x6e, 0x64, 0x62,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xd9, 0x01, 0x0a, 0x15, 0x53, 0x61, 0x6e, 0x64, 0x62,
 	0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
 	0x12, 0x27, 0x0a, 0x0f, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x63, 0x6f,
 	0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x61, 0x76, 0x61, 0x69, 0x6c,
@@ -908,139 +2030,311 @@ var file_proto_sandbox_proto_rawDesc = []byte{
 	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
 	0x73, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74,
 	0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xa1, 0x02, 0x0a, 0x0d, 0x41,
-	0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x14,
-	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x67, 0x69, 0x74, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f,
-	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x61, 0x72, 0x65,
-	0x6e, 0x74, 0x47, 0x69, 0x74, 0x46, 0x75, 0x6c, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
-	0x0c, 0x67, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x69, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x55, 0x72, 0x6c, 0x12,
-	0x22, 0x0a, 0x0d, 0x67, 0x69, 0x74, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x69, 0x74, 0x46, 0x75, 0x6c, 0x6c, 0x4e,
-	0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x67, 0x69, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72,
-	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x69, 0x74,
-	0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x69, 0x74,
-	0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x67, 0x69, 0x74, 0x55, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09,
-	0x67, 0x69, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x67, 0x69, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x33, 0x0a, 0x16, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x75, 0x73, 0x65, 0x72, 0x51,
-	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x5b,
-	0x0a, 0x0e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x22, 0x6d, 0x0a, 0x16, 0x52,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62,
-	0x6f, 0x78, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a,
-	0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0x4d, 0x0a, 0x17, 0x52, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x18, 0x55, 0x6e, 0x72,
-	0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62,
-	0x6f, 0x78, 0x49, 0x64, 0x22, 0x4f, 0x0a, 0x19, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x69, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
-	0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e,
-	0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x75,
+	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x04, 0x52, 0x0d, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x49,
+	0x64, 0x73, 0x22, 0xe0, 0x02, 0x0a, 0x0d, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x14, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x67,
+	0x69, 0x74, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x47, 0x69, 0x74, 0x46, 0x75, 0x6c,
+	0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x67, 0x69, 0x74, 0x5f, 0x72, 0x65, 0x70,
+	0x6f, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x69, 0x74,
+	0x52, 0x65, 0x70, 0x6f, 0x55, 0x72, 0x6c, 0x12, 0x22, 0x0a, 0x0d, 0x67, 0x69, 0x74, 0x5f, 0x66,
+	0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x67, 0x69, 0x74, 0x46, 0x75, 0x6c, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x67,
+	0x69, 0x74, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x67, 0x69, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x69, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x69, 0x74, 0x55, 0x73, 0x65, 0x72,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x69, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x69, 0x74, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x33, 0x0a, 0x16, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x13, 0x75, 0x73, 0x65, 0x72, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x26, 0x0a,
+	0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x61,
+	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52,
+	0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x93, 0x06, 0x0a, 0x09, 0x4a, 0x75, 0x64, 0x67, 0x65, 0x53,
+	0x70, 0x65, 0x63, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6d,
+	0x70, 0x69, 0x6c, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x65, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x53, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x53, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x6d, 0x61,
+	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4d, 0x61,
+	0x70, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61,
+	0x63, 0x6b, 0x5f, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0b, 0x73, 0x74, 0x61, 0x63, 0x6b, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x08, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1b, 0x0a,
+	0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x6e,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6f, 0x70,
+	0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6a, 0x75, 0x64, 0x67, 0x65,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6a, 0x75, 0x64,
+	0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65,
+	0x72, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72,
+	0x12, 0x27, 0x0a, 0x0f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x66, 0x6c, 0x6f, 0x61, 0x74,
+	0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x65, 0x72, 0x5f, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x72, 0x53, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x12, 0x34, 0x0a, 0x0a, 0x74, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x61, 0x73, 0x65, 0x73, 0x18, 0x10,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x54,
+	0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x53, 0x70, 0x65, 0x63, 0x52, 0x09, 0x74, 0x65, 0x73,
+	0x74, 0x43, 0x61, 0x73, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x6a, 0x75, 0x64, 0x67, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6a, 0x75, 0x64, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x54, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x0b,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x77, 0x61, 0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22,
+	0x94, 0x01, 0x0a, 0x0c, 0x54, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x53, 0x70, 0x65, 0x63,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x5b, 0x0a, 0x0e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x06,
+	0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f,
+	0x62, 0x49, 0x64, 0x22, 0x55, 0x0a, 0x06, 0x4a, 0x6f, 0x62, 0x41, 0x63, 0x6b, 0x12, 0x15, 0x0a,
+	0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a,
+	0x6f, 0x62, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xa3, 0x01, 0x0a, 0x0b, 0x4a,
+	0x6f, 0x62, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6a, 0x6f,
+	0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x22, 0xf4, 0x02, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x15,
+	0x0a, 0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x26, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f,
+	0x6d, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x65, 0x78, 0x65, 0x63, 0x75,
+	0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12,
+	0x22, 0x0a, 0x0d, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x54, 0x69, 0x6d,
+	0x65, 0x4d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x12, 0x38, 0x0a,
+	0x0a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0a, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x8b, 0x02, 0x0a, 0x0f, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x64, 0x69, 0x63, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x74, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x20, 0x0a, 0x0c, 0x77, 0x61, 0x6c, 0x6c, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x77, 0x61,
+	0x6c, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x5f, 0x6b, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4b, 0x62, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x74, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x6d, 0x0a, 0x16, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x22, 0x4d, 0x0a, 0x17, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0x39, 0x0a, 0x18, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x22, 0x4f,
+	0x0a, 0x19, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x69, 0x0a, 0x10, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
+	0x49, 0x64, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e,
+	0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x47, 0x0a, 0x11, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x22, 0xa4, 0x01, 0x0a, 0x15, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x6f, 0x6f, 0x6c, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa3, 0x03, 0x0a, 0x0e, 0x53,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x12, 0x3a, 0x0a, 0x07,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e,
+	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x38, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62,
 	0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x22, 0x47, 0x0a, 0x11, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x52, 0x0a, 0x15, 0x53, 0x61, 0x6e,
-	0x64, 0x62, 0x6f, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x22, 0xf3, 0x01,
-	0x0a, 0x0e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x12,
-	0x3a, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62,
-	0x6f, 0x78, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x48, 0x00, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x12, 0x38, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x61,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2a, 0x0a, 0x07, 0x6a, 0x6f, 0x62, 0x5f, 0x61, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x41,
+	0x63, 0x6b, 0x48, 0x00, 0x52, 0x06, 0x6a, 0x6f, 0x62, 0x41, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0a,
+	0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x09, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x39, 0x0a, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f,
+	0x78, 0x2e, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52,
+	0x0b, 0x6a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x42, 0x0e, 0x0a, 0x0c,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x4a, 0x04, 0x08, 0x06,
+	0x10, 0x07, 0x52, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x22, 0x3a, 0x0a, 0x09, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x15, 0x0a,
+	0x06, 0x6a, 0x6f, 0x62, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6a,
+	0x6f, 0x62, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x43, 0x0a, 0x0d,
+	0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0x26, 0x0a, 0x0c, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f,
+	0x78, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x26, 0x0a, 0x0c, 0x45, 0x76, 0x69,
+	0x63, 0x74, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x22, 0xd8, 0x03, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f,
+	0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x49, 0x64, 0x12, 0x4d, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x0a, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x46, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f,
+	0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x5f, 0x6a, 0x6f, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x61,
+	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x48,
+	0x00, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x30, 0x0a, 0x06,
+	0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x43, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x53, 0x61, 0x6e,
+	0x64, 0x62, 0x6f, 0x78, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f, 0x72, 0x64, 0x6f, 0x6e, 0x12, 0x2d,
+	0x0a, 0x05, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x53, 0x61, 0x6e,
+	0x64, 0x62, 0x6f, 0x78, 0x48, 0x00, 0x52, 0x05, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x12, 0x2d, 0x0a,
+	0x05, 0x65, 0x76, 0x69, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x45, 0x76, 0x69, 0x63, 0x74, 0x53, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x48, 0x00, 0x52, 0x05, 0x65, 0x76, 0x69, 0x63, 0x74, 0x42, 0x0e, 0x0a, 0x0c,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x32, 0xe5, 0x01, 0x0a,
+	0x0e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x4a, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x73,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x61,
 	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x61,
-	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0b, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x22, 0x93, 0x02, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
-	0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x61, 0x6e, 0x64,
-	0x62, 0x6f, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x61,
-	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x49, 0x64, 0x12, 0x4d, 0x0a, 0x10, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0b, 0x6a, 0x6f, 0x62, 0x5f, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x61,
-	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0a, 0x6a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x46, 0x0a, 0x0e, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x61, 0x6e, 0x64,
-	0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x00, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x0e, 0x0a, 0x0c, 0x6d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x32, 0xe5, 0x01, 0x0a, 0x0e, 0x53, 0x61,
-	0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4a, 0x0a, 0x09,
-	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x2e, 0x73, 0x61, 0x6e, 0x64,
-	0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62,
-	0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x41, 0x64, 0x64, 0x4a,
-	0x6f, 0x62, 0x12, 0x16, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64,
-	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73, 0x61, 0x6e,
-	0x64, 0x62, 0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e,
-	0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64,
-	0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x32, 0xd1, 0x02, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
-	0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x12, 0x1f, 0x2e, 0x73, 0x61, 0x6e, 0x64,
-	0x62, 0x6f, 0x78, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64,
-	0x62, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x61, 0x6e,
-	0x64, 0x62, 0x6f, 0x78, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e,
-	0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x11,
-	0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f,
-	0x78, 0x12, 0x21, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x55, 0x6e, 0x72, 0x65,
-	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x55,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x41,
+	0x64, 0x64, 0x4a, 0x6f, 0x62, 0x12, 0x16, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e,
+	0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x41, 0x64, 0x64, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e,
+	0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0xd1, 0x02, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54, 0x0a, 0x0f, 0x52, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x12, 0x1f, 0x2e, 0x73,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53,
+	0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x5a, 0x0a, 0x11, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e,
+	0x64, 0x62, 0x6f, 0x78, 0x12, 0x21, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x55,
 	0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72,
-	0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x19, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e,
-	0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74,
-	0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0d,
-	0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x17, 0x2e,
-	0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x4d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x19, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
-	0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x16, 0x5a, 0x14, 0x4f, 0x4a, 0x2d, 0x41, 0x50, 0x49, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f,
+	0x78, 0x2e, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x48,
+	0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x12, 0x19, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x48, 0x65,
+	0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x47, 0x0a, 0x0d, 0x53, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x12, 0x17, 0x2e, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78, 0x2e, 0x53, 0x61, 0x6e, 0x64, 0x62,
+	0x6f, 0x78, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x19, 0x2e, 0x73, 0x61, 0x6e, 0x64,
+	0x62, 0x6f, 0x78, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x72, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x16, 0x5a, 0x14, 0x4f, 0x4a, 0x2d, 0x41,
+	0x50, 0x49, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x61, 0x6e, 0x64, 0x62, 0x6f, 0x78,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1055,49 +2349,72 @@ func file_proto_sandbox_proto_rawDescGZIP() []byte {
 	return file_proto_sandbox_proto_rawDescData
 }
 
-var file_proto_sandbox_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_sandbox_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_sandbox_proto_goTypes = []interface{}{
 	(*SandboxStatusRequest)(nil),      // 0: sandbox.SandboxStatusRequest
 	(*SandboxStatusResponse)(nil),     // 1: sandbox.SandboxStatusResponse
 	(*AddJobRequest)(nil),             // 2: sandbox.AddJobRequest
-	(*AddJobResponse)(nil),            // 3: sandbox.AddJobResponse
-	(*RegisterSandboxRequest)(nil),    // 4: sandbox.RegisterSandboxRequest
-	(*RegisterSandboxResponse)(nil),   // 5: sandbox.RegisterSandboxResponse
-	(*UnregisterSandboxRequest)(nil),  // 6: sandbox.UnregisterSandboxRequest
-	(*UnregisterSandboxResponse)(nil), // 7: sandbox.UnregisterSandboxResponse
-	(*HeartbeatRequest)(nil),          // 8: sandbox.HeartbeatRequest
-	(*HeartbeatResponse)(nil),         // 9: sandbox.HeartbeatResponse
-	(*SandboxConnectRequest)(nil),     // 10: sandbox.SandboxConnectRequest
-	(*SandboxMessage)(nil),            // 11: sandbox.SandboxMessage
-	(*SchedulerMessage)(nil),          // 12: sandbox.SchedulerMessage
+	(*JudgeSpec)(nil),                 // 3: sandbox.JudgeSpec
+	(*StageLimits)(nil),               // 4: sandbox.StageLimits
+	(*TestCaseSpec)(nil),              // 5: sandbox.TestCaseSpec
+	(*AddJobResponse)(nil),            // 6: sandbox.AddJobResponse
+	(*JobAck)(nil),                    // 7: sandbox.JobAck
+	(*JobProgress)(nil),               // 8: sandbox.JobProgress
+	(*JobResult)(nil),                 // 9: sandbox.JobResult
+	(*ExecutionRecord)(nil),           // 10: sandbox.ExecutionRecord
+	(*RegisterSandboxRequest)(nil),    // 11: sandbox.RegisterSandboxRequest
+	(*RegisterSandboxResponse)(nil),   // 12: sandbox.RegisterSandboxResponse
+	(*UnregisterSandboxRequest)(nil),  // 13: sandbox.UnregisterSandboxRequest
+	(*UnregisterSandboxResponse)(nil), // 14: sandbox.UnregisterSandboxResponse
+	(*HeartbeatRequest)(nil),          // 15: sandbox.HeartbeatRequest
+	(*HeartbeatResponse)(nil),         // 16: sandbox.HeartbeatResponse
+	(*SandboxConnectRequest)(nil),     // 17: sandbox.SandboxConnectRequest
+	(*SandboxMessage)(nil),            // 18: sandbox.SandboxMessage
+	(*CancelJob)(nil),                 // 19: sandbox.CancelJob
+	(*CordonSandbox)(nil),             // 20: sandbox.CordonSandbox
+	(*DrainSandbox)(nil),              // 21: sandbox.DrainSandbox
+	(*EvictSandbox)(nil),              // 22: sandbox.EvictSandbox
+	(*SchedulerMessage)(nil),          // 23: sandbox.SchedulerMessage
 }
 var file_proto_sandbox_proto_depIdxs = []int32{
-	1,  // 0: sandbox.HeartbeatRequest.status:type_name -> sandbox.SandboxStatusResponse
-	10, // 1: sandbox.SandboxMessage.connect:type_name -> sandbox.SandboxConnectRequest
-	1,  // 2: sandbox.SandboxMessage.status:type_name -> sandbox.SandboxStatusResponse
-	3,  // 3: sandbox.SandboxMessage.job_response:type_name -> sandbox.AddJobResponse
-	5,  // 4: sandbox.SchedulerMessage.connect_response:type_name -> sandbox.RegisterSandboxResponse
-	2,  // 5: sandbox.SchedulerMessage.job_request:type_name -> sandbox.AddJobRequest
-	0,  // 6: sandbox.SchedulerMessage.status_request:type_name -> sandbox.SandboxStatusRequest
-	0,  // 7: sandbox.SandboxService.GetStatus:input_type -> sandbox.SandboxStatusRequest
-	2,  // 8: sandbox.SandboxService.AddJob:input_type -> sandbox.AddJobRequest
-	0,  // 9: sandbox.SandboxService.HealthCheck:input_type -> sandbox.SandboxStatusRequest
-	4,  // 10: sandbox.SchedulerService.RegisterSandbox:input_type -> sandbox.RegisterSandboxRequest
-	6,  // 11: sandbox.SchedulerService.UnregisterSandbox:input_type -> sandbox.UnregisterSandboxRequest
-	8,  // 12: sandbox.SchedulerService.Heartbeat:input_type -> sandbox.HeartbeatRequest
-	11, // 13: sandbox.SchedulerService.SandboxStream:input_type -> sandbox.SandboxMessage
-	1,  // 14: sandbox.SandboxService.GetStatus:output_type -> sandbox.SandboxStatusResponse
-	3,  // 15: sandbox.SandboxService.AddJob:output_type -> sandbox.AddJobResponse
-	1,  // 16: sandbox.SandboxService.HealthCheck:output_type -> sandbox.SandboxStatusResponse
-	5,  // 17: sandbox.SchedulerService.RegisterSandbox:output_type -> sandbox.RegisterSandboxResponse
-	7,  // 18: sandbox.SchedulerService.UnregisterSandbox:output_type -> sandbox.UnregisterSandboxResponse
-	9,  // 19: sandbox.SchedulerService.Heartbeat:output_type -> sandbox.HeartbeatResponse
-	12, // 20: sandbox.SchedulerService.SandboxStream:output_type -> sandbox.SchedulerMessage
-	14, // [14:21] is the sub-list for method output_type
-	7,  // [7:14] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	3,  // 0: sandbox.AddJobRequest.spec:type_name -> sandbox.JudgeSpec
+	5,  // 1: sandbox.JudgeSpec.test_cases:type_name -> sandbox.TestCaseSpec
+	4,  // 2: sandbox.JudgeSpec.compile_limits:type_name -> sandbox.StageLimits
+	4,  // 3: sandbox.JudgeSpec.score_limits:type_name -> sandbox.StageLimits
+	10, // 4: sandbox.JobResult.executions:type_name -> sandbox.ExecutionRecord
+	1,  // 5: sandbox.HeartbeatRequest.status:type_name -> sandbox.SandboxStatusResponse
+	17, // 6: sandbox.SandboxMessage.connect:type_name -> sandbox.SandboxConnectRequest
+	1,  // 7: sandbox.SandboxMessage.status:type_name -> sandbox.SandboxStatusResponse
+	6,  // 8: sandbox.SandboxMessage.job_response:type_name -> sandbox.AddJobResponse
+	7,  // 9: sandbox.SandboxMessage.job_ack:type_name -> sandbox.JobAck
+	9,  // 10: sandbox.SandboxMessage.job_result:type_name -> sandbox.JobResult
+	8,  // 11: sandbox.SandboxMessage.job_progress:type_name -> sandbox.JobProgress
+	12, // 12: sandbox.SchedulerMessage.connect_response:type_name -> sandbox.RegisterSandboxResponse
+	2,  // 13: sandbox.SchedulerMessage.job_request:type_name -> sandbox.AddJobRequest
+	0,  // 14: sandbox.SchedulerMessage.status_request:type_name -> sandbox.SandboxStatusRequest
+	19, // 15: sandbox.SchedulerMessage.cancel_job:type_name -> sandbox.CancelJob
+	20, // 16: sandbox.SchedulerMessage.cordon:type_name -> sandbox.CordonSandbox
+	21, // 17: sandbox.SchedulerMessage.drain:type_name -> sandbox.DrainSandbox
+	22, // 18: sandbox.SchedulerMessage.evict:type_name -> sandbox.EvictSandbox
+	0,  // 19: sandbox.SandboxService.GetStatus:input_type -> sandbox.SandboxStatusRequest
+	2,  // 20: sandbox.SandboxService.AddJob:input_type -> sandbox.AddJobRequest
+	0,  // 21: sandbox.SandboxService.HealthCheck:input_type -> sandbox.SandboxStatusRequest
+	11, // 22: sandbox.SchedulerService.RegisterSandbox:input_type -> sandbox.RegisterSandboxRequest
+	13, // 23: sandbox.SchedulerService.UnregisterSandbox:input_type -> sandbox.UnregisterSandboxRequest
+	15, // 24: sandbox.SchedulerService.Heartbeat:input_type -> sandbox.HeartbeatRequest
+	18, // 25: sandbox.SchedulerService.SandboxStream:input_type -> sandbox.SandboxMessage
+	1,  // 26: sandbox.SandboxService.GetStatus:output_type -> sandbox.SandboxStatusResponse
+	6,  // 27: sandbox.SandboxService.AddJob:output_type -> sandbox.AddJobResponse
+	1,  // 28: sandbox.SandboxService.HealthCheck:output_type -> sandbox.SandboxStatusResponse
+	12, // 29: sandbox.SchedulerService.RegisterSandbox:output_type -> sandbox.RegisterSandboxResponse
+	14, // 30: sandbox.SchedulerService.UnregisterSandbox:output_type -> sandbox.UnregisterSandboxResponse
+	16, // 31: sandbox.SchedulerService.Heartbeat:output_type -> sandbox.HeartbeatResponse
+	23, // 32: sandbox.SchedulerService.SandboxStream:output_type -> sandbox.SchedulerMessage
+	26, // [26:33] is the sub-list for method output_type
+	19, // [19:26] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_sandbox_proto_init() }
@@ -1143,7 +2460,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddJobResponse); i {
+			switch v := v.(*JudgeSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1155,7 +2472,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterSandboxRequest); i {
+			switch v := v.(*StageLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1167,7 +2484,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterSandboxResponse); i {
+			switch v := v.(*TestCaseSpec); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1179,7 +2496,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnregisterSandboxRequest); i {
+			switch v := v.(*AddJobResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1191,7 +2508,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UnregisterSandboxResponse); i {
+			switch v := v.(*JobAck); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1203,7 +2520,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeartbeatRequest); i {
+			switch v := v.(*JobProgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1215,7 +2532,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HeartbeatResponse); i {
+			switch v := v.(*JobResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1227,7 +2544,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SandboxConnectRequest); i {
+			switch v := v.(*ExecutionRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1239,7 +2556,7 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SandboxMessage); i {
+			switch v := v.(*RegisterSandboxRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1251,6 +2568,138 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 		file_proto_sandbox_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterSandboxResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnregisterSandboxRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnregisterSandboxResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HeartbeatResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SandboxConnectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SandboxMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CordonSandbox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainSandbox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvictSandbox); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sandbox_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SchedulerMessage); i {
 			case 0:
 				return &v.state
@@ -1263,15 +2712,22 @@ func file_proto_sandbox_proto_init() {
 			}
 		}
 	}
-	file_proto_sandbox_proto_msgTypes[11].OneofWrappers = []interface{}{
+	file_proto_sandbox_proto_msgTypes[18].OneofWrappers = []interface{}{
 		(*SandboxMessage_Connect)(nil),
 		(*SandboxMessage_Status)(nil),
 		(*SandboxMessage_JobResponse)(nil),
+		(*SandboxMessage_JobAck)(nil),
+		(*SandboxMessage_JobResult)(nil),
+		(*SandboxMessage_JobProgress)(nil),
 	}
-	file_proto_sandbox_proto_msgTypes[12].OneofWrappers = []interface{}{
+	file_proto_sandbox_proto_msgTypes[23].OneofWrappers = []interface{}{
 		(*SchedulerMessage_ConnectResponse)(nil),
 		(*SchedulerMessage_JobRequest)(nil),
 		(*SchedulerMessage_StatusRequest)(nil),
+		(*SchedulerMessage_CancelJob)(nil),
+		(*SchedulerMessage_Cordon)(nil),
+		(*SchedulerMessage_Drain)(nil),
+		(*SchedulerMessage_Evict)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1279,7 +2735,7 @@ func file_proto_sandbox_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_sandbox_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
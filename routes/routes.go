@@ -168,6 +168,7 @@ func RegisterRoutes(r *gin.Engine) {
 		api.PATCH("/admin/:id/user", AuthMiddleware(), handlers.UpdateUserInfo)
 		api.POST("/admin/:id/user/change_email", AuthMiddleware(), handlers.ChangeUserEmail)
 		api.GET("/admin/questions/:id/export", AuthMiddleware(), handlers.ExportQuestionScore)
+		api.GET("/admin/rejudge/:id/export", AuthMiddleware(), handlers.ExportRejudgeReport)
 
 		// Exam routes
 		api.POST("/exams/admin", AuthMiddleware(), handlers.CreateExam)
@@ -182,9 +183,19 @@ func RegisterRoutes(r *gin.Engine) {
 		api.GET("/exams/:id/questions", AuthMiddleware(false), handlers.GetExamQuestions)
 		api.GET("/exams/:id/score/top", AuthMiddleware(), handlers.GetTopExamScore)
 
+		// Extension routes
+		api.GET("/extensions/admin", AuthMiddleware(), handlers.ListExtensions)
+		api.PUT("/extensions/admin", AuthMiddleware(), handlers.PutExtension)
+		api.DELETE("/extensions/admin/:id", AuthMiddleware(), handlers.DeleteExtension)
+
 		// Sandbox routes
 		api.POST("/sandbox/admin/sandbox_cmd", AuthMiddleware(), handlers.PostSandboxCmd)
 		api.GET("/sandbox/status", handlers.GetSandboxStatus)
+		api.GET("/sandbox/admin/instances", AuthMiddleware(), handlers.ListSandboxInstances)
+		api.POST("/sandbox/admin/instances/:id/cordon", AuthMiddleware(), handlers.CordonSandbox)
+		api.POST("/sandbox/admin/instances/:id/uncordon", AuthMiddleware(), handlers.UncordonSandbox)
+		api.POST("/sandbox/admin/instances/:id/drain", AuthMiddleware(), handlers.DrainSandbox)
+		api.POST("/sandbox/admin/instances/:id/evict", AuthMiddleware(), handlers.EvictSandbox)
 
 		// Gitea routes
 		api.POST("/gitea", AuthMiddleware(), handlers.PostGiteaHook)
@@ -204,6 +215,9 @@ func RegisterRoutes(r *gin.Engine) {
 		api.POST("/questions/admin/question", AuthMiddleware(), handlers.AddQuestion)
 		api.GET("/questions/admin/:ID/question_limit", AuthMiddleware(), handlers.GetQuestionLimitByID)
 		api.GET("/questions/admin/:ID/scripts", AuthMiddleware(), handlers.GetQuestionScripts)
+		api.GET("/questions/admin/:ID/testcases", AuthMiddleware(), handlers.GetQuestionTestCases)
+		api.PUT("/questions/admin/:ID/testcases", AuthMiddleware(), handlers.PutQuestionTestCases)
+		api.GET("/questions/admin/profiles", AuthMiddleware(), handlers.GetJudgeProfiles)
 		api.GET("/questions/user", AuthMiddleware(), handlers.GetUsersQuestions)
 		api.GET("/questions/user/:ID/question", AuthMiddleware(), handlers.GetUserQuestionByID)
 
@@ -213,9 +227,18 @@ func RegisterRoutes(r *gin.Engine) {
 		api.GET("/score/leaderboard", AuthMiddleware(false), handlers.GetLeaderboard)
 		api.GET("/score/:question_id/question", AuthMiddleware(), handlers.GetScoreByQuestionID)
 		api.POST("/score/admin/:question_id/question/rescore", AuthMiddleware(), handlers.ReScoreQuestion)
+		api.POST("/score/admin/rejudge", AuthMiddleware(), handlers.RejudgeSubmissions)
+		api.GET("/score/admin/rejudge", AuthMiddleware(), handlers.ListRejudgeBatches)
+		api.GET("/score/admin/rejudge/:id", AuthMiddleware(), handlers.GetRejudgeBatch)
+		api.POST("/score/admin/rejudge/:id/cancel", AuthMiddleware(), handlers.CancelRejudgeBatch)
 		api.GET("/score/top", AuthMiddleware(), handlers.GetTopScore)
 		api.POST("/score/:question_id/question/user_rescore", AuthMiddleware(), handlers.ReScoreUserQuestion)
 		api.GET("/score/uqr/:UQR_ID/score", AuthMiddleware(), handlers.GetScoreByUQRID)
+		api.GET("/score/uqt/:id/stream", AuthMiddleware(), handlers.GetScoreStream)
+		api.POST("/score/admin/uqt/:id/cancel", AuthMiddleware(), handlers.CancelSubmission)
+		api.GET("/score/admin/overrides", AuthMiddleware(), handlers.ListScoreOverrides)
+		api.POST("/score/admin/overrides", AuthMiddleware(), handlers.CreateScoreOverride)
+		api.DELETE("/score/admin/overrides/:id", AuthMiddleware(), handlers.RevokeScoreOverride)
 
 		// User routes
 		api.GET("/user", AuthMiddleware(), handlers.GetUser)
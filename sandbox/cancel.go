@@ -0,0 +1,91 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	errJobAborted   = errors.New("job cancelled by scheduler")
+	errJudgeTimeout = errors.New("judge deadline exceeded")
+)
+
+// trackedJob 已接收但尚未完成的任務
+type trackedJob struct {
+	cancel    context.CancelCauseFunc // 評測開始後才有值
+	cancelled bool
+}
+
+// CancelJob 取消任務：評測中的任務立即中止，尚未開始的任務在開始時略過
+//
+// 回傳 false 表示沙箱中沒有此任務。
+func (s *Sandbox) CancelJob(jobID uint64) bool {
+	s.runningJobsMutex.Lock()
+	defer s.runningJobsMutex.Unlock()
+
+	job, ok := s.runningJobs[jobID]
+	if !ok {
+		return false
+	}
+	job.cancelled = true
+	if job.cancel != nil {
+		job.cancel(errJobAborted)
+	}
+	return true
+}
+
+// isJobCancelled 任務是否已被調度器取消
+func (s *Sandbox) isJobCancelled(jobID uint64) bool {
+	s.runningJobsMutex.RLock()
+	defer s.runningJobsMutex.RUnlock()
+
+	job, ok := s.runningJobs[jobID]
+	return ok && job.cancelled
+}
+
+// jobContext 建立任務的評測 context，超過整體時限或被取消時結束
+//
+// 不繼承 WorkerLoop 的 context，關機時讓已開始的任務完整執行。
+func (s *Sandbox) jobContext(jobID uint64, timeout time.Duration) (context.Context, context.CancelFunc) {
+	base, cancelCause := context.WithCancelCause(context.Background())
+	ctx, cancelTimeout := context.WithTimeout(base, timeout)
+
+	s.runningJobsMutex.Lock()
+	if job, ok := s.runningJobs[jobID]; ok {
+		job.cancel = cancelCause
+		if job.cancelled {
+			cancelCause(errJobAborted)
+		}
+	}
+	s.runningJobsMutex.Unlock()
+
+	return ctx, func() {
+		s.runningJobsMutex.Lock()
+		if job, ok := s.runningJobs[jobID]; ok {
+			job.cancel = nil
+		}
+		s.runningJobsMutex.Unlock()
+		cancelTimeout()
+		cancelCause(nil)
+	}
+}
+
+// judgeTimeout 題目設定的整體評測時限
+func judgeTimeout(timeoutMs uint) time.Duration {
+	if timeoutMs == 0 {
+		return execTimeoutDuration
+	}
+	return time.Duration(timeoutMs) * time.Millisecond
+}
+
+// judgeInterrupted 評測被取消或超過整體時限時回傳對應的錯誤
+func judgeInterrupted(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+	if errors.Is(context.Cause(ctx), errJobAborted) {
+		return errJobAborted
+	}
+	return errJudgeTimeout
+}
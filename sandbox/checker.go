@@ -0,0 +1,91 @@
+package sandbox
+
+import (
+	"OJ-API/models"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const maxDiffPreview = 64
+
+// checkOutput 以內建比對器比較標準答案與程式輸出，回傳是否正確與差異說明
+func checkOutput(checker models.CheckerType, tolerance float64, expected, actual string) (bool, string) {
+	switch checker {
+	case models.CheckerWhitespace:
+		return checkTokens(expected, actual, func(e, a string) bool { return e == a })
+	case models.CheckerFloat:
+		return checkTokens(expected, actual, func(e, a string) bool { return floatEqual(e, a, tolerance) })
+	default:
+		return checkExact(expected, actual)
+	}
+}
+
+// checkExact 逐行完全比對，僅忽略換行符號差異（CRLF）與檔案結尾的空行
+func checkExact(expected, actual string) (bool, string) {
+	exp := splitLines(expected)
+	act := splitLines(actual)
+
+	for i := 0; i < len(exp) || i < len(act); i++ {
+		if i >= len(exp) {
+			return false, fmt.Sprintf("line %d: expected end of output, got %q", i+1, preview(act[i]))
+		}
+		if i >= len(act) {
+			return false, fmt.Sprintf("line %d: expected %q, got end of output", i+1, preview(exp[i]))
+		}
+		if exp[i] != act[i] {
+			return false, fmt.Sprintf("line %d: expected %q, got %q", i+1, preview(exp[i]), preview(act[i]))
+		}
+	}
+	return true, ""
+}
+
+// checkTokens 忽略空白差異，逐一比對以空白分隔的字詞
+func checkTokens(expected, actual string, equal func(e, a string) bool) (bool, string) {
+	exp := strings.Fields(expected)
+	act := strings.Fields(actual)
+
+	for i := 0; i < len(exp) || i < len(act); i++ {
+		if i >= len(exp) {
+			return false, fmt.Sprintf("token %d: expected end of output, got %q", i+1, preview(act[i]))
+		}
+		if i >= len(act) {
+			return false, fmt.Sprintf("token %d: expected %q, got end of output", i+1, preview(exp[i]))
+		}
+		if !equal(exp[i], act[i]) {
+			return false, fmt.Sprintf("token %d: expected %q, got %q", i+1, preview(exp[i]), preview(act[i]))
+		}
+	}
+	return true, ""
+}
+
+// floatEqual 數值以絕對或相對誤差比較，非數值則需完全相同
+func floatEqual(expected, actual string, tolerance float64) bool {
+	e, errE := strconv.ParseFloat(expected, 64)
+	a, errA := strconv.ParseFloat(actual, 64)
+	if errE != nil || errA != nil {
+		return expected == actual
+	}
+	if math.IsNaN(e) || math.IsNaN(a) {
+		return math.IsNaN(e) && math.IsNaN(a)
+	}
+	diff := math.Abs(e - a)
+	return diff <= tolerance || diff <= tolerance*math.Abs(e)
+}
+
+func splitLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+func preview(s string) string {
+	if len(s) > maxDiffPreview {
+		return s[:maxDiffPreview] + "..."
+	}
+	return s
+}
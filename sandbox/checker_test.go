@@ -0,0 +1,41 @@
+package sandbox
+
+import (
+	"testing"
+
+	"OJ-API/models"
+)
+
+func TestCheckOutput(t *testing.T) {
+	cases := []struct {
+		name      string
+		checker   models.CheckerType
+		tolerance float64
+		expected  string
+		actual    string
+		want      bool
+		wantDiff  string
+	}{
+		{name: "exact", checker: models.CheckerExact, expected: "3\n", actual: "3\n", want: true},
+		{name: "exact ignores CRLF and trailing newlines", checker: models.CheckerExact, expected: "1\n2\n", actual: "1\r\n2\r\n\n", want: true},
+		{name: "exact keeps trailing spaces", checker: models.CheckerExact, expected: "1 2\n", actual: "1 2 \n", wantDiff: `line 1: expected "1 2", got "1 2 "`},
+		{name: "exact missing line", checker: models.CheckerExact, expected: "1\n2\n", actual: "1\n", wantDiff: `line 2: expected "2", got end of output`},
+		{name: "default is exact", expected: "a\n", actual: "b\n", wantDiff: `line 1: expected "a", got "b"`},
+		{name: "whitespace", checker: models.CheckerWhitespace, expected: "1 2\n3\n", actual: "1\t2 3", want: true},
+		{name: "whitespace extra token", checker: models.CheckerWhitespace, expected: "1 2", actual: "1 2 3", wantDiff: `token 3: expected end of output, got "3"`},
+		{name: "float within absolute tolerance", checker: models.CheckerFloat, tolerance: 1e-6, expected: "0.3333333", actual: "0.33333331", want: true},
+		{name: "float within relative tolerance", checker: models.CheckerFloat, tolerance: 1e-6, expected: "1000000", actual: "1000000.5", want: true},
+		{name: "float outside tolerance", checker: models.CheckerFloat, tolerance: 1e-6, expected: "0.5", actual: "0.6", wantDiff: `token 1: expected "0.5", got "0.6"`},
+		{name: "float compares words exactly", checker: models.CheckerFloat, tolerance: 1e-6, expected: "yes 1.0", actual: "yes 1", want: true},
+		{name: "float NaN", checker: models.CheckerFloat, tolerance: 1e-6, expected: "NaN", actual: "nan", want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diff := checkOutput(tc.checker, tc.tolerance, tc.expected, tc.actual)
+			if got != tc.want || diff != tc.wantDiff {
+				t.Errorf("checkOutput() = %t, %q, want %t, %q", got, diff, tc.want, tc.wantDiff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Adapter converts a test result file of a specific format into the gtest-style InputJSON
+type Adapter func(path string, data []byte) (InputJSON, error)
+
+var adapters = map[string]Adapter{}
+
+// registerAdapter registers an input adapter under the given format name
+func registerAdapter(format string, adapter Adapter) {
+	adapters[format] = adapter
+}
+
+// adapterFormats returns the names of all registered formats
+func adapterFormats() []string {
+	formats := make([]string, 0, len(adapters))
+	for format := range adapters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// parseResult normalizes a result file with the adapter of the given format
+func parseResult(format, path string, data []byte) (InputJSON, error) {
+	adapter, ok := adapters[format]
+	if !ok {
+		return InputJSON{}, fmt.Errorf("unsupported result format %q (supported: %s)", format, strings.Join(adapterFormats(), ", "))
+	}
+	return adapter(path, data)
+}
+
+// summarize recomputes the counters of every suite and of the whole result from its test cases
+func summarize(input *InputJSON) {
+	input.Tests, input.Failures, input.Errors, input.Disabled = 0, 0, 0, 0
+	for i := range input.TestSuites {
+		suite := &input.TestSuites[i]
+		suite.Tests, suite.Failures, suite.Errors, suite.Disabled = 0, 0, 0, 0
+		for _, tc := range suite.TestSuite {
+			suite.Tests++
+			switch {
+			case len(tc.Errors) > 0:
+				suite.Errors++
+			case len(tc.Failures) > 0:
+				suite.Failures++
+			case skippedCase(tc):
+				suite.Disabled++
+			}
+		}
+		input.Tests += suite.Tests
+		input.Failures += suite.Failures
+		input.Errors += suite.Errors
+		input.Disabled += suite.Disabled
+	}
+	if input.Name == "" {
+		input.Name = "AllTests"
+	}
+}
+
+// formatSeconds converts a duration in seconds to the gtest "0.123s" notation
+func formatSeconds(seconds string) string {
+	seconds = strings.TrimSpace(seconds)
+	if seconds == "" {
+		return "0s"
+	}
+	if v, err := strconv.ParseFloat(seconds, 64); err == nil {
+		return strconv.FormatFloat(v, 'f', -1, 64) + "s"
+	}
+	return seconds
+}
+
+// skippedCase reports whether an adapter found a test case skipped, disabled or marked TODO instead of run to a verdict
+func skippedCase(tc TestCase) bool {
+	return tc.skipped
+}
+
+// passedCase reports whether a test case ran and passed, skipped cases of the JUnit, pytest and TAP adapters never earn points
+func passedCase(tc TestCase) bool {
+	return len(tc.Failures) == 0 && len(tc.Errors) == 0 && !skippedCase(tc)
+}
+
+// newTestCase builds a test case with the gtest status/result convention
+func newTestCase(name, className, file string, line int, seconds string, skipped bool) TestCase {
+	tc := TestCase{
+		Name:      name,
+		File:      file,
+		Line:      line,
+		Status:    "RUN",
+		Result:    "COMPLETED",
+		Time:      formatSeconds(seconds),
+		ClassName: className,
+	}
+	if skipped {
+		tc.Status = "NOTRUN"
+		tc.Result = "SKIPPED"
+		tc.skipped = true
+	}
+	return tc
+}
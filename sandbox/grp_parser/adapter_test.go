@@ -0,0 +1,191 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// caseOutcome summarizes a normalized test case for comparison
+type caseOutcome struct {
+	Suite  string
+	Name   string
+	Passed bool
+}
+
+func outcomes(input InputJSON) []caseOutcome {
+	var got []caseOutcome
+	for _, suite := range input.TestSuites {
+		for _, tc := range suite.TestSuite {
+			got = append(got, caseOutcome{suite.Name, tc.Name, passedCase(tc)})
+		}
+	}
+	return got
+}
+
+func TestParseResult(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   string
+		path     string
+		data     string
+		want     []caseOutcome
+		failures int
+		errors   int
+		disabled int
+	}{
+		{
+			name:   "gtest keeps disabled and skipped cases as passed",
+			format: "gtest",
+			path:   "ut_test.json",
+			data: `{
+				"tests": 4, "failures": 1, "disabled": 1, "errors": 0, "name": "AllTests",
+				"testsuites": [{
+					"name": "Sum", "tests": 4, "failures": 1, "disabled": 1, "errors": 0,
+					"testsuite": [
+						{"name": "Positive", "status": "RUN", "result": "COMPLETED", "classname": "Sum"},
+						{"name": "Negative", "status": "RUN", "result": "COMPLETED", "classname": "Sum",
+							"failures": [{"failure": "Expected: -3", "type": ""}]},
+						{"name": "DISABLED_Overflow", "status": "NOTRUN", "result": "SUPPRESSED", "classname": "Sum"},
+						{"name": "Large", "status": "RUN", "result": "SKIPPED", "classname": "Sum"}
+					]
+				}]
+			}`,
+			want: []caseOutcome{
+				{"Sum", "Positive", true},
+				{"Sum", "Negative", false},
+				{"Sum", "DISABLED_Overflow", true},
+				{"Sum", "Large", true},
+			},
+			failures: 1,
+			disabled: 1,
+		},
+		{
+			name:   "junit testsuites with nested suites",
+			format: "junit",
+			path:   "TEST-report.xml",
+			data: `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="AllTests">
+  <testsuite name="CalculatorTest" time="0.05">
+    <testcase name="testAdd" classname="CalculatorTest" time="0.01"/>
+    <testcase name="testDivide" classname="CalculatorTest" time="0.02">
+      <failure message="expected 2" type="AssertionError">at CalculatorTest.java:12</failure>
+    </testcase>
+    <testsuite name="CalculatorTest$Nested">
+      <testcase name="testCrash" classname="CalculatorTest$Nested">
+        <error message="NullPointerException" type="java.lang.NullPointerException"/>
+      </testcase>
+      <testcase name="testLater" classname="CalculatorTest$Nested">
+        <skipped message="not implemented"/>
+      </testcase>
+    </testsuite>
+  </testsuite>
+</testsuites>`,
+			want: []caseOutcome{
+				{"CalculatorTest", "testAdd", true},
+				{"CalculatorTest", "testDivide", false},
+				{"CalculatorTest$Nested", "testCrash", false},
+				{"CalculatorTest$Nested", "testLater", false},
+			},
+			failures: 1,
+			errors:   1,
+			disabled: 1,
+		},
+		{
+			name:   "junit single testsuite named after the file",
+			format: "junit",
+			path:   "report.xml",
+			data:   `<testsuite><testcase name="TestSum"/></testsuite>`,
+			want:   []caseOutcome{{"report", "TestSum", true}},
+		},
+		{
+			name:   "pytest",
+			format: "pytest",
+			path:   "report.json",
+			data: `{
+				"created": 1700000000, "duration": 0.5,
+				"tests": [
+					{"nodeid": "tests/test_sum.py::test_positive", "outcome": "passed", "call": {"duration": 0.1, "outcome": "passed"}},
+					{"nodeid": "tests/test_sum.py::TestSum::test_negative", "outcome": "failed",
+						"call": {"duration": 0.1, "outcome": "failed", "longrepr": "assert -1 == -3"}},
+					{"nodeid": "tests/test_sum.py::test_large", "outcome": "skipped", "setup": {"duration": 0, "outcome": "skipped"}},
+					{"nodeid": "tests/test_io.py::test_read", "outcome": "error",
+						"setup": {"duration": 0, "outcome": "failed", "crash": {"path": "conftest.py", "lineno": 3, "message": "fixture not found"}}}
+				]
+			}`,
+			want: []caseOutcome{
+				{"tests/test_sum.py", "test_positive", true},
+				{"tests/test_sum.py", "test_negative", false},
+				{"tests/test_sum.py", "test_large", false},
+				{"tests/test_io.py", "test_read", false},
+			},
+			failures: 1,
+			errors:   1,
+			disabled: 1,
+		},
+		{
+			name:   "tap with skip, todo and missing tests",
+			format: "tap",
+			path:   "sum.tap",
+			data: `TAP version 13
+1..6
+ok 1 - positive
+not ok 2 - negative
+  ---
+  message: expected -3
+  ...
+ok 3 - large # SKIP slow
+not ok 4 - overflow # TODO not implemented
+ok 5 - zero # todo passes by accident
+`,
+			want: []caseOutcome{
+				{"sum", "positive", true},
+				{"sum", "negative", false},
+				{"sum", "large", false},
+				{"sum", "overflow", false},
+				{"sum", "zero", false},
+				{"sum", "test 6", false},
+			},
+			failures: 2,
+			disabled: 3,
+		},
+		{
+			name:   "tap bail out",
+			format: "tap",
+			path:   "sum.tap",
+			data:   "1..2\nok 1 - positive\nBail out! segmentation fault\n",
+			want: []caseOutcome{
+				{"sum", "positive", true},
+				{"sum", "Bail out", false},
+				{"sum", "test 2", false},
+			},
+			failures: 1,
+			errors:   1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			input, err := parseResult(tc.format, tc.path, []byte(tc.data))
+			if err != nil {
+				t.Fatalf("parseResult() error = %v", err)
+			}
+			if got := outcomes(input); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("outcomes = %+v, want %+v", got, tc.want)
+			}
+			if tc.format == "gtest" {
+				// GoogleTest reports its own counters, they are kept as is
+				return
+			}
+			if input.Failures != tc.failures || input.Errors != tc.errors || input.Disabled != tc.disabled {
+				t.Errorf("failures/errors/disabled = %d/%d/%d, want %d/%d/%d",
+					input.Failures, input.Errors, input.Disabled, tc.failures, tc.errors, tc.disabled)
+			}
+		})
+	}
+}
+
+func TestParseResultUnsupportedFormat(t *testing.T) {
+	if _, err := parseResult("nunit", "report.xml", nil); err == nil {
+		t.Fatal("parseResult() with an unknown format succeeded")
+	}
+}
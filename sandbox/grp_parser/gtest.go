@@ -0,0 +1,19 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+func init() {
+	registerAdapter("gtest", parseGTest)
+}
+
+// parseGTest reads GoogleTest output produced by --gtest_output=json
+func parseGTest(path string, data []byte) (InputJSON, error) {
+	var input InputJSON
+	if err := json.Unmarshal(data, &input); err != nil {
+		return InputJSON{}, fmt.Errorf("failed to parse input JSON: %v", err)
+	}
+	return input, nil
+}
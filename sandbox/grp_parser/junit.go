@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	registerAdapter("junit", parseJUnit)
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	File      string         `xml:"file,attr"`
+	Line      int            `xml:"line,attr"`
+	Time      string         `xml:"time,attr"`
+	Failures  []junitProblem `xml:"failure"`
+	Errors    []junitProblem `xml:"error"`
+	Skipped   *junitProblem  `xml:"skipped"`
+}
+
+type junitSuite struct {
+	Name      string       `xml:"name,attr"`
+	Timestamp string       `xml:"timestamp,attr"`
+	Time      string       `xml:"time,attr"`
+	File      string       `xml:"file,attr"`
+	Cases     []junitCase  `xml:"testcase"`
+	Suites    []junitSuite `xml:"testsuite"`
+}
+
+type junitRoot struct {
+	XMLName   xml.Name
+	Name      string       `xml:"name,attr"`
+	Timestamp string       `xml:"timestamp,attr"`
+	Time      string       `xml:"time,attr"`
+	Suites    []junitSuite `xml:"testsuite"`
+}
+
+// parseJUnit reads JUnit XML reports (Maven Surefire, gotestsum, cargo2junit, ...)
+// The root element may be either <testsuites> or a single <testsuite>.
+func parseJUnit(path string, data []byte) (InputJSON, error) {
+	var root junitRoot
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return InputJSON{}, fmt.Errorf("failed to parse JUnit XML: %v", err)
+	}
+
+	var suites []junitSuite
+	switch root.XMLName.Local {
+	case "testsuites":
+		suites = root.Suites
+	case "testsuite":
+		var suite junitSuite
+		if err := xml.Unmarshal(data, &suite); err != nil {
+			return InputJSON{}, fmt.Errorf("failed to parse JUnit XML: %v", err)
+		}
+		suites = []junitSuite{suite}
+	default:
+		return InputJSON{}, fmt.Errorf("unexpected JUnit root element <%s>", root.XMLName.Local)
+	}
+
+	input := InputJSON{
+		Timestamp: root.Timestamp,
+		Time:      formatSeconds(root.Time),
+	}
+	if root.XMLName.Local == "testsuites" {
+		input.Name = root.Name
+	}
+	for _, suite := range flattenJUnitSuites(suites) {
+		input.TestSuites = append(input.TestSuites, convertJUnitSuite(suite, path))
+	}
+	summarize(&input)
+	return input, nil
+}
+
+// flattenJUnitSuites lists nested suites alongside their parents, dropping suites without test cases
+func flattenJUnitSuites(suites []junitSuite) []junitSuite {
+	var flat []junitSuite
+	for _, suite := range suites {
+		if len(suite.Cases) > 0 {
+			flat = append(flat, suite)
+		}
+		flat = append(flat, flattenJUnitSuites(suite.Suites)...)
+	}
+	return flat
+}
+
+func convertJUnitSuite(suite junitSuite, path string) TestSuite {
+	name := suite.Name
+	if name == "" {
+		name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+
+	converted := TestSuite{
+		Name:      name,
+		Timestamp: suite.Timestamp,
+		Time:      formatSeconds(suite.Time),
+	}
+	for _, c := range suite.Cases {
+		file := c.File
+		if file == "" {
+			file = suite.File
+		}
+		tc := newTestCase(c.Name, c.ClassName, file, c.Line, c.Time, c.Skipped != nil)
+		tc.Timestamp = suite.Timestamp
+		for _, f := range c.Failures {
+			tc.Failures = append(tc.Failures, Failure{Failure: problemText(f), Type: f.Type})
+		}
+		for _, e := range c.Errors {
+			tc.Errors = append(tc.Errors, Error{Error: problemText(e), Type: e.Type})
+		}
+		converted.TestSuite = append(converted.TestSuite, tc)
+	}
+	return converted
+}
+
+// problemText joins the message attribute and the body of a <failure> or <error> element
+func problemText(p junitProblem) string {
+	text := strings.TrimSpace(p.Text)
+	switch {
+	case text == "":
+		return p.Message
+	case p.Message == "" || strings.HasPrefix(text, p.Message):
+		return text
+	default:
+		return p.Message + "\n" + text
+	}
+}
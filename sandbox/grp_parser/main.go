@@ -2,9 +2,11 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -14,7 +16,7 @@ var boxPathRegex = regexp.MustCompile(`(?m)/[^:\n]*/box/`)
 // TestSuite represents a test suite structure from the input JSON
 type TestSuite struct {
 	Name      string     `json:"name"`
-	MaxScore  int        `json:"maxscore"`
+	MaxScore  float64    `json:"maxscore"`
 	GetScore  float64    `json:"getscore"`
 	Tests     int        `json:"tests"`
 	Failures  int        `json:"failures"`
@@ -37,6 +39,10 @@ type TestCase struct {
 	ClassName string    `json:"classname"`
 	Failures  []Failure `json:"failures,omitempty"`
 	Errors    []Error   `json:"errors,omitempty"`
+
+	// skipped marks cases the JUnit, pytest and TAP adapters report as skipped, they earn no points.
+	// GoogleTest output never sets it, disabled and skipped gtest cases keep counting as passed.
+	skipped bool
 }
 
 // Failure represents a test failure
@@ -65,33 +71,47 @@ type InputJSON struct {
 
 // ScoreTestSuite represents test suite scoring structure
 type ScoreTestSuite struct {
-	TestSuite string `json:"testsuite"`
-	Score     int    `json:"score"`
+	TestSuite    string             `json:"testsuite"`
+	Score        float64            `json:"score"`
+	Weights      map[string]float64 `json:"weights,omitempty"`        // weight per test case name, default 1
+	AllOrNothing bool               `json:"all_or_nothing,omitempty"` // score only when every test case passes
+	Requires     []string           `json:"requires,omitempty"`       // suites that must fully pass before this one scores
+	Bonus        bool               `json:"bonus,omitempty"`          // extra points on top of the regular total, not limited by max_score
+}
+
+// ScoreTask lists the test suites produced by a compile/execute target
+type ScoreTask struct {
+	Target string   `json:"target"`
+	Suite  []string `json:"suite"`
 }
 
 // ScoreJSON represents the structure of the score JSON file
 type ScoreJSON struct {
 	HomeworkName string           `json:"homework_name"`
 	Semester     string           `json:"semester"`
+	MaxScore     *float64         `json:"max_score,omitempty"` // cap of the regular score, bonus suites may go beyond it
 	TestSuites   []ScoreTestSuite `json:"testsuites"`
+	Task         []ScoreTask      `json:"task,omitempty"`
 }
 
 // JSONParser handles JSON parsing and score calculation
 type JSONParser struct {
 	parsePath string
 	scorePath string
+	target    string
 	scoreFile ScoreJSON
 	inputFile InputJSON
 	score     float64
-	task      map[string]int
+	task      map[string]ScoreTestSuite
 }
 
-// NewJSONParser creates a new JSONParser instance
-func NewJSONParser(parsePath, scorePath string) (*JSONParser, error) {
+// NewJSONParser creates a new JSONParser instance, normalizing the result file with the adapter of the given format
+func NewJSONParser(format, parsePath, scorePath, target string) (*JSONParser, error) {
 	parser := &JSONParser{
 		parsePath: parsePath,
 		scorePath: scorePath,
-		task:      make(map[string]int),
+		target:    target,
+		task:      make(map[string]ScoreTestSuite),
 	}
 
 	// Read and parse input JSON file
@@ -100,9 +120,9 @@ func NewJSONParser(parsePath, scorePath string) (*JSONParser, error) {
 		return nil, fmt.Errorf("failed to read input file: %v", err)
 	}
 
-	err = json.Unmarshal(inputData, &parser.inputFile)
+	parser.inputFile, err = parseResult(format, parsePath, inputData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse input JSON: %v", err)
+		return nil, err
 	}
 
 	// Read and parse score JSON file
@@ -116,44 +136,210 @@ func NewJSONParser(parsePath, scorePath string) (*JSONParser, error) {
 		return nil, fmt.Errorf("failed to parse score JSON: %v", err)
 	}
 
-	parser.parseScore()
+	if err := parser.parseScore(); err != nil {
+		return nil, err
+	}
 	return parser, nil
 }
 
-// parseScore parses the score configuration
-func (jp *JSONParser) parseScore() {
+// parseScore parses and validates the score configuration
+func (jp *JSONParser) parseScore() error {
 	for _, testSuite := range jp.scoreFile.TestSuites {
-		jp.task[testSuite.TestSuite] = testSuite.Score
+		if _, exists := jp.task[testSuite.TestSuite]; exists {
+			return fmt.Errorf("score map: duplicate testsuite %q", testSuite.TestSuite)
+		}
+		jp.task[testSuite.TestSuite] = testSuite
 	}
+	// Each target is scored on its own, so a prerequisite must be produced by the same target
+	targets := make(map[string]string)
+	for _, task := range jp.scoreFile.Task {
+		for _, name := range task.Suite {
+			targets[name] = task.Target
+		}
+	}
+	for _, testSuite := range jp.scoreFile.TestSuites {
+		for _, required := range testSuite.Requires {
+			if _, exists := jp.task[required]; !exists {
+				return fmt.Errorf("score map: testsuite %q requires unknown testsuite %q", testSuite.TestSuite, required)
+			}
+			target, requiredTarget := targets[testSuite.TestSuite], targets[required]
+			if target != "" && requiredTarget != "" && target != requiredTarget {
+				return fmt.Errorf("score map: testsuite %q of target %q requires testsuite %q of another target %q", testSuite.TestSuite, target, required, requiredTarget)
+			}
+		}
+	}
+	return nil
 }
 
 // Parse calculates the score based on test results
 func (jp *JSONParser) Parse() {
+	// First pass: clean failure messages and find the suites whose test cases all passed
+	passed := make(map[string]bool)
 	for i := range jp.inputFile.TestSuites {
-		name := jp.inputFile.TestSuites[i].Name
-		ac := 0.0 // accepted count
-		wa := 0.0 // wrong answer count
-
-		for _, suite := range jp.inputFile.TestSuites[i].TestSuite {
-			// Check if individual test case has failures or errors
-			if len(suite.Failures) > 0 {
-				for j := range suite.Failures {
-					suite.Failures[j].Failure = boxPathRegex.ReplaceAllString(suite.Failures[j].Failure, "")
-				}
+		suite := &jp.inputFile.TestSuites[i]
+		ok := len(suite.TestSuite) > 0
+		for _, tc := range suite.TestSuite {
+			for j := range tc.Failures {
+				tc.Failures[j].Failure = boxPathRegex.ReplaceAllString(tc.Failures[j].Failure, "")
+			}
+			if !passedCase(tc) {
+				ok = false
 			}
-			if len(suite.Failures) > 0 || len(suite.Errors) > 0 {
-				wa += 1.0
+		}
+		passed[suite.Name] = ok
+	}
+
+	var regular, bonus float64
+	for i := range jp.inputFile.TestSuites {
+		suite := &jp.inputFile.TestSuites[i]
+		rule, exists := jp.task[suite.Name]
+		if !exists {
+			continue
+		}
+
+		ac := 0.0 // accepted weight
+		wa := 0.0 // wrong answer weight
+		for _, tc := range suite.TestSuite {
+			weight := 1.0
+			if w, ok := rule.Weights[tc.Name]; ok {
+				weight = w
+			}
+			if passedCase(tc) {
+				ac += weight
 			} else {
-				ac += 1.0
+				wa += weight
+			}
+		}
+
+		suite.MaxScore = rule.Score
+		if ac+wa <= 0 {
+			continue
+		}
+
+		ratio := ac / (ac + wa)
+		if rule.AllOrNothing && wa > 0 {
+			ratio = 0
+		}
+		for _, required := range rule.Requires {
+			if !passed[required] {
+				ratio = 0
+				suite.TestSuite = append(suite.TestSuite, scoreMapCase(suite.Name,
+					fmt.Sprintf("No score: prerequisite testsuite %s did not pass", required)))
+				suite.Tests++
+				suite.Failures++
+				jp.inputFile.Tests++
+				jp.inputFile.Failures++
 			}
 		}
 
-		if maxScore, exists := jp.task[name]; exists && (ac+wa) > 0 {
-			jp.inputFile.TestSuites[i].MaxScore = maxScore
-			jp.inputFile.TestSuites[i].GetScore = (ac / (ac + wa)) * float64(maxScore)
-			jp.score += (ac / (ac + wa)) * float64(maxScore)
+		suite.GetScore = ratio * rule.Score
+		if rule.Bonus {
+			bonus += suite.GetScore
+		} else {
+			regular += suite.GetScore
 		}
 	}
+
+	// max_score caps the regular suites only, bonus points are added on top of it
+	if jp.scoreFile.MaxScore != nil && regular > *jp.scoreFile.MaxScore {
+		regular = *jp.scoreFile.MaxScore
+	}
+	jp.score = regular + bonus
+}
+
+// validateRun checks the score map against the test results before scoring: every testsuite the run is
+// expected to produce must be present and weights must name test cases of their testsuite, otherwise a
+// mistake in the score map would silently score 0 or a partial score.
+func (jp *JSONParser) validateRun() error {
+	present := make(map[string]TestSuite)
+	for _, suite := range jp.inputFile.TestSuites {
+		present[suite.Name] = suite
+	}
+
+	expected, problems := jp.expectedSuites(present)
+	for _, name := range expected {
+		if _, ok := present[name]; !ok {
+			problems = append(problems, fmt.Sprintf("testsuite %q does not exist in the test results", name))
+		}
+	}
+
+	for _, rule := range jp.scoreFile.TestSuites {
+		suite, ok := present[rule.TestSuite]
+		if !ok || len(rule.Weights) == 0 {
+			continue
+		}
+		cases := make(map[string]bool)
+		for _, tc := range suite.TestSuite {
+			cases[tc.Name] = true
+		}
+		names := make([]string, 0, len(rule.Weights))
+		for name := range rule.Weights {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if !cases[name] {
+				problems = append(problems, fmt.Sprintf("weights of testsuite %q name test case %q which does not exist in the test results", rule.TestSuite, name))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("score map: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// expectedSuites returns the testsuites of the score map the test results must contain:
+// all of them without a task list, otherwise those of the target being scored. Without -target the
+// tasks are inferred from the testsuites present in the results.
+func (jp *JSONParser) expectedSuites(present map[string]TestSuite) ([]string, []string) {
+	if len(jp.scoreFile.Task) == 0 {
+		names := make([]string, 0, len(jp.scoreFile.TestSuites))
+		for _, suite := range jp.scoreFile.TestSuites {
+			names = append(names, suite.TestSuite)
+		}
+		return names, nil
+	}
+
+	var names []string
+	found := false
+	for _, task := range jp.scoreFile.Task {
+		match := task.Target == jp.target
+		if jp.target == "" {
+			for _, name := range task.Suite {
+				if _, ok := present[name]; ok {
+					match = true
+					break
+				}
+			}
+		}
+		if match {
+			found = true
+			names = append(names, task.Suite...)
+		}
+	}
+	switch {
+	case found:
+		return names, nil
+	case jp.target != "":
+		return nil, []string{fmt.Sprintf("target %q is not in the task list", jp.target)}
+	default:
+		return nil, []string{"none of the testsuites in the test results is in the task list"}
+	}
+}
+
+// scoreMapCase builds a failed test case describing a score map problem
+func scoreMapCase(suite, message string) TestCase {
+	return TestCase{
+		Name:      suite,
+		File:      "Score Map",
+		Status:    "RUN",
+		Result:    "COMPLETED",
+		Time:      "0s",
+		ClassName: "ScoreMap",
+		Failures:  []Failure{{Failure: message, Type: "ScoreMap"}},
+	}
 }
 
 // GetScore returns the calculated score
@@ -190,7 +376,7 @@ func writeScore(score float64) (bool, error) {
 	return higher, os.WriteFile(file, []byte(fmt.Sprintf("%.2f\n", newScore)), 0644)
 }
 
-// writeJSONFile copies the JSON file content to message.txt
+// writeJSONFile writes the normalized result to message.txt
 func (jp *JSONParser) writeJSONFile(jsonPath string) error {
 	outputFile, err := os.Create("message.txt")
 	if err != nil {
@@ -212,17 +398,31 @@ func (jp *JSONParser) writeJSONFile(jsonPath string) error {
 }
 
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Fprintf(os.Stderr, "Usage: %s <gtest.json> <score.json>\n", os.Args[0])
+	format := flag.String("format", "gtest", "format of the test result file ("+strings.Join(adapterFormats(), ", ")+")")
+	target := flag.String("target", "", "target being scored, used to check that its suites in the score map were run")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-format=gtest] [-target=name] <result file> <score.json>\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
 		os.Exit(1)
 	}
+	resultPath, scorePath := flag.Arg(0), flag.Arg(1)
 
-	parser, err := NewJSONParser(os.Args[1], os.Args[2])
+	parser, err := NewJSONParser(*format, resultPath, scorePath, *target)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating parser: %v\n", err)
 		os.Exit(1)
 	}
 
+	if err := parser.validateRun(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error validating test results: %v\n", err)
+		os.Exit(1)
+	}
+
 	parser.Parse()
 	score := parser.GetScore()
 	fmt.Printf("%.2f\n", score)
@@ -234,7 +434,7 @@ func main() {
 	}
 
 	if higher {
-		if err := parser.writeJSONFile(os.Args[1]); err != nil {
+		if err := parser.writeJSONFile(resultPath); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing JSON file: %v\n", err)
 			os.Exit(1)
 		}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// passing and failing build test cases for scoring tests
+func passing(name string) TestCase {
+	return newTestCase(name, "", "", 0, "", false)
+}
+
+func failing(name string) TestCase {
+	tc := newTestCase(name, "", "", 0, "", false)
+	tc.Failures = []Failure{{Failure: "/tmp/box/1/box/main.cpp:3: wrong answer", Type: ""}}
+	return tc
+}
+
+func skipped(name string) TestCase {
+	return newTestCase(name, "", "", 0, "", true)
+}
+
+func suite(name string, cases ...TestCase) TestSuite {
+	return TestSuite{Name: name, TestSuite: cases}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
+
+func TestParseScore(t *testing.T) {
+	cases := []struct {
+		name   string
+		suites []TestSuite
+		score  ScoreJSON
+		want   float64
+	}{
+		{
+			name:   "score is proportional to passed cases",
+			suites: []TestSuite{suite("Sum", passing("a"), passing("b"), failing("c"), failing("d"))},
+			score:  ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 40}}},
+			want:   20,
+		},
+		{
+			name:   "skipped cases are not passed",
+			suites: []TestSuite{suite("Sum", passing("a"), skipped("b"))},
+			score:  ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 100}}},
+			want:   50,
+		},
+		{
+			name:   "suite of skipped cases earns nothing",
+			suites: []TestSuite{suite("Sum", skipped("a"), skipped("b"))},
+			score:  ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 100}}},
+			want:   0,
+		},
+		{
+			name: "disabled gtest cases are passed",
+			suites: []TestSuite{suite("Sum", passing("a"),
+				TestCase{Name: "DISABLED_b", Status: "NOTRUN", Result: "SUPPRESSED"},
+				TestCase{Name: "c", Status: "RUN", Result: "SKIPPED"})},
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 90}}},
+			want:  90,
+		},
+		{
+			name:   "weights",
+			suites: []TestSuite{suite("Sum", passing("small"), failing("large"))},
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Sum", Score: 100, Weights: map[string]float64{"small": 1, "large": 3}},
+			}},
+			want: 25,
+		},
+		{
+			name:   "all or nothing",
+			suites: []TestSuite{suite("Sum", passing("a"), passing("b"), failing("c"))},
+			score:  ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 100, AllOrNothing: true}}},
+			want:   0,
+		},
+		{
+			name: "requires a passed suite",
+			suites: []TestSuite{
+				suite("Basic", passing("a")),
+				suite("Advanced", passing("a"), failing("b")),
+			},
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Basic", Score: 40},
+				{TestSuite: "Advanced", Score: 60, Requires: []string{"Basic"}},
+			}},
+			want: 70,
+		},
+		{
+			name: "requires a failed suite",
+			suites: []TestSuite{
+				suite("Basic", passing("a"), failing("b")),
+				suite("Advanced", passing("a")),
+			},
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Basic", Score: 40},
+				{TestSuite: "Advanced", Score: 60, Requires: []string{"Basic"}},
+			}},
+			want: 20,
+		},
+		{
+			name: "requires a suite with a skipped case",
+			suites: []TestSuite{
+				suite("Basic", passing("a"), skipped("b")),
+				suite("Advanced", passing("a")),
+			},
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Basic", Score: 40},
+				{TestSuite: "Advanced", Score: 60, Requires: []string{"Basic"}},
+			}},
+			want: 20,
+		},
+		{
+			name: "max score caps regular suites",
+			suites: []TestSuite{
+				suite("Sum", passing("a")),
+				suite("Product", passing("a")),
+			},
+			score: ScoreJSON{MaxScore: float(100), TestSuites: []ScoreTestSuite{
+				{TestSuite: "Sum", Score: 80},
+				{TestSuite: "Product", Score: 80},
+			}},
+			want: 100,
+		},
+		{
+			name: "bonus goes beyond max score",
+			suites: []TestSuite{
+				suite("Sum", passing("a")),
+				suite("Extra", passing("a"), failing("b")),
+			},
+			score: ScoreJSON{MaxScore: float(100), TestSuites: []ScoreTestSuite{
+				{TestSuite: "Sum", Score: 100},
+				{TestSuite: "Extra", Score: 20, Bonus: true},
+			}},
+			want: 110,
+		},
+		{
+			name:   "suites missing from the score map are ignored",
+			suites: []TestSuite{suite("Sum", passing("a")), suite("Debug", failing("a"))},
+			score:  ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 100}}},
+			want:   100,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := &JSONParser{
+				scoreFile: tc.score,
+				inputFile: InputJSON{TestSuites: tc.suites},
+				task:      make(map[string]ScoreTestSuite),
+			}
+			if err := parser.parseScore(); err != nil {
+				t.Fatalf("parseScore() error = %v", err)
+			}
+			parser.Parse()
+			if got := parser.GetScore(); math.Abs(got-tc.want) > 1e-9 {
+				t.Errorf("score = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseStripsBoxPaths(t *testing.T) {
+	parser := &JSONParser{
+		scoreFile: ScoreJSON{TestSuites: []ScoreTestSuite{{TestSuite: "Sum", Score: 100}}},
+		inputFile: InputJSON{TestSuites: []TestSuite{suite("Sum", failing("a"))}},
+		task:      make(map[string]ScoreTestSuite),
+	}
+	if err := parser.parseScore(); err != nil {
+		t.Fatalf("parseScore() error = %v", err)
+	}
+	parser.Parse()
+	if got := parser.inputFile.TestSuites[0].TestSuite[0].Failures[0].Failure; got != "main.cpp:3: wrong answer" {
+		t.Errorf("failure = %q, want the sandbox path removed", got)
+	}
+}
+
+func TestParseReportsUnmetPrerequisite(t *testing.T) {
+	parser := &JSONParser{
+		scoreFile: ScoreJSON{TestSuites: []ScoreTestSuite{
+			{TestSuite: "Basic", Score: 40},
+			{TestSuite: "Advanced", Score: 60, Requires: []string{"Basic"}},
+		}},
+		inputFile: InputJSON{TestSuites: []TestSuite{suite("Basic", failing("a")), suite("Advanced", passing("a"))}},
+		task:      make(map[string]ScoreTestSuite),
+	}
+	if err := parser.parseScore(); err != nil {
+		t.Fatalf("parseScore() error = %v", err)
+	}
+	parser.Parse()
+
+	advanced := parser.inputFile.TestSuites[1]
+	last := advanced.TestSuite[len(advanced.TestSuite)-1]
+	if advanced.GetScore != 0 || len(last.Failures) == 0 || !strings.Contains(last.Failures[0].Failure, "prerequisite testsuite Basic") {
+		t.Errorf("Advanced = %+v, want no score and a prerequisite failure", advanced)
+	}
+}
+
+func TestParseScoreValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		score   ScoreJSON
+		wantErr string
+	}{
+		{
+			name: "duplicate suite",
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Sum", Score: 50},
+				{TestSuite: "Sum", Score: 50},
+			}},
+			wantErr: "duplicate testsuite",
+		},
+		{
+			name: "unknown prerequisite",
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Advanced", Score: 50, Requires: []string{"Basic"}},
+			}},
+			wantErr: "unknown testsuite",
+		},
+		{
+			name: "prerequisite of another target",
+			score: ScoreJSON{
+				TestSuites: []ScoreTestSuite{
+					{TestSuite: "Basic", Score: 50},
+					{TestSuite: "Advanced", Score: 50, Requires: []string{"Basic"}},
+				},
+				Task: []ScoreTask{
+					{Target: "basic", Suite: []string{"Basic"}},
+					{Target: "advanced", Suite: []string{"Advanced"}},
+				},
+			},
+			wantErr: "another target",
+		},
+		{
+			name: "prerequisite of the same target",
+			score: ScoreJSON{
+				TestSuites: []ScoreTestSuite{
+					{TestSuite: "Basic", Score: 50},
+					{TestSuite: "Advanced", Score: 50, Requires: []string{"Basic"}},
+				},
+				Task: []ScoreTask{{Target: "all", Suite: []string{"Basic", "Advanced"}}},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := &JSONParser{scoreFile: tc.score, task: make(map[string]ScoreTestSuite)}
+			err := parser.parseScore()
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("parseScore() error = %v, want nil", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("parseScore() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRun(t *testing.T) {
+	sumAndProduct := []ScoreTestSuite{{TestSuite: "Sum", Score: 50}, {TestSuite: "Product", Score: 50}}
+	tasks := []ScoreTask{
+		{Target: "unit", Suite: []string{"Sum", "Product"}},
+		{Target: "other", Suite: []string{"Other"}},
+	}
+
+	cases := []struct {
+		name    string
+		target  string
+		score   ScoreJSON
+		suites  []TestSuite
+		wantErr string // empty if the results match the score map
+	}{
+		{
+			name:   "all suites present",
+			score:  ScoreJSON{TestSuites: sumAndProduct},
+			suites: []TestSuite{suite("Sum", passing("a")), suite("Product", failing("a"))},
+		},
+		{
+			name:    "suite missing without a task list",
+			score:   ScoreJSON{TestSuites: sumAndProduct},
+			suites:  []TestSuite{suite("Sum", passing("a"))},
+			wantErr: `testsuite "Product" does not exist`,
+		},
+		{
+			name:    "suite of the target missing",
+			target:  "unit",
+			score:   ScoreJSON{TestSuites: sumAndProduct, Task: tasks},
+			suites:  []TestSuite{suite("Sum", passing("a"))},
+			wantErr: `testsuite "Product" does not exist`,
+		},
+		{
+			name:   "suites of other targets are not required",
+			target: "unit",
+			score:  ScoreJSON{TestSuites: sumAndProduct, Task: tasks},
+			suites: []TestSuite{suite("Sum", passing("a")), suite("Product", passing("a"))},
+		},
+		{
+			name:    "target inferred from the results",
+			score:   ScoreJSON{TestSuites: sumAndProduct, Task: tasks},
+			suites:  []TestSuite{suite("Sum", passing("a"))},
+			wantErr: `testsuite "Product" does not exist`,
+		},
+		{
+			name:    "unknown target",
+			target:  "integration",
+			score:   ScoreJSON{TestSuites: sumAndProduct, Task: tasks},
+			suites:  []TestSuite{suite("Sum", passing("a")), suite("Product", passing("a"))},
+			wantErr: `target "integration" is not in the task list`,
+		},
+		{
+			name:    "results of no task",
+			score:   ScoreJSON{TestSuites: sumAndProduct, Task: tasks},
+			suites:  []TestSuite{suite("Debug", passing("a"))},
+			wantErr: "none of the testsuites",
+		},
+		{
+			name: "weights name a missing test case",
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Sum", Score: 100, Weights: map[string]float64{"small": 1, "large": 3}},
+			}},
+			suites:  []TestSuite{suite("Sum", passing("small"), passing("big"))},
+			wantErr: `test case "large"`,
+		},
+		{
+			name: "weights name present test cases",
+			score: ScoreJSON{TestSuites: []ScoreTestSuite{
+				{TestSuite: "Sum", Score: 100, Weights: map[string]float64{"small": 1, "large": 3}},
+			}},
+			suites: []TestSuite{suite("Sum", passing("small"), passing("large"))},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := &JSONParser{
+				target:    tc.target,
+				scoreFile: tc.score,
+				inputFile: InputJSON{TestSuites: tc.suites},
+				task:      make(map[string]ScoreTestSuite),
+			}
+			if err := parser.parseScore(); err != nil {
+				t.Fatalf("parseScore() error = %v", err)
+			}
+			err := parser.validateRun()
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("validateRun() error = %v, want nil", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("validateRun() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
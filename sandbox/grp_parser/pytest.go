@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func init() {
+	registerAdapter("pytest", parsePytest)
+}
+
+type pytestCrash struct {
+	Path    string `json:"path"`
+	Lineno  int    `json:"lineno"`
+	Message string `json:"message"`
+}
+
+type pytestStage struct {
+	Duration float64      `json:"duration"`
+	Outcome  string       `json:"outcome"`
+	Crash    *pytestCrash `json:"crash"`
+	Longrepr string       `json:"longrepr"`
+}
+
+type pytestTest struct {
+	NodeID   string       `json:"nodeid"`
+	Lineno   int          `json:"lineno"`
+	Outcome  string       `json:"outcome"`
+	Setup    *pytestStage `json:"setup"`
+	Call     *pytestStage `json:"call"`
+	Teardown *pytestStage `json:"teardown"`
+}
+
+type pytestReport struct {
+	Created  float64      `json:"created"`
+	Duration float64      `json:"duration"`
+	Tests    []pytestTest `json:"tests"`
+}
+
+// parsePytest reads reports of the pytest-json-report plugin
+// Tests are grouped into suites by their module path, the part of the node ID before "::".
+func parsePytest(path string, data []byte) (InputJSON, error) {
+	var report pytestReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return InputJSON{}, fmt.Errorf("failed to parse pytest JSON report: %v", err)
+	}
+
+	timestamp := ""
+	if report.Created > 0 {
+		timestamp = time.Unix(int64(report.Created), 0).UTC().Format(time.RFC3339)
+	}
+
+	input := InputJSON{
+		Timestamp: timestamp,
+		Time:      formatSeconds(strconv.FormatFloat(report.Duration, 'f', -1, 64)),
+	}
+	index := make(map[string]int)
+	for _, t := range report.Tests {
+		module, name, found := strings.Cut(t.NodeID, "::")
+		if !found {
+			name = module
+		}
+		className := ""
+		if i := strings.LastIndex(name, "::"); i >= 0 {
+			className, name = name[:i], name[i+2:]
+		}
+
+		i, ok := index[module]
+		if !ok {
+			i = len(input.TestSuites)
+			index[module] = i
+			input.TestSuites = append(input.TestSuites, TestSuite{Name: module, Timestamp: timestamp})
+		}
+
+		var duration float64
+		for _, stage := range []*pytestStage{t.Setup, t.Call, t.Teardown} {
+			if stage != nil {
+				duration += stage.Duration
+			}
+		}
+
+		tc := newTestCase(name, className, module, t.Lineno, strconv.FormatFloat(duration, 'f', -1, 64), t.Outcome == "skipped")
+		tc.Timestamp = timestamp
+		switch t.Outcome {
+		case "failed":
+			tc.Failures = append(tc.Failures, Failure{Failure: pytestMessage(t), Type: "AssertionError"})
+		case "error":
+			tc.Errors = append(tc.Errors, Error{Error: pytestMessage(t), Type: "Error"})
+		}
+		input.TestSuites[i].TestSuite = append(input.TestSuites[i].TestSuite, tc)
+	}
+
+	summarize(&input)
+	return input, nil
+}
+
+// pytestMessage picks the failure description of the first stage that did not pass
+func pytestMessage(t pytestTest) string {
+	for _, stage := range []*pytestStage{t.Setup, t.Call, t.Teardown} {
+		if stage == nil || stage.Outcome == "passed" {
+			continue
+		}
+		if stage.Longrepr != "" {
+			return stage.Longrepr
+		}
+		if stage.Crash != nil {
+			return fmt.Sprintf("%s:%d: %s", stage.Crash.Path, stage.Crash.Lineno, stage.Crash.Message)
+		}
+	}
+	return t.Outcome
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func init() {
+	registerAdapter("tap", parseTAP)
+}
+
+var (
+	tapPlanRegex = regexp.MustCompile(`^1\.\.(\d+)`)
+	tapTestRegex = regexp.MustCompile(`^(not )?ok\b\s*(\d+)?\s*(?:-\s*)?([^#]*?)\s*(?:#\s*(.*))?$`)
+)
+
+// parseTAP reads Test Anything Protocol output
+// Only top-level test points are scored; indented subtests are treated as diagnostics of the
+// following test point. The whole file becomes one suite named after the result file.
+func parseTAP(path string, data []byte) (InputJSON, error) {
+	suite := TestSuite{Name: strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))}
+	planned := -1
+	var diagnostics []string
+	last := -1 // index of the latest test point
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "TAP version"):
+			continue
+		case tapPlanRegex.MatchString(line):
+			planned, _ = strconv.Atoi(tapPlanRegex.FindStringSubmatch(line)[1])
+			continue
+		case strings.HasPrefix(line, "Bail out!"):
+			tc := newTestCase("Bail out", "", "", 0, "", false)
+			tc.Errors = []Error{{Error: strings.TrimSpace(strings.TrimPrefix(line, "Bail out!")), Type: "BailOut"}}
+			suite.TestSuite = append(suite.TestSuite, tc)
+			last = -1
+			continue
+		}
+
+		m := tapTestRegex.FindStringSubmatch(line)
+		if m == nil {
+			// YAML blocks, comments and subtest output describe the previous failed test point
+			if last >= 0 && len(suite.TestSuite[last].Failures) > 0 {
+				diagnostics = append(diagnostics, line)
+				suite.TestSuite[last].Failures[0].Failure = strings.Join(diagnostics, "\n")
+			}
+			continue
+		}
+
+		failed := m[1] != ""
+		name := m[3]
+		if name == "" {
+			name = "test " + m[2]
+		}
+		directive := strings.ToUpper(m[4])
+		skipped := strings.HasPrefix(directive, "SKIP")
+		todo := strings.HasPrefix(directive, "TODO")
+
+		// TODO tests are not failures according to the TAP specification, but like skipped tests they earn no points
+		tc := newTestCase(name, "", "", 0, "", skipped || todo)
+		diagnostics = nil
+		if failed && !skipped && !todo {
+			diagnostics = []string{line}
+			tc.Failures = []Failure{{Failure: line, Type: "NotOk"}}
+		}
+		suite.TestSuite = append(suite.TestSuite, tc)
+		last = len(suite.TestSuite) - 1
+	}
+	if err := scanner.Err(); err != nil {
+		return InputJSON{}, fmt.Errorf("failed to read TAP output: %v", err)
+	}
+
+	// Tests announced by the plan but never reported count as failures
+	run := 0
+	for _, tc := range suite.TestSuite {
+		if tc.Name != "Bail out" {
+			run++
+		}
+	}
+	for i := run + 1; i <= planned; i++ {
+		tc := newTestCase(fmt.Sprintf("test %d", i), "", "", 0, "", false)
+		tc.Failures = []Failure{{Failure: "test did not run", Type: "Missing"}}
+		suite.TestSuite = append(suite.TestSuite, tc)
+	}
+
+	input := InputJSON{TestSuites: []TestSuite{suite}}
+	summarize(&input)
+	return input, nil
+}
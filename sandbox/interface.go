@@ -21,12 +21,16 @@ const (
 	RUNTIME_ERROR         JudgeResult = "RUNTIME_ERROR"
 	TIME_LIMIT_EXCEEDED   JudgeResult = "TIME_LIMIT_EXCEEDED"
 	MEMORY_LIMIT_EXCEEDED JudgeResult = "MEMORY_LIMIT_EXCEEDED"
+	CANCELLED             JudgeResult = "CANCELLED"
+	SUPERSEDED            JudgeResult = "SUPERSEDED"
+	RATE_LIMITED          JudgeResult = "RATE_LIMITED"
 )
 
 type SandboxJudgeResult struct {
-	Target string `json:"target"`
-	Status string `json:"status"`
-	Result string `json:"result"`
+	Target string           `json:"target"`
+	Status string           `json:"status"`
+	Result string           `json:"result"`
+	Record *ExecutionRecord `json:"record,omitempty"`
 }
 
 type SandboxScoreResult struct {
@@ -71,8 +75,8 @@ type TestCase struct {
 
 type TestSuite struct {
 	Name      string     `json:"name"`
-	MaxScore  int        `json:"maxscore"`
-	GetScore  int        `json:"getscore"`
+	MaxScore  float64    `json:"maxscore"`
+	GetScore  float64    `json:"getscore"`
 	Tests     int        `json:"tests"`
 	Failures  int        `json:"failures"`
 	Disabled  int        `json:"disabled"`
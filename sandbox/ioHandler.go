@@ -0,0 +1,407 @@
+package sandbox
+
+import (
+	"OJ-API/models"
+	"OJ-API/utils"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	ioTarget      = "io"        // 輸入輸出模式在評測進度與結果中使用的 target 名稱
+	repoCaseDir   = "testcases" // 題目倉庫中存放測資的目錄，<name>.in 與 <name>.out 成對
+	ioWorkDir     = ".judge"    // 沙箱內存放輸入與輸出檔的目錄
+	ioCaseTimeout = 10 * time.Second
+)
+
+type ioCaseResult struct {
+	Verdict JudgeResult
+	Message string
+	Meta    Meta
+}
+
+// runIOJudge 輸入輸出模式：編譯一次後逐筆測資以標準輸入執行，並以比對器檢查標準輸出
+func (s *Sandbox) runIOJudge(parentCtx context.Context, judgeinfo JudgeInfo, report *JobReport) error {
+	boxID := judgeinfo.BoxID
+	codePath := judgeinfo.CodePath
+	mothercodePath := judgeinfo.MotherCodePath
+	cmd := judgeinfo.QuestionInfo
+
+	// 檢查父 context 是否已經被取消，如果是則不開始新任務
+	select {
+	case <-parentCtx.Done():
+		// 退回任務，由調度器派發到其他沙箱重新評測
+		s.Release(boxID)
+		return errJobCancelled
+	default:
+	}
+
+	defer s.Release(boxID)
+	defer os.RemoveAll(string(codePath))
+	defer os.RemoveAll(mothercodePath)
+
+	ctx, cancel := s.jobContext(judgeinfo.JobID, judgeTimeout(cmd.JudgeTimeout))
+	defer cancel()
+
+	report.StartedAt = time.Now().UTC()
+
+	// 資料庫沒有設定測資時，改用題目倉庫中的測資
+	testCases := judgeinfo.TestCases
+	if len(testCases) == 0 {
+		cases, err := loadRepoTestCases(filepath.Join(mothercodePath, repoCaseDir))
+		if err != nil {
+			report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to load test cases", err.Error())
+			return err
+		}
+		testCases = cases
+	}
+	if len(testCases) == 0 {
+		err := errors.New("no test cases configured for this question")
+		report.Message = NewErrorResult(SYSTEM_FAILED, "No_Test_Cases", err.Error())
+		return err
+	}
+
+	boxRoot, err := s.CopyCodeToBox(boxID, string(codePath))
+	if err != nil {
+		report.Message = NewErrorResult(SYSTEM_FAILED, "System_Failed", err.Error())
+		return err
+	}
+
+	ioDir := filepath.Join(boxRoot, ioWorkDir)
+	if err := os.MkdirAll(ioDir, 0777); err != nil {
+		report.Message = NewErrorResult(SYSTEM_FAILED, "System_Failed", err.Error())
+		return err
+	}
+	os.Chmod(ioDir, 0777)
+
+	s.ReportJob(&JobReport{
+		JobID:     judgeinfo.JobID,
+		Type:      JobStarted,
+		Stage:     "started",
+		StartedAt: report.StartedAt,
+		Message:   NewErrorResult(JUDGING, "Judge", "Judging..."),
+	})
+
+	/*
+		Compile the code
+	*/
+
+	if strings.TrimSpace(cmd.CompileScript) != "" {
+		compileID, err := s.WriteToTempFile([]byte(cmd.CompileScript), boxID)
+		if err != nil {
+			report.Message = NewErrorResult(SYSTEM_FAILED, "System_Failed", err.Error())
+			return err
+		}
+		defer os.Remove(s.shellFilename(compileID, boxID))
+
+		stageStart := time.Now()
+		compileResult := s.runCompile(boxID, ctx, s.shellFilename(compileID, boxID), []byte(boxRoot),
+			CompileFile{Task: []CompileTask{{Target: ioTarget}}}, compileLimits(cmd), judgeinfo.JobID)
+		report.CompileTime = time.Since(stageStart)
+		if err := judgeInterrupted(ctx); err != nil {
+			return err
+		}
+
+		if compileResult[0].Status != "SUCCESS" {
+			all := AllTests{
+				Tests:      1,
+				Failures:   1,
+				Name:       "AllTests",
+				Timestamp:  time.Now().UTC().Format(time.RFC3339),
+				Time:       "0s",
+				TestSuites: []TestSuite{generateErrorSuite(ioTarget, string(COMPILE_ERROR), compileResult[0].Result)},
+			}
+			jsonBytes, _ := json.MarshalIndent(all, "", "  ")
+			report.Score = 0
+			report.Verdict = COMPILE_ERROR
+			report.Message = string(jsonBytes)
+			return nil
+		}
+	}
+
+	/*
+		Execute every test case
+	*/
+
+	execID, err := s.WriteToTempFile([]byte(cmd.ExecuteScript), boxID)
+	if err != nil {
+		report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to save code as file", err.Error())
+		return err
+	}
+	defer os.Remove(s.shellFilename(execID, boxID))
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	maxScore := ioMaxScore(cmd.ScoreMap)
+	suite := TestSuite{
+		Name:      ioTarget,
+		MaxScore:  maxScore,
+		Timestamp: now,
+	}
+
+	var totalWeight, passedWeight, totalTime float64
+	stageStart := time.Now()
+	for i, tc := range testCases {
+		name := tc.Name
+		if name == "" {
+			name = fmt.Sprintf("case_%d", i+1)
+		}
+
+		result := s.runTestCase(ctx, boxID, boxRoot, cmd, tc, s.shellFilename(execID, boxID))
+		if err := judgeInterrupted(ctx); err != nil {
+			return err
+		}
+		s.reportStage(judgeinfo.JobID, "execute", name, string(result.Verdict))
+
+		weight := tc.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		totalWeight += weight
+		totalTime += result.Meta.Time
+		report.Executions = append(report.Executions, result.Meta.Record(name, result.Verdict))
+		if report.Verdict == "" && result.Verdict != ACCEPTED {
+			report.Verdict = result.Verdict
+		}
+
+		testCase := TestCase{
+			Name:      name,
+			File:      "stdin/stdout",
+			Status:    "passed",
+			Result:    string(result.Verdict),
+			Timestamp: now,
+			Time:      fmt.Sprintf("%.3fs", result.Meta.Time),
+			Classname: ioTarget,
+		}
+		if result.Verdict == ACCEPTED {
+			passedWeight += weight
+		} else {
+			testCase.Status = "failed"
+			testCase.Failures = []Failure{{Failure: result.Message, Type: string(result.Verdict)}}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.TestSuite = append(suite.TestSuite, testCase)
+	}
+	report.ExecuteTime = time.Since(stageStart)
+
+	/*
+
+		Part for result.
+
+	*/
+
+	score := maxScore * passedWeight / totalWeight
+	suite.GetScore = score
+	suite.Time = fmt.Sprintf("%.3fs", totalTime)
+
+	all := AllTests{
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		Name:       "AllTests",
+		Timestamp:  now,
+		Time:       suite.Time,
+		TestSuites: []TestSuite{suite},
+	}
+
+	jsonBytes, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to marshal result", err.Error())
+		return err
+	}
+
+	if report.Verdict == "" {
+		report.Verdict = ACCEPTED
+	}
+
+	// 評測結果由調度器寫入資料庫
+	report.Score = score
+	report.Message = string(jsonBytes)
+
+	utils.Debug("Done for judge!")
+	return nil
+}
+
+// runTestCase 以單筆測資執行程式，依執行資訊與比對結果判定
+func (s *Sandbox) runTestCase(parentCtx context.Context, box int, boxRoot string, qt models.QuestionTestScript, tc models.QuestionTestCase, shellCommand string) ioCaseResult {
+	inPath := filepath.Join(boxRoot, ioWorkDir, "input")
+	outPath := filepath.Join(boxRoot, ioWorkDir, "output")
+	errPath := filepath.Join(boxRoot, ioWorkDir, "stderr")
+	os.Remove(outPath)
+	os.Remove(errPath)
+
+	if err := os.WriteFile(inPath, []byte(tc.Input), 0644); err != nil {
+		return ioCaseResult{Verdict: SYSTEM_FAILED, Message: err.Error()}
+	}
+
+	timeLimit := qt.Time
+	if tc.Time > 0 {
+		timeLimit = tc.Time
+	}
+	memLimit := qt.Memory
+	if tc.Memory > 0 {
+		memLimit = tc.Memory
+	}
+	wallTime := max(qt.WallTime, timeLimit*2)
+
+	ctx, cancel := context.WithTimeout(parentCtx, time.Duration(wallTime)*time.Millisecond+ioCaseTimeout)
+	defer cancel()
+
+	run, err := s.runner.Run(ctx, RunRequest{
+		BoxID:   box,
+		Dir:     boxRoot,
+		Command: []string{"/usr/bin/bash", shellCommand},
+		Limits: Limits{
+			Time:      timeLimit,
+			WallTime:  wallTime,
+			Memory:    memLimit,
+			Stack:     qt.StackMemory,
+			FileSize:  qt.FileSize,
+			Processes: qt.Processes,
+			OpenFiles: qt.OpenFiles,
+		},
+		Stdin:  inPath,
+		Stdout: outPath,
+		Stderr: errPath,
+	})
+	if err != nil {
+		return ioCaseResult{Verdict: SYSTEM_FAILED, Message: fmt.Sprintf("%v\n%s", err, run.Output)}
+	}
+
+	meta := run.Meta
+	result := ioCaseResult{Meta: meta}
+	if verdict := meta.Verdict(memLimit); verdict != "" {
+		result.Verdict = verdict
+		result.Message = meta.Message
+		if stderr, err := os.ReadFile(errPath); err == nil && len(stderr) > 0 {
+			result.Message += "\n" + preview(strings.TrimSpace(string(stderr)))
+		}
+		return result
+	}
+
+	actual, err := os.ReadFile(outPath)
+	if err != nil {
+		result.Verdict = SYSTEM_FAILED
+		result.Message = fmt.Sprintf("failed to read program output: %v", err)
+		return result
+	}
+
+	if qt.Checker == models.CheckerCustom {
+		result.Verdict, result.Message = s.runCustomChecker(parentCtx, box, boxRoot, qt.CheckerScript, tc.Output)
+		return result
+	}
+
+	if ok, diff := checkOutput(qt.Checker, qt.FloatTolerance, tc.Output, string(actual)); ok {
+		result.Verdict = ACCEPTED
+	} else {
+		result.Verdict = WRONG_ANSWER
+		result.Message = diff
+	}
+	return result
+}
+
+// runCustomChecker 執行自訂比對器：bash checker <input> <answer> <output>
+//
+// 比對器結束碼 0 表示正確、1 表示答案錯誤，其他視為系統錯誤；標準輸出作為說明訊息。
+func (s *Sandbox) runCustomChecker(parentCtx context.Context, box int, boxRoot string, checkerScript string, expected string) (JudgeResult, string) {
+	inPath := filepath.Join(boxRoot, ioWorkDir, "input")
+	outPath := filepath.Join(boxRoot, ioWorkDir, "output")
+	ansPath := filepath.Join(boxRoot, ioWorkDir, "answer")
+	msgPath := filepath.Join(boxRoot, ioWorkDir, "checker")
+	os.Remove(msgPath)
+
+	// 受測程式已結束，此時才將比對器與標準答案放入沙箱，受測程式無法讀取或竄改
+	checkerID, err := s.WriteToTempFile([]byte(checkerScript), box)
+	if err != nil {
+		return SYSTEM_FAILED, fmt.Sprintf("failed to save checker as file: %v", err)
+	}
+	checkerCommand := s.shellFilename(checkerID, box)
+	defer os.Remove(checkerCommand)
+	if err := os.Chmod(checkerCommand, 0555); err != nil {
+		return SYSTEM_FAILED, err.Error()
+	}
+	if err := os.WriteFile(ansPath, []byte(expected), 0644); err != nil {
+		return SYSTEM_FAILED, err.Error()
+	}
+	defer os.Remove(ansPath)
+
+	ctx, cancel := context.WithTimeout(parentCtx, execTimeoutDuration)
+	defer cancel()
+
+	run, err := s.runner.Run(ctx, RunRequest{
+		BoxID:   box,
+		Dir:     boxRoot,
+		Command: []string{"/usr/bin/bash", checkerCommand, inPath, ansPath, outPath},
+		Limits:  Limits{Time: 10000, WallTime: 20000, FileSize: 10240, Processes: 100},
+		Stdout:  msgPath,
+	})
+	if err != nil {
+		return SYSTEM_FAILED, fmt.Sprintf("checker failed: %v\n%s", err, run.Output)
+	}
+	meta := run.Meta
+
+	message := ""
+	if msg, err := os.ReadFile(msgPath); err == nil {
+		message = strings.TrimSpace(string(msg))
+	}
+
+	switch {
+	case meta.Status == "" && meta.ExitCode == 0:
+		return ACCEPTED, message
+	case meta.Status == "RE" && meta.ExitCode == 1:
+		return WRONG_ANSWER, message
+	default:
+		return SYSTEM_FAILED, fmt.Sprintf("checker failed: %s %s", meta.Message, message)
+	}
+}
+
+// ioMaxScore 輸入輸出模式的滿分：score map 中名為 io 的 testsuite 分數，其次為 max_score，皆未設定時為 100
+func ioMaxScore(data string) float64 {
+	var scoreMap ScoreMap
+	if err := json.Unmarshal([]byte(data), &scoreMap); err != nil {
+		return 100
+	}
+	for _, suite := range scoreMap.TestSuites {
+		if suite.TestSuite == ioTarget {
+			return suite.Score
+		}
+	}
+	if scoreMap.MaxScore != nil {
+		return *scoreMap.MaxScore
+	}
+	return 100
+}
+
+// loadRepoTestCases 讀取題目倉庫 testcases/ 目錄下成對的 <name>.in 與 <name>.out
+func loadRepoTestCases(dir string) ([]models.QuestionTestCase, error) {
+	inputs, err := filepath.Glob(filepath.Join(dir, "*.in"))
+	if err != nil {
+		return nil, err
+	}
+
+	cases := make([]models.QuestionTestCase, 0, len(inputs))
+	for i, inPath := range inputs {
+		name := strings.TrimSuffix(filepath.Base(inPath), ".in")
+		input, err := os.ReadFile(inPath)
+		if err != nil {
+			return nil, err
+		}
+		output, err := os.ReadFile(filepath.Join(dir, name+".out"))
+		if err != nil {
+			return nil, fmt.Errorf("missing expected output for test case %s: %v", name, err)
+		}
+		cases = append(cases, models.QuestionTestCase{
+			Ordinal: i,
+			Name:    name,
+			Input:   string(input),
+			Output:  string(output),
+			Weight:  1,
+		})
+	}
+	return cases, nil
+}
@@ -0,0 +1,29 @@
+package sandbox
+
+import "testing"
+
+func TestIOMaxScore(t *testing.T) {
+	cases := []struct {
+		name     string
+		scoreMap string
+		want     float64
+	}{
+		{name: "no score map", scoreMap: "", want: 100},
+		{name: "invalid score map", scoreMap: "{", want: 100},
+		{name: "max score", scoreMap: `{"max_score": 60, "testsuites": []}`, want: 60},
+		{
+			name:     "io testsuite takes precedence",
+			scoreMap: `{"max_score": 60, "testsuites": [{"testsuite": "unit", "score": 30}, {"testsuite": "io", "score": 40}]}`,
+			want:     40,
+		},
+		{name: "other testsuites only", scoreMap: `{"testsuites": [{"testsuite": "unit", "score": 30}]}`, want: 100},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ioMaxScore(tc.scoreMap); got != tc.want {
+				t.Errorf("ioMaxScore() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package sandbox
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Meta 沙箱執行資訊，欄位沿用 isolate --meta 的格式，其他執行後端依此產生
+type Meta struct {
+	Time        float64 // CPU 時間（秒）
+	TimeWall    float64 // 實際經過時間（秒）
+	MaxRSS      uint    // 最大常駐記憶體（KB）
+	CgMem       uint    // cgroup 記憶體用量（KB），僅在 --cg 模式下提供
+	CgOOMKilled bool
+	ExitCode    int
+	ExitSig     int
+	Killed      bool
+	Status      string // RE / SG / TO / XX，正常結束時為空
+	Message     string
+}
+
+// ReadMeta 讀取並解析 isolate 的 meta 檔案
+func ReadMeta(path string) (Meta, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Meta{}, err
+	}
+	return ParseMeta(data), nil
+}
+
+// ParseMeta 解析 isolate meta 檔案內容，格式為每行一組 key:value
+func ParseMeta(data []byte) Meta {
+	var m Meta
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "time":
+			m.Time, _ = strconv.ParseFloat(value, 64)
+		case "time-wall":
+			m.TimeWall, _ = strconv.ParseFloat(value, 64)
+		case "max-rss":
+			rss, _ := strconv.ParseUint(value, 10, 64)
+			m.MaxRSS = uint(rss)
+		case "cg-mem":
+			mem, _ := strconv.ParseUint(value, 10, 64)
+			m.CgMem = uint(mem)
+		case "cg-oom-killed":
+			m.CgOOMKilled = value == "1"
+		case "exitcode":
+			m.ExitCode, _ = strconv.Atoi(value)
+		case "exitsig":
+			m.ExitSig, _ = strconv.Atoi(value)
+		case "killed":
+			m.Killed = value == "1"
+		case "status":
+			m.Status = value
+		case "message":
+			m.Message = value
+		}
+	}
+	return m
+}
+
+// Memory 取得程式使用的記憶體（KB），優先使用 cgroup 的統計
+func (m Meta) Memory() uint {
+	if m.CgMem > 0 {
+		return m.CgMem
+	}
+	return m.MaxRSS
+}
+
+// Verdict 依執行資訊判定結果，memLimit 為記憶體上限（KB）
+//
+// 程式正常結束時回傳空字串，需再比對輸出決定 ACCEPTED 或 WRONG_ANSWER。
+func (m Meta) Verdict(memLimit uint) JudgeResult {
+	switch {
+	case m.Status == "TO":
+		return TIME_LIMIT_EXCEEDED
+	case m.Status == "XX":
+		return SYSTEM_FAILED
+	case m.CgOOMKilled:
+		return MEMORY_LIMIT_EXCEEDED
+	case m.Status != "" && memLimit > 0 && m.Memory() >= memLimit:
+		// --mem 限制位址空間，超過時通常表現為配置失敗後崩潰
+		return MEMORY_LIMIT_EXCEEDED
+	case m.Status == "RE" || m.Status == "SG":
+		return RUNTIME_ERROR
+	}
+	return ""
+}
+
+// ExecutionRecord 單一 target（輸入輸出模式為單筆測資）的執行紀錄
+type ExecutionRecord struct {
+	Target   string      `json:"target"`
+	Verdict  JudgeResult `json:"verdict"`
+	Time     float64     `json:"time"`      // CPU 時間（秒）
+	TimeWall float64     `json:"time_wall"` // 實際經過時間（秒）
+	Memory   uint        `json:"memory"`    // 峰值記憶體（KB）
+	ExitCode int         `json:"exit_code"`
+	ExitSig  int         `json:"exit_sig"`
+	Status   string      `json:"status"`
+	Message  string      `json:"message"`
+}
+
+// Record 將執行資訊轉為執行紀錄
+func (m Meta) Record(target string, verdict JudgeResult) ExecutionRecord {
+	return ExecutionRecord{
+		Target:   target,
+		Verdict:  verdict,
+		Time:     m.Time,
+		TimeWall: m.TimeWall,
+		Memory:   m.Memory(),
+		ExitCode: m.ExitCode,
+		ExitSig:  m.ExitSig,
+		Status:   m.Status,
+		Message:  m.Message,
+	}
+}
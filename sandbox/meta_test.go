@@ -0,0 +1,48 @@
+package sandbox
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMeta(t *testing.T) {
+	data := []byte("time:0.125\ntime-wall:0.310\nmax-rss:2048\ncg-mem:4096\nexitcode:1\nstatus:RE\nmessage:Exited with error status 1\nkilled\n")
+	want := Meta{
+		Time:     0.125,
+		TimeWall: 0.31,
+		MaxRSS:   2048,
+		CgMem:    4096,
+		ExitCode: 1,
+		Status:   "RE",
+		Message:  "Exited with error status 1",
+	}
+	if got := ParseMeta(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMeta() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMetaVerdict(t *testing.T) {
+	cases := []struct {
+		name     string
+		meta     Meta
+		memLimit uint
+		want     JudgeResult
+	}{
+		{name: "normal exit", meta: Meta{MaxRSS: 1024}, memLimit: 65536},
+		{name: "time limit", meta: Meta{Status: "TO", Killed: true}, want: TIME_LIMIT_EXCEEDED},
+		{name: "internal error", meta: Meta{Status: "XX"}, want: SYSTEM_FAILED},
+		{name: "cgroup out of memory", meta: Meta{Status: "SG", ExitSig: 9, CgOOMKilled: true}, want: MEMORY_LIMIT_EXCEEDED},
+		{name: "crash at the memory limit", meta: Meta{Status: "SG", ExitSig: 6, MaxRSS: 65536}, memLimit: 65536, want: MEMORY_LIMIT_EXCEEDED},
+		{name: "crash below the memory limit", meta: Meta{Status: "SG", ExitSig: 11, MaxRSS: 1024}, memLimit: 65536, want: RUNTIME_ERROR},
+		{name: "non-zero exit", meta: Meta{Status: "RE", ExitCode: 1}, want: RUNTIME_ERROR},
+		{name: "memory within the limit after a normal exit", meta: Meta{MaxRSS: 65536}, memLimit: 65536},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.meta.Verdict(tc.memLimit); got != tc.want {
+				t.Errorf("Verdict() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
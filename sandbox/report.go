@@ -0,0 +1,134 @@
+package sandbox
+
+import (
+	"OJ-API/utils"
+	"errors"
+	"time"
+)
+
+var errJobCancelled = errors.New("job cancelled due to server shutdown")
+
+type JobReportType int
+
+const (
+	JobRejected   JobReportType = iota // 退回任務，由調度器重新派發
+	JobStarted                         // 開始評測
+	JobProgressed                      // 單一 target 完成某個評測階段
+	JobFinished                        // 評測結束
+)
+
+// JobReport 回報給調度器的任務狀態
+type JobReport struct {
+	JobID       uint64
+	Type        JobReportType
+	Success     bool // 評測是否正常完成，false 表示系統錯誤
+	Score       float64
+	Verdict     JudgeResult
+	Message     string
+	Stage       string // started / compile / execute / score
+	Target      string
+	Status      string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	CompileTime time.Duration
+	ExecuteTime time.Duration
+	ScoreTime   time.Duration
+	Executions  []ExecutionRecord
+}
+
+// TrackJob 記錄已接收但尚未完成的任務
+func (s *Sandbox) TrackJob(jobID uint64) {
+	s.runningJobsMutex.Lock()
+	defer s.runningJobsMutex.Unlock()
+	s.runningJobs[jobID] = &trackedJob{}
+}
+
+// RunningJobIDs 獲取尚未完成的任務 ID，用於向調度器續約
+func (s *Sandbox) RunningJobIDs() []uint64 {
+	s.runningJobsMutex.RLock()
+	defer s.runningJobsMutex.RUnlock()
+
+	ids := make([]uint64, 0, len(s.runningJobs))
+	for id := range s.runningJobs {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// ReportJob 將任務狀態放入待發送隊列，任務結束或退回時停止追蹤
+func (s *Sandbox) ReportJob(report *JobReport) {
+	if report.Type == JobRejected || report.Type == JobFinished {
+		s.runningJobsMutex.Lock()
+		delete(s.runningJobs, report.JobID)
+		s.runningJobsMutex.Unlock()
+	}
+
+	s.reports.Enqueue(report)
+}
+
+// RequeueReport 將發送失敗的回報放回隊列，等待重新連線後發送
+func (s *Sandbox) RequeueReport(report *JobReport) {
+	s.reports.Enqueue(report)
+}
+
+// PendingReportCount 獲取尚未發送的回報數量
+func (s *Sandbox) PendingReportCount() int {
+	return int(s.reports.Length())
+}
+
+// NextReport 取出下一個待發送的回報
+func (s *Sandbox) NextReport() *JobReport {
+	item := s.reports.Dequeue()
+	if item == nil {
+		return nil
+	}
+
+	report, ok := item.(*JobReport)
+	if !ok {
+		utils.Warn("[Sandbox] Dequeued item is not of type *JobReport")
+		return nil
+	}
+	return report
+}
+
+// reportStage 回報單一 target 的評測階段結果
+func (s *Sandbox) reportStage(jobID uint64, stage string, target string, status string) {
+	s.ReportJob(&JobReport{
+		JobID:  jobID,
+		Type:   JobProgressed,
+		Stage:  stage,
+		Target: target,
+		Status: status,
+	})
+}
+
+// reportJobResult 依評測結果回報任務狀態
+func (s *Sandbox) reportJobResult(report *JobReport, err error) {
+	switch {
+	case errors.Is(err, errJobCancelled):
+		s.ReportJob(&JobReport{JobID: report.JobID, Type: JobRejected, Message: err.Error()})
+		return
+	case errors.Is(err, errJobAborted):
+		report.Score = 0
+		report.Verdict = CANCELLED
+		report.Message = NewErrorResult(CANCELLED, "Cancelled", err.Error())
+		err = nil
+	case errors.Is(err, errJudgeTimeout):
+		report.Score = 0
+		report.Verdict = TIME_LIMIT_EXCEEDED
+		report.Message = NewErrorResult(TIME_LIMIT_EXCEEDED, "Judge_Timeout", err.Error())
+		err = nil
+	}
+
+	report.Type = JobFinished
+	report.FinishedAt = time.Now().UTC()
+	if err != nil {
+		report.Success = false
+		if report.Message == "" {
+			report.Message = err.Error()
+		}
+	} else {
+		report.Success = true
+	}
+	s.ReportJob(report)
+}
@@ -2,7 +2,6 @@ package sandbox
 
 import (
 	"OJ-API/config"
-	"OJ-API/database"
 	"OJ-API/gitclone"
 	"OJ-API/models"
 	"OJ-API/utils"
@@ -10,7 +9,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -54,7 +52,7 @@ func (s *Sandbox) assignJob(ctx context.Context) {
 		job := s.ReleaseJob()
 		boxID, ok := s.Reserve(1 * time.Second)
 		if !ok {
-			s.ReserveJob(job.Repo, job.CodePath, job.UQR)
+			s.ReserveJob(job.ID, job.Repo, job.CodePath, job.Script, job.TestCases)
 			continue
 		}
 		go s.runShellCommandByRepo(ctx, boxID, job)
@@ -66,12 +64,11 @@ type JudgeInfo struct {
 	MotherCodePath string
 	BoxID          int
 	CodePath       []byte
-	UQR            models.UserQuestionTable
+	JobID          uint64
+	TestCases      []models.QuestionTestCase
 }
 
-func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo) {
-	db := database.DBConn
-	userQuestion := judgeinfo.UQR
+func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo, report *JobReport) error {
 	boxID := judgeinfo.BoxID
 	codePath := judgeinfo.CodePath
 	mothercodePath := judgeinfo.MotherCodePath
@@ -82,45 +79,40 @@ func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo
 	// 檢查父 context 是否已經被取消，如果是則不開始新任務
 	select {
 	case <-parentCtx.Done():
-		db.Model(&userQuestion).Updates(models.UserQuestionTable{
-			Score:   -2,
-			Message: NewErrorResult(WAITING_TO_JUDGE, "Judge Done", "Job cancelled due to server shutdown"),
-		})
+		// 退回任務，由調度器派發到其他沙箱重新評測
 		s.Release(boxID)
-		return
+		return errJobCancelled
 	default:
 	}
 
-	db.Model(&userQuestion).Updates(models.UserQuestionTable{
-		JudgeTime: time.Now().UTC(),
-	})
+	report.StartedAt = time.Now().UTC()
 
 	CopyDir(mothercodePath+"/test", string(codePath)+"/test")
-	boxRoot, _ := CopyCodeToBox(boxID, string(codePath))
+	boxRoot, _ := s.CopyCodeToBox(boxID, string(codePath))
 
 	defer s.Release(boxID)
 
-	db.Model(&userQuestion).Updates(models.UserQuestionTable{
-		Score:   -1,
-		Message: NewErrorResult(JUDGING, "Judge", "Judging..."),
+	s.ReportJob(&JobReport{
+		JobID:     judgeinfo.JobID,
+		Type:      JobStarted,
+		Stage:     "started",
+		StartedAt: report.StartedAt,
+		Message:   NewErrorResult(JUDGING, "Judge", "Judging..."),
 	})
 
 	// 使用獨立的 context，不會被父 context 取消影響，讓任務完整執行
-	ctx, cancel := context.WithTimeout(context.Background(), execTimeoutDuration)
+	ctx, cancel := s.jobContext(judgeinfo.JobID, judgeTimeout(cmd.JudgeTimeout))
 	defer cancel()
 
 	// saving code as file
 	compileScript := []byte(cmd.CompileScript)
-	codeID, err := WriteToTempFile(compileScript, boxID)
+	codeID, err := s.WriteToTempFile(compileScript, boxID)
 	if err != nil {
-		db.Model(&userQuestion).Updates(models.UserQuestionTable{
-			Score:   -2,
-			Message: NewErrorResult(SYSTEM_FAILED, "System_Failed", err.Error()),
-		})
-		return
+		report.Message = NewErrorResult(SYSTEM_FAILED, "System_Failed", err.Error())
+		return err
 	}
 
-	defer os.Remove(shellFilename(codeID, boxID))
+	defer os.Remove(s.shellFilename(codeID, boxID))
 
 	if len(codePath) > 0 {
 		// make utils dir at code path
@@ -132,11 +124,8 @@ func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo
 
 		if err := copyFile(srcPath, dstPath); err != nil {
 			utils.Debug(fmt.Sprintf("Failed to copy grp_parser: %v", err))
-			db.Model(&userQuestion).Updates(models.UserQuestionTable{
-				Score:   -2,
-				Message: NewErrorResult(SYSTEM_FAILED, "Failed to copy score parser", err.Error()),
-			})
-			return
+			report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to copy score parser", err.Error())
+			return err
 		}
 
 		s.getJsonfromdb(fmt.Sprintf("%v/%s", string(boxRoot), "utils"), cmd)
@@ -150,24 +139,31 @@ func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo
 		Compile the code
 	*/
 
-	SandboxJudgeInfo.CompileResult = s.runCompile(boxID, ctx, shellFilename(codeID, boxID), []byte(boxRoot), scoreMap)
+	stageStart := time.Now()
+	SandboxJudgeInfo.CompileResult = s.runCompile(boxID, ctx, s.shellFilename(codeID, boxID), []byte(boxRoot), scoreMap, compileLimits(cmd), judgeinfo.JobID)
+	report.CompileTime = time.Since(stageStart)
+	if err := judgeInterrupted(ctx); err != nil {
+		return err
+	}
 
 	/*
 		Execute the code
 	*/
 
-	execodeID, err := WriteToTempFile([]byte(cmd.ExecuteScript), boxID)
+	execodeID, err := s.WriteToTempFile([]byte(cmd.ExecuteScript), boxID)
 	if err != nil {
-		db.Model(&userQuestion).Updates(models.UserQuestionTable{
-			Score:   -2,
-			Message: NewErrorResult(SYSTEM_FAILED, "Failed to save code as file", err.Error()),
-		})
-		return
+		report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to save code as file", err.Error())
+		return err
 	}
 
-	defer os.Remove(shellFilename(execodeID, boxID))
+	defer os.Remove(s.shellFilename(execodeID, boxID))
 
-	SandboxJudgeInfo.ExecuteResult = s.runExecute(boxID, ctx, cmd, shellFilename(execodeID, boxID), []byte(boxRoot), SandboxJudgeInfo.CompileResult)
+	stageStart = time.Now()
+	SandboxJudgeInfo.ExecuteResult = s.runExecute(boxID, ctx, cmd, s.shellFilename(execodeID, boxID), []byte(boxRoot), SandboxJudgeInfo.CompileResult, judgeinfo.JobID)
+	report.ExecuteTime = time.Since(stageStart)
+	if err := judgeInterrupted(ctx); err != nil {
+		return err
+	}
 	/*
 	*
 	*	Part for calculate score.
@@ -176,18 +172,20 @@ func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo
 
 	ScoreScript := cmd.ScoreScript
 
-	scoreScriptID, err := WriteToTempFile([]byte(ScoreScript), boxID)
+	scoreScriptID, err := s.WriteToTempFile([]byte(ScoreScript), boxID)
 	if err != nil {
-		db.Model(&userQuestion).Updates(models.UserQuestionTable{
-			Score:   -2,
-			Message: NewErrorResult(SYSTEM_FAILED, "Failed to save code as file", err.Error()),
-		})
-		return
+		report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to save code as file", err.Error())
+		return err
 	}
-	defer os.Remove(shellFilename(execodeID, boxID))
+	defer os.Remove(s.shellFilename(execodeID, boxID))
 
 	compileAndExecuteResult := s.mergeCompileAndExecuteResult(SandboxJudgeInfo.CompileResult, SandboxJudgeInfo.ExecuteResult)
-	SandboxJudgeInfo.JudgeScoreResult = s.runScore(boxID, ctx, shellFilename(scoreScriptID, boxID), []byte(boxRoot), compileAndExecuteResult)
+	stageStart = time.Now()
+	SandboxJudgeInfo.JudgeScoreResult = s.runScore(boxID, ctx, s.shellFilename(scoreScriptID, boxID), []byte(boxRoot), compileAndExecuteResult, scoreLimits(cmd), judgeinfo.JobID)
+	report.ScoreTime = time.Since(stageStart)
+	if err := judgeInterrupted(ctx); err != nil {
+		return err
+	}
 
 	/*
 
@@ -203,179 +201,211 @@ func (s *Sandbox) runShellCommand(parentCtx context.Context, judgeinfo JudgeInfo
 	jsonBytes, err := json.MarshalIndent(totalResult, "", "  ")
 	if err != nil {
 		utils.Debugf("[runHandler] Failed to marshal totalResult: %v\n", err)
-	} else {
-		result := string(jsonBytes)
-		if err := db.Model(&userQuestion).Updates(models.UserQuestionTable{
-			Score:   score,
-			Message: strings.TrimSpace(string(result)),
-		}).Error; err != nil {
-			db.Model(&userQuestion).Updates(models.UserQuestionTable{
-				Score:   -2,
-				Message: NewErrorResult(SYSTEM_FAILED, "Failed to update score", err.Error()),
-			})
-			return
+		report.Message = NewErrorResult(SYSTEM_FAILED, "Failed to marshal result", err.Error())
+		return err
+	}
+
+	for _, r := range SandboxJudgeInfo.ExecuteResult {
+		if r.Record != nil {
+			report.Executions = append(report.Executions, *r.Record)
 		}
 	}
 
+	// 評測結果由調度器寫入資料庫
+	report.Score = score
+	report.Verdict = judgeVerdict(SandboxJudgeInfo.JudgeScoreResult, report.Executions, totalResult)
+	report.Message = strings.TrimSpace(string(jsonBytes))
+
 	utils.Debug("Done for judge!")
+	return nil
 }
 
-func (s *Sandbox) runShellCommandByRepo(ctx context.Context, boxID int, work *Job) {
+// judgeVerdict 依各 target 的結果決定整體評測結果
+func judgeVerdict(scoreResults []SandboxScoreResult, records []ExecutionRecord, all AllTests) JudgeResult {
+	for _, r := range scoreResults {
+		if strings.EqualFold(r.Status, "SUCCESS") {
+			continue
+		}
+		switch JudgeResult(r.Status) {
+		case COMPILE_ERROR, RUNTIME_ERROR, TIME_LIMIT_EXCEEDED, MEMORY_LIMIT_EXCEEDED, SYSTEM_FAILED:
+			return JudgeResult(r.Status)
+		}
+		// 計分腳本失敗等非預期狀態
+		return SYSTEM_FAILED
+	}
+	for _, r := range records {
+		if r.Verdict != ACCEPTED {
+			return r.Verdict
+		}
+	}
+	if all.Failures > 0 {
+		return WRONG_ANSWER
+	}
+	return ACCEPTED
+}
 
-	db := database.DBConn
-	var cmd models.QuestionTestScript
-	if err := db.Joins("Question").
-		Where("git_repo_url = ?", work.Repo).Take(&cmd).Error; err != nil {
-		db.Model(&work.UQR).Updates(models.UserQuestionTable{
-			Score:   -2,
-			Message: fmt.Sprintf("Failed to find shell command for %v: %v", work.Repo, err),
-		})
+func (s *Sandbox) runShellCommandByRepo(ctx context.Context, boxID int, work *Job) {
+	var err error
+	report := &JobReport{JobID: work.ID}
+	defer func() {
+		s.reportJobResult(report, err)
+	}()
+
+	// 任務在等待沙箱時已被取消
+	if s.isJobCancelled(work.ID) {
+		err = errJobAborted
 		s.Release(boxID)
 		return
 	}
-	gitURL := config.GetGiteaBaseURL() + "/" + cmd.Question.GitRepoURL
-	mothercodepath, err := gitclone.CloneRepository(cmd.Question.GitRepoURL, gitURL, "", "", "")
+
+	gitURL := config.GetGiteaBaseURL() + "/" + work.Repo
+	mothercodepath, err := gitclone.CloneRepository(work.Repo, gitURL, "", "", "")
 
 	if err != nil {
-		db.Model(&work.UQR).Updates(models.UserQuestionTable{
-			Score:   -2,
-			Message: fmt.Sprintf("Can't get test info: %v", err),
-		})
+		report.Message = fmt.Sprintf("Can't get test info: %v", err)
 		s.Release(boxID)
 		return
 	}
 
 	judgeinfo := JudgeInfo{
-		QuestionInfo:   cmd,
+		QuestionInfo:   work.Script,
 		MotherCodePath: mothercodepath,
 		BoxID:          boxID,
 		CodePath:       work.CodePath,
-		UQR:            work.UQR,
+		JobID:          work.ID,
+		TestCases:      work.TestCases,
+	}
+	if work.Script.JudgeMode == models.JudgeModeIO {
+		err = s.runIOJudge(ctx, judgeinfo, report)
+		return
 	}
-	s.runShellCommand(ctx, judgeinfo)
+	err = s.runShellCommand(ctx, judgeinfo, report)
 }
 
-func (s *Sandbox) runCompile(box int, ctx context.Context, shellCommand string, codePath []byte, compilefile CompileFile) []SandboxJudgeResult {
-	var results []SandboxJudgeResult
-	for _, task := range compilefile.Task {
-		cmdArgs := []string{
-			fmt.Sprintf("--box-id=%v", box),
-			"--fsize=10240",
-			"--wait",
-			"--processes",
-			"--open-files=0",
-			"--env=PATH",
-		}
-		if len(codePath) > 0 {
-			cmdArgs = append(cmdArgs,
-				fmt.Sprintf("--chdir=%v", string(codePath)),
-				fmt.Sprintf("--dir=%v:rw", string(codePath)),
-				fmt.Sprintf("--env=CODE_PATH=%v", string(codePath)))
-		}
+// compileLimits 編譯階段的資源限制
+func compileLimits(qt models.QuestionTestScript) Limits {
+	return Limits{
+		Time:      qt.CompileTime,
+		WallTime:  qt.CompileWallTime,
+		Memory:    qt.CompileMemory,
+		Processes: qt.CompileProcesses,
+		FileSize:  qt.CompileFileSize,
+	}
+}
 
-		scriptFile := shellCommand
-		cmdArgs = append(cmdArgs, "--run", "--", "/usr/bin/sh", scriptFile, task.Target)
+// scoreLimits 計分階段的資源限制
+func scoreLimits(qt models.QuestionTestScript) Limits {
+	return Limits{
+		Time:      qt.ScoreTime,
+		WallTime:  qt.ScoreWallTime,
+		Memory:    qt.ScoreMemory,
+		Processes: qt.ScoreProcesses,
+		FileSize:  qt.ScoreFileSize,
+		OpenFiles: 65536,
+	}
+}
 
-		cmd := exec.CommandContext(ctx, "isolate", cmdArgs...)
+func (s *Sandbox) runCompile(box int, ctx context.Context, shellCommand string, codePath []byte, compilefile CompileFile, limits Limits, jobID uint64) []SandboxJudgeResult {
+	var results []SandboxJudgeResult
+	for _, task := range compilefile.Task {
+		run, err := s.runner.Run(ctx, RunRequest{
+			BoxID:   box,
+			Dir:     string(codePath),
+			Command: []string{"/usr/bin/sh", shellCommand, task.Target},
+			Limits:  limits,
+		})
 
 		result := SandboxJudgeResult{
 			Target: task.Target,
+			Result: string(run.Output),
 		}
-
-		out, err := cmd.CombinedOutput()
 		if err != nil {
 			result.Status = string(COMPILE_ERROR)
-			result.Result = string(out)
+			result.Result = fmt.Sprintf("%v\n%s", err, run.Output)
+		} else if run.Meta.Status == "TO" {
+			result.Status = string(COMPILE_ERROR)
+			result.Result = fmt.Sprintf("Compilation exceeded the time limit: %s\n%s", run.Meta.Message, run.Output)
+		} else if run.Meta.Status != "" {
+			result.Status = string(COMPILE_ERROR)
 		} else {
 			result.Status = "SUCCESS"
-			result.Result = string(out)
 		}
 		results = append(results, result)
+		s.reportStage(jobID, "compile", result.Target, result.Status)
 	}
 
 	return results
 }
 
-func (s *Sandbox) runExecute(box int, ctx context.Context, qt models.QuestionTestScript, shellCommand string, codePath []byte, compileResult []SandboxJudgeResult) []SandboxJudgeResult {
+func (s *Sandbox) runExecute(box int, ctx context.Context, qt models.QuestionTestScript, shellCommand string, codePath []byte, compileResult []SandboxJudgeResult, jobID uint64) []SandboxJudgeResult {
 	var results []SandboxJudgeResult
 	for _, target := range compileResult {
-		if target.Status == "FAILED" {
+		if target.Status != "SUCCESS" {
 			result := SandboxJudgeResult{
 				Target: target.Target,
 				Result: "COMPILE NOT SUCCESS",
 				Status: "FAILED",
 			}
 			results = append(results, result)
+			s.reportStage(jobID, "execute", result.Target, result.Status)
 			continue
 		}
-		cmdArgs := []string{
-			fmt.Sprintf("--box-id=%v", box),
-			fmt.Sprintf("--fsize=%v", qt.FileSize),
-			"--wait",
-			fmt.Sprintf("--processes=%v", qt.Processes),
-			fmt.Sprintf("--open-files=%v", qt.OpenFiles),
-			"--env=PATH",
-			fmt.Sprintf("--time=%.3f", float64(qt.Time)/1000.0),
-			fmt.Sprintf("--wall-time=%.3f", float64(qt.WallTime)/1000.0),
-			fmt.Sprintf("--mem=%v", qt.Memory),
-			fmt.Sprintf("--stack=%v", qt.StackMemory),
-		}
-
-		if len(codePath) > 0 {
-			cmdArgs = append(cmdArgs,
-				fmt.Sprintf("--chdir=%v", string(codePath)),
-				fmt.Sprintf("--dir=%v:rw", string(codePath)),
-				fmt.Sprintf("--env=CODE_PATH=%v", string(codePath)))
-		}
-
-		cmdArgs = append(cmdArgs, "--run", "--", "/usr/bin/bash", shellCommand, target.Target)
-
-		cmd := exec.CommandContext(ctx, "isolate", cmdArgs...)
+		run, err := s.runner.Run(ctx, RunRequest{
+			BoxID:   box,
+			Dir:     string(codePath),
+			Command: []string{"/usr/bin/bash", shellCommand, target.Target},
+			Limits: Limits{
+				Time:      qt.Time,
+				WallTime:  qt.WallTime,
+				Memory:    qt.Memory,
+				Stack:     qt.StackMemory,
+				FileSize:  qt.FileSize,
+				Processes: qt.Processes,
+				OpenFiles: qt.OpenFiles,
+			},
+		})
 
 		result := SandboxJudgeResult{
 			Target: target.Target,
 		}
-		out, err := cmd.CombinedOutput()
 		if err != nil {
-			outStr := string(out)
-			if strings.Contains(outStr, "Exited with error status 1") {
-				result.Status = "SUCCESS" // 整體執行成功
-				result.Result = "⚠️ GTest 測試未全數通過，請檢查 JSON 結果。"
-				results = append(results, result)
-				continue
-			}
-
-			// ✅ 再檢查是否為 core dumped / Segfault 類型
-			hasCore := strings.Contains(outStr, "core dumped") ||
-				strings.Contains(outStr, "Illegal instruction") ||
-				strings.Contains(outStr, "Segmentation fault") ||
-				strings.Contains(outStr, "Aborted")
-
-			hasExit := strings.Contains(outStr, "Exited with error status")
-
-			if hasCore && hasExit {
-				// 提取 exit code
-				exitLine := regexp.MustCompile(`Exited with error status [0-9]+`).FindString(outStr)
-				result.Result = "Illegal instruction (core dumped)\n" + exitLine +
-					"\n請檢查程式中是否有使用未初始化指標、陣列越界、或動態記憶體錯誤等行為。"
-				result.Status = string(RUNTIME_ERROR)
-			} else {
-				result.Result = outStr
-				result.Status = "FAILED"
-			}
+			result.Status = string(SYSTEM_FAILED)
+			result.Result = fmt.Sprintf("%v\n%s", err, run.Output)
+			results = append(results, result)
+			s.reportStage(jobID, "execute", result.Target, result.Status)
+			continue
+		}
 
-		} else {
+		meta, out := run.Meta, run.Output
+		verdict := meta.Verdict(qt.Memory)
+		switch {
+		case verdict == "":
 			result.Status = "SUCCESS"
 			result.Result = string(out)
+			verdict = ACCEPTED
+		case verdict == RUNTIME_ERROR && meta.Status == "RE" && meta.ExitCode == 1:
+			// 測試框架在有測試未通過時以 1 結束，仍需進行計分
+			result.Status = "SUCCESS" // 整體執行成功
+			result.Result = "⚠️ GTest 測試未全數通過，請檢查 JSON 結果。"
+			verdict = WRONG_ANSWER
+		case verdict == RUNTIME_ERROR && meta.ExitSig != 0:
+			result.Status = string(verdict)
+			result.Result = fmt.Sprintf("%s\n請檢查程式中是否有使用未初始化指標、陣列越界、或動態記憶體錯誤等行為。", meta.Message)
+		default:
+			result.Status = string(verdict)
+			result.Result = fmt.Sprintf("%s\n%s", meta.Message, out)
 		}
+		record := meta.Record(target.Target, verdict)
+		result.Record = &record
 		results = append(results, result)
+		s.reportStage(jobID, "execute", result.Target, result.Status)
 
 	}
 
 	return results
 }
 
-func (s *Sandbox) runScore(box int, ctx context.Context, shellCommand string, codePath []byte, mergeResult []SandboxJudgeResult) []SandboxScoreResult {
+func (s *Sandbox) runScore(box int, ctx context.Context, shellCommand string, codePath []byte, mergeResult []SandboxJudgeResult, limits Limits, jobID uint64) []SandboxScoreResult {
 	var results []SandboxScoreResult
 	for _, target := range mergeResult {
 		if target.Status != "SUCCESS" {
@@ -386,44 +416,33 @@ func (s *Sandbox) runScore(box int, ctx context.Context, shellCommand string, co
 				Score:  0.0,
 			}
 			results = append(results, result)
+			s.reportStage(jobID, "score", result.Target, result.Status)
 			continue
 		}
-		cmdArgs := []string{
-			fmt.Sprintf("--box-id=%v", box),
-			"--fsize=10240",
-			"--wait",
-			"--processes=100",
-			"--open-files=65536",
-			"--env=PATH",
-		}
-
-		if len(codePath) > 0 {
-			cmdArgs = append(cmdArgs,
-				fmt.Sprintf("--chdir=%v", string(codePath)),
-				fmt.Sprintf("--dir=%v:rw", string(codePath)),
-				fmt.Sprintf("--env=CODE_PATH=%v", string(codePath)))
-		}
-
-		cmdArgs = append(cmdArgs, "--run", "--", "/usr/bin/bash", shellCommand, target.Target)
-
-		utils.Debugf("Command: isolate %s", strings.Join(cmdArgs, " "))
-		cmd := exec.CommandContext(ctx, "isolate", cmdArgs...)
-
-		out, err := cmd.CombinedOutput()
+		run, err := s.runner.Run(ctx, RunRequest{
+			BoxID:   box,
+			Dir:     string(codePath),
+			Command: []string{"/usr/bin/bash", shellCommand, target.Target},
+			Limits:  limits,
+		})
+		out := string(run.Output)
 		result := SandboxScoreResult{
 			Target: target.Target,
 		}
 		if err != nil {
 			result.Status = "FAILED"
-			result.Result = string(out)
-
+			result.Result = fmt.Sprintf("%v\n%s", err, out)
+		} else if run.Meta.Status != "" {
+			result.Status = "FAILED"
+			result.Result = fmt.Sprintf("%s\n%s", run.Meta.Message, out)
 		} else {
-			score, _ := s.extractScore(string(out))
+			score, _ := s.extractScore(out)
 			result.Status = "SUCCESS"
-			result.Result = string(out)
+			result.Result = out
 			result.Score = score
 		}
 		results = append(results, result)
+		s.reportStage(jobID, "score", result.Target, result.Status)
 	}
 
 	return results
@@ -0,0 +1,62 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+)
+
+// Limits 單次執行的資源限制，數值為 0 表示不限制
+type Limits struct {
+	Time      uint // CPU 時間（毫秒）
+	WallTime  uint // 實際經過時間（毫秒）
+	Memory    uint // 位址空間（KB）
+	Stack     uint // 堆疊（KB）
+	FileSize  uint // 單一檔案大小（KB）
+	Processes uint // 行程數
+	OpenFiles uint // 開啟檔案數
+}
+
+// RunRequest 在沙箱中執行一個步驟所需的資訊
+type RunRequest struct {
+	BoxID   int
+	Dir     string   // 工作目錄，以可寫入方式掛載進沙箱，並設為 CODE_PATH
+	Command []string // 執行的指令與參數
+	Limits  Limits
+	Stdin   string // 標準輸入檔案路徑，空字串表示不重導向
+	Stdout  string // 標準輸出檔案路徑，空字串表示併入 Output
+	Stderr  string // 標準錯誤檔案路徑，空字串表示併入 Output
+}
+
+// RunResult 執行結果，Meta.Status 為空表示程式正常結束
+type RunResult struct {
+	Meta   Meta
+	Output []byte // 未重導向的標準輸出與標準錯誤
+}
+
+// Runner 沙箱執行後端，負責沙箱的建立、執行與清理
+//
+// 回傳的 error 僅代表後端本身的錯誤，受測程式失敗時以 Meta 表示。
+type Runner interface {
+	Name() string
+	// Init 建立（或重置）沙箱
+	Init(boxID int) error
+	// BoxRoot 沙箱在主機上的目錄，沙箱內以相同路徑存取
+	BoxRoot(boxID int) string
+	// Run 以指定的限制執行指令並收集執行資訊
+	Run(ctx context.Context, req RunRequest) (RunResult, error)
+	// Cleanup 移除沙箱
+	Cleanup(boxID int) error
+}
+
+// NewRunner 依名稱建立執行後端：isolate、nsjail 或 process
+func NewRunner(name string, isolatePath string, boxPath string) (Runner, error) {
+	switch name {
+	case "", "isolate":
+		return newIsolateRunner(isolatePath)
+	case "nsjail":
+		return newNsjailRunner(boxPath)
+	case "process":
+		return newProcessRunner(boxPath), nil
+	}
+	return nil, fmt.Errorf("unknown sandbox runner %q", name)
+}
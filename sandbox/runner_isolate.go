@@ -0,0 +1,112 @@
+package sandbox
+
+import (
+	"OJ-API/utils"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// isolateRunner 以 isolate 執行，需要 root 權限與 cgroup 設定
+type isolateRunner struct {
+	root string // isolate 的沙箱根目錄
+}
+
+func newIsolateRunner(root string) (*isolateRunner, error) {
+	if _, err := exec.LookPath("isolate"); err != nil {
+		return nil, fmt.Errorf("isolate runner: %v", err)
+	}
+	return &isolateRunner{root: root}, nil
+}
+
+func (r *isolateRunner) Name() string {
+	return "isolate"
+}
+
+func (r *isolateRunner) Init(boxID int) error {
+	out, err := exec.Command("isolate", "--init", fmt.Sprintf("--box-id=%v", boxID)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("isolate --init box %v: %v: %s", boxID, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (r *isolateRunner) BoxRoot(boxID int) string {
+	return fmt.Sprintf("%s/%d/box", r.root, boxID)
+}
+
+func (r *isolateRunner) Run(ctx context.Context, req RunRequest) (RunResult, error) {
+	metaFile, err := os.CreateTemp("", "isolate-meta-*")
+	if err != nil {
+		return RunResult{}, err
+	}
+	metaFile.Close()
+	metaPath := metaFile.Name()
+	defer os.Remove(metaPath)
+
+	limits := req.Limits
+	cmdArgs := []string{
+		fmt.Sprintf("--box-id=%v", req.BoxID),
+		"--wait",
+		"--env=PATH",
+		fmt.Sprintf("--meta=%v", metaPath),
+		fmt.Sprintf("--open-files=%v", limits.OpenFiles),
+	}
+	if limits.Processes > 0 {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--processes=%v", limits.Processes))
+	} else {
+		cmdArgs = append(cmdArgs, "--processes")
+	}
+	if limits.FileSize > 0 {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--fsize=%v", limits.FileSize))
+	}
+	if limits.Time > 0 {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--time=%.3f", float64(limits.Time)/1000.0))
+	}
+	if limits.WallTime > 0 {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--wall-time=%.3f", float64(limits.WallTime)/1000.0))
+	}
+	if limits.Memory > 0 {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--mem=%v", limits.Memory))
+	}
+	if limits.Stack > 0 {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--stack=%v", limits.Stack))
+	}
+	if req.Stdin != "" {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--stdin=%v", req.Stdin))
+	}
+	if req.Stdout != "" {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--stdout=%v", req.Stdout))
+	}
+	if req.Stderr != "" {
+		cmdArgs = append(cmdArgs, fmt.Sprintf("--stderr=%v", req.Stderr))
+	}
+	if req.Dir != "" {
+		cmdArgs = append(cmdArgs,
+			fmt.Sprintf("--chdir=%v", req.Dir),
+			fmt.Sprintf("--dir=%v:rw", req.Dir),
+			fmt.Sprintf("--env=CODE_PATH=%v", req.Dir))
+	}
+	cmdArgs = append(cmdArgs, "--run", "--")
+	cmdArgs = append(cmdArgs, req.Command...)
+
+	utils.Debugf("Command: isolate %s", strings.Join(cmdArgs, " "))
+	// 非零結束時 isolate 也會回傳錯誤，實際結果以 meta 為準
+	out, runErr := exec.CommandContext(ctx, "isolate", cmdArgs...).CombinedOutput()
+	meta, err := ReadMeta(metaPath)
+	if err != nil {
+		return RunResult{Output: out}, fmt.Errorf("failed to read isolate meta: %v", err)
+	}
+	if runErr != nil && meta.Status == "" {
+		// isolate 本身失敗或被中止時不會寫入狀態
+		meta.Status = "XX"
+		meta.Message = runErr.Error()
+	}
+	return RunResult{Meta: meta, Output: out}, nil
+}
+
+func (r *isolateRunner) Cleanup(boxID int) error {
+	return exec.Command("isolate", "--cleanup", fmt.Sprintf("--box-id=%v", boxID)).Run()
+}
@@ -0,0 +1,98 @@
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+)
+
+// nsjailRunner 以 nsjail 執行，根目錄唯讀掛載，僅工作目錄與 /tmp 可寫入
+type nsjailRunner struct {
+	root string
+}
+
+func newNsjailRunner(root string) (*nsjailRunner, error) {
+	if _, err := exec.LookPath("nsjail"); err != nil {
+		return nil, fmt.Errorf("nsjail runner: %v", err)
+	}
+	return &nsjailRunner{root: root}, nil
+}
+
+func (r *nsjailRunner) Name() string {
+	return "nsjail"
+}
+
+func (r *nsjailRunner) Init(boxID int) error {
+	return resetBoxDir(r.BoxRoot(boxID))
+}
+
+func (r *nsjailRunner) BoxRoot(boxID int) string {
+	return fmt.Sprintf("%s/%d/box", r.root, boxID)
+}
+
+func (r *nsjailRunner) Run(ctx context.Context, req RunRequest) (RunResult, error) {
+	limits := req.Limits
+	cpuLimit := "inf"
+	if limits.Time > 0 {
+		// 多給一秒，讓逾時由 CPU 時間判定而非訊號
+		cpuLimit = strconv.FormatUint(uint64(ceilDiv(limits.Time, 1000)+1), 10)
+	}
+	// nsjail 的記憶體與檔案大小限制以 MB 為單位，未設定時不限制
+	args := []string{
+		"--mode", "o",
+		"--quiet",
+		"--chroot", "/",
+		"--tmpfsmount", "/tmp",
+		"--env", "PATH=" + os.Getenv("PATH"),
+		"--rlimit_as", mbLimit(limits.Memory),
+		"--rlimit_stack", mbLimit(limits.Stack),
+		"--rlimit_fsize", mbLimit(limits.FileSize),
+		"--rlimit_nofile", countLimit(limits.OpenFiles),
+		"--rlimit_nproc", countLimit(limits.Processes),
+		"--rlimit_cpu", cpuLimit,
+		// 實際時間由 runProcess 計時中止
+		"--time_limit", "0",
+	}
+	if req.Dir != "" {
+		args = append(args,
+			"--bindmount", req.Dir,
+			"--cwd", req.Dir,
+			"--env", "CODE_PATH="+req.Dir)
+	}
+	args = append(args, "--")
+	args = append(args, req.Command...)
+
+	result, err := runProcess(ctx, req, "nsjail", args)
+	if err != nil {
+		return result, err
+	}
+	// nsjail 以 128 + 訊號編號回報受測程式被訊號中止
+	if meta := &result.Meta; meta.Status == "RE" && meta.ExitCode > 128 {
+		meta.ExitSig = meta.ExitCode - 128
+		meta.ExitCode = 0
+		meta.Status = "SG"
+		meta.Message = "Caught fatal signal " + strconv.Itoa(meta.ExitSig)
+	}
+	return result, nil
+}
+
+func (r *nsjailRunner) Cleanup(boxID int) error {
+	return os.RemoveAll(filepath.Dir(r.BoxRoot(boxID)))
+}
+
+func mbLimit(kb uint) string {
+	if kb == 0 {
+		return "inf"
+	}
+	return strconv.FormatUint(uint64(ceilDiv(kb, 1024)), 10)
+}
+
+func countLimit(n uint) string {
+	if n == 0 {
+		return "soft"
+	}
+	return strconv.FormatUint(uint64(n), 10)
+}
@@ -0,0 +1,232 @@
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"OJ-API/utils"
+)
+
+// processRunner 直接以一般行程執行，不需特權，僅供開發機使用
+//
+// 沒有任何隔離，資源限制透過 bash ulimit 設定，實際時間由本程式計時中止。
+// 以 root 執行時行程數限制不生效。
+type processRunner struct {
+	root string
+}
+
+func newProcessRunner(root string) *processRunner {
+	if os.Geteuid() == 0 {
+		utils.Warn("process runner: running as root, the process limit (RLIMIT_NPROC) is not enforced")
+	}
+	return &processRunner{root: root}
+}
+
+func (r *processRunner) Name() string {
+	return "process"
+}
+
+func (r *processRunner) Init(boxID int) error {
+	return resetBoxDir(r.BoxRoot(boxID))
+}
+
+func (r *processRunner) BoxRoot(boxID int) string {
+	return fmt.Sprintf("%s/%d/box", r.root, boxID)
+}
+
+func (r *processRunner) Run(ctx context.Context, req RunRequest) (RunResult, error) {
+	limits := req.Limits
+	script := ""
+	if limits.Time > 0 {
+		// 多給一秒，讓逾時由 CPU 時間判定而非訊號
+		script += fmt.Sprintf("ulimit -t %d; ", ceilDiv(limits.Time, 1000)+1)
+	}
+	if limits.Memory > 0 {
+		script += fmt.Sprintf("ulimit -v %d; ", limits.Memory)
+	}
+	if limits.Stack > 0 {
+		script += fmt.Sprintf("ulimit -s %d; ", limits.Stack)
+	}
+	if limits.FileSize > 0 {
+		script += fmt.Sprintf("ulimit -f %d; ", limits.FileSize)
+	}
+	if limits.OpenFiles > 0 {
+		script += fmt.Sprintf("ulimit -n %d; ", limits.OpenFiles)
+	}
+	if limits.Processes > 0 {
+		// RLIMIT_NPROC 計算的是同一使用者的所有行程，因此加上目前已有的行程數
+		script += fmt.Sprintf("ulimit -u %d; ", userProcessCount(os.Getuid())+limits.Processes)
+	}
+	script += `exec "$@"`
+
+	args := append([]string{"-c", script, "bash"}, req.Command...)
+	return runProcess(ctx, req, "bash", args)
+}
+
+func (r *processRunner) Cleanup(boxID int) error {
+	return os.RemoveAll(filepath.Dir(r.BoxRoot(boxID)))
+}
+
+// resetBoxDir 清空並重新建立沙箱目錄
+func resetBoxDir(boxRoot string) error {
+	if err := os.RemoveAll(filepath.Dir(boxRoot)); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(boxRoot, 0777); err != nil {
+		return err
+	}
+	return os.Chmod(boxRoot, 0777)
+}
+
+// runProcess 在主機上執行行程並依 rusage 與結束狀態產生與 isolate 相同格式的 Meta
+func runProcess(ctx context.Context, req RunRequest, name string, args []string) (RunResult, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = []string{"PATH=" + os.Getenv("PATH")}
+	if req.Dir != "" {
+		cmd.Dir = req.Dir
+		cmd.Env = append(cmd.Env, "CODE_PATH="+req.Dir)
+	}
+	// 獨立的行程群組，逾時時可一併中止子行程
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	files := []*os.File{}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	if req.Stdin != "" {
+		f, err := os.Open(req.Stdin)
+		if err != nil {
+			return RunResult{}, err
+		}
+		files = append(files, f)
+		cmd.Stdin = f
+	}
+	for _, redirect := range []struct {
+		path string
+		dst  *io.Writer
+	}{{req.Stdout, &cmd.Stdout}, {req.Stderr, &cmd.Stderr}} {
+		if redirect.path == "" {
+			continue
+		}
+		f, err := os.Create(redirect.path)
+		if err != nil {
+			return RunResult{}, err
+		}
+		files = append(files, f)
+		*redirect.dst = f
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		return RunResult{}, err
+	}
+
+	done := make(chan struct{})
+	wallExceeded := make(chan bool, 1)
+	go func() {
+		var timeout <-chan time.Time
+		if req.Limits.WallTime > 0 {
+			timer := time.NewTimer(time.Duration(req.Limits.WallTime) * time.Millisecond)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case <-done:
+			wallExceeded <- false
+			return
+		case <-timeout:
+			wallExceeded <- true
+		case <-ctx.Done():
+			wallExceeded <- false
+		}
+		syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}()
+
+	waitErr := cmd.Wait()
+	close(done)
+	elapsed := time.Since(start)
+	killedByWall := <-wallExceeded
+
+	state := cmd.ProcessState
+	if state == nil {
+		return RunResult{Output: output.Bytes()}, waitErr
+	}
+
+	meta := Meta{TimeWall: elapsed.Seconds()}
+	if usage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		meta.Time = time.Duration(usage.Utime.Nano() + usage.Stime.Nano()).Seconds()
+		meta.MaxRSS = uint(usage.Maxrss)
+	}
+	if status, ok := state.Sys().(syscall.WaitStatus); ok {
+		switch {
+		case status.Signaled():
+			meta.ExitSig = int(status.Signal())
+			meta.Status = "SG"
+			meta.Message = "Caught fatal signal " + strconv.Itoa(meta.ExitSig)
+		case status.ExitStatus() != 0:
+			meta.ExitCode = status.ExitStatus()
+			meta.Status = "RE"
+			meta.Message = "Exited with error status " + strconv.Itoa(meta.ExitCode)
+		}
+	}
+
+	switch {
+	case req.Limits.Time > 0 && meta.Time*1000 > float64(req.Limits.Time):
+		meta.Killed = killedByWall || meta.Status == "SG"
+		meta.Status = "TO"
+		meta.Message = "Time limit exceeded"
+	case killedByWall:
+		meta.Status = "TO"
+		meta.Message = "Time limit exceeded (wall clock)"
+		meta.Killed = true
+	case ctx.Err() != nil:
+		meta.Status = "XX"
+		meta.Message = ctx.Err().Error()
+	}
+
+	return RunResult{Meta: meta, Output: output.Bytes()}, nil
+}
+
+// userProcessCount 計算 /proc 中屬於指定使用者的行程數
+func userProcessCount(uid int) uint {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return 0
+	}
+	prefix := "Uid:\t" + strconv.Itoa(uid) + "\t"
+	var count uint
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		status, err := os.ReadFile(filepath.Join("/proc", entry.Name(), "status"))
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(status), "\n") {
+			if strings.HasPrefix(line, prefix) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+func ceilDiv(a, b uint) uint {
+	return (a + b - 1) / b
+}
@@ -3,8 +3,6 @@ package sandbox
 import (
 	"OJ-API/models"
 	"OJ-API/utils"
-	"fmt"
-	"os/exec"
 	"sync"
 	"time"
 
@@ -18,24 +16,27 @@ type Sandbox struct {
 	sandboxCount        int             // How many sandbox
 	availableCount      int             // How many sandbox can use
 	availableCountMutex sync.RWMutex    // Mutex for availableCount
+	runningJobs         map[uint64]*trackedJob
+	runningJobsMutex    sync.RWMutex
+	reports             *lockfree.Queue // Job reports waiting to be sent to scheduler
+	runner              Runner          // Backend that runs the judge steps
 }
 
 type Job struct {
-	Repo     string
-	CodePath []byte
-	UQR      models.UserQuestionTable
+	ID        uint64
+	Repo      string
+	CodePath  []byte
+	Script    models.QuestionTestScript
+	TestCases []models.QuestionTestCase
 }
 
-func NewSandbox(count int) *Sandbox {
+func NewSandbox(count int, runner Runner) (*Sandbox, error) {
 	availableBoxIDs := lockfree.NewQueue()
 	for i := 0; i < count; i++ {
-		cmd := exec.Command("isolate", "--init", fmt.Sprintf("-b %v", i))
-		err := cmd.Run()
-		if err != nil {
-			panic(err)
-		} else {
-			availableBoxIDs.Enqueue(i)
+		if err := runner.Init(i); err != nil {
+			return nil, err
 		}
+		availableBoxIDs.Enqueue(i)
 	}
 	s := &Sandbox{
 		AvailableBoxIDs:     availableBoxIDs,
@@ -44,8 +45,11 @@ func NewSandbox(count int) *Sandbox {
 		jobQueue:            lockfree.NewQueue(),
 		availableCount:      count,
 		availableCountMutex: sync.RWMutex{},
+		runningJobs:         make(map[uint64]*trackedJob),
+		reports:             lockfree.NewQueue(),
+		runner:              runner,
 	}
-	return s
+	return s, nil
 }
 
 func (s *Sandbox) Reserve(timeout time.Duration) (int, bool) {
@@ -81,10 +85,11 @@ func (s *Sandbox) Release(boxID int) {
 			return
 		}
 	}
-	/* Release Isolate resource */
+	/* Reset the box */
 
-	cmd := exec.Command("isolate", "--init", fmt.Sprintf("-b %v", boxID))
-	cmd.Run()
+	if err := s.runner.Init(boxID); err != nil {
+		utils.Errorf("Error resetting box %v: %v", boxID, err)
+	}
 
 	s.AddAvailableCount()
 	s.AvailableBoxIDs.Enqueue(boxID)
@@ -106,12 +111,14 @@ func (s *Sandbox) IsJobEmpty() bool {
 	return s.jobQueue.Length() == 0
 }
 
-func (s *Sandbox) ReserveJob(repo string, codePath []byte, uqtid models.UserQuestionTable) {
+func (s *Sandbox) ReserveJob(jobID uint64, repo string, codePath []byte, script models.QuestionTestScript, testCases []models.QuestionTestCase) {
 
 	job := &Job{
-		Repo:     repo,
-		CodePath: codePath,
-		UQR:      uqtid,
+		ID:        jobID,
+		Repo:      repo,
+		CodePath:  codePath,
+		Script:    script,
+		TestCases: testCases,
 	}
 	s.jobQueue.Enqueue(job)
 }
@@ -135,13 +142,10 @@ func (s *Sandbox) ReleaseJob() *Job {
 
 func (s *Sandbox) Cleanup() {
 	for i := 0; i < s.sandboxCount; i++ {
-		cmd := exec.Command("isolate", "-b", fmt.Sprintf("%v", i), "--cleanup")
 		utils.Debugf("Cleaning up box %v", i)
-		err := cmd.Run()
-		if err != nil {
+		if err := s.runner.Cleanup(i); err != nil {
 			utils.Errorf("Error cleaning up box %v: %v", i, err)
 		}
-
 	}
 	for {
 		ok := s.AvailableBoxIDs.Dequeue()
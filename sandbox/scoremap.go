@@ -0,0 +1,109 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ScoreMapSuite score map 中單一 testsuite 的計分規則，與 grp_parser 的 ScoreTestSuite 相同
+type ScoreMapSuite struct {
+	TestSuite    string             `json:"testsuite"`
+	Score        float64            `json:"score"`
+	Weights      map[string]float64 `json:"weights,omitempty"`
+	AllOrNothing bool               `json:"all_or_nothing,omitempty"`
+	Requires     []string           `json:"requires,omitempty"`
+	Bonus        bool               `json:"bonus,omitempty"`
+}
+
+// ScoreMap 題目的 score map，沙箱寫入 utils/score.json 供 grp_parser 計分
+type ScoreMap struct {
+	MaxScore   *float64        `json:"max_score,omitempty"`
+	TestSuites []ScoreMapSuite `json:"testsuites"`
+	Task       []CompileTask   `json:"task"`
+}
+
+// ValidateScoreMap 檢查 score map 的格式與計分規則，空字串視為未設定
+func ValidateScoreMap(data string) error {
+	if strings.TrimSpace(data) == "" {
+		return nil
+	}
+
+	var scoreMap ScoreMap
+	if err := json.Unmarshal([]byte(data), &scoreMap); err != nil {
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+	if scoreMap.MaxScore != nil && *scoreMap.MaxScore < 0 {
+		return fmt.Errorf("max_score must not be negative")
+	}
+
+	suites := make(map[string]ScoreMapSuite)
+	for _, suite := range scoreMap.TestSuites {
+		if suite.TestSuite == "" {
+			return fmt.Errorf("testsuite name is required")
+		}
+		if _, exists := suites[suite.TestSuite]; exists {
+			return fmt.Errorf("duplicate testsuite %q", suite.TestSuite)
+		}
+		if suite.Score < 0 {
+			return fmt.Errorf("testsuite %q has a negative score", suite.TestSuite)
+		}
+		for name, weight := range suite.Weights {
+			if weight < 0 {
+				return fmt.Errorf("test case %q of testsuite %q has a negative weight", name, suite.TestSuite)
+			}
+		}
+		suites[suite.TestSuite] = suite
+	}
+
+	// 每個 target 各自計分，前置條件必須由同一個 target 產生
+	targets := make(map[string]string)
+	for _, task := range scoreMap.Task {
+		for _, name := range task.Suite {
+			targets[name] = task.Target
+		}
+	}
+	for _, suite := range scoreMap.TestSuites {
+		for _, required := range suite.Requires {
+			if _, exists := suites[required]; !exists {
+				return fmt.Errorf("testsuite %q requires unknown testsuite %q", suite.TestSuite, required)
+			}
+			target, requiredTarget := targets[suite.TestSuite], targets[required]
+			if target != "" && requiredTarget != "" && target != requiredTarget {
+				return fmt.Errorf("testsuite %q of target %q requires testsuite %q of another target %q", suite.TestSuite, target, required, requiredTarget)
+			}
+		}
+	}
+
+	// 前置條件不可形成循環，否則相關 testsuite 永遠無法得分
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("testsuite %q is part of a prerequisite cycle", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, required := range suites[name].Requires {
+			if err := visit(required); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+	for _, suite := range scoreMap.TestSuites {
+		if err := visit(suite.TestSuite); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package sandbox
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateScoreMap(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{name: "empty", data: " "},
+		{
+			name: "valid",
+			data: `{"max_score": 100, "testsuites": [
+				{"testsuite": "Basic", "score": 40},
+				{"testsuite": "Advanced", "score": 60, "weights": {"large": 2}, "requires": ["Basic"]},
+				{"testsuite": "Extra", "score": 10, "bonus": true}
+			], "task": [{"target": "all", "suite": ["Basic", "Advanced", "Extra"]}]}`,
+		},
+		{name: "invalid JSON", data: `{"testsuites": [`, wantErr: "invalid JSON"},
+		{name: "negative max score", data: `{"max_score": -1, "testsuites": []}`, wantErr: "max_score"},
+		{name: "missing name", data: `{"testsuites": [{"score": 10}]}`, wantErr: "name is required"},
+		{
+			name:    "duplicate suite",
+			data:    `{"testsuites": [{"testsuite": "Basic", "score": 10}, {"testsuite": "Basic", "score": 10}]}`,
+			wantErr: "duplicate testsuite",
+		},
+		{name: "negative score", data: `{"testsuites": [{"testsuite": "Basic", "score": -10}]}`, wantErr: "negative score"},
+		{
+			name:    "negative weight",
+			data:    `{"testsuites": [{"testsuite": "Basic", "score": 10, "weights": {"a": -1}}]}`,
+			wantErr: "negative weight",
+		},
+		{
+			name:    "unknown prerequisite",
+			data:    `{"testsuites": [{"testsuite": "Advanced", "score": 10, "requires": ["Basic"]}]}`,
+			wantErr: "unknown testsuite",
+		},
+		{
+			name: "prerequisite of another target",
+			data: `{"testsuites": [
+				{"testsuite": "Basic", "score": 40},
+				{"testsuite": "Advanced", "score": 60, "requires": ["Basic"]}
+			], "task": [{"target": "basic", "suite": ["Basic"]}, {"target": "advanced", "suite": ["Advanced"]}]}`,
+			wantErr: "another target",
+		},
+		{
+			name: "prerequisite cycle",
+			data: `{"testsuites": [
+				{"testsuite": "A", "score": 40, "requires": ["B"]},
+				{"testsuite": "B", "score": 60, "requires": ["A"]}
+			]}`,
+			wantErr: "cycle",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateScoreMap(tc.data)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("ValidateScoreMap() error = %v, want nil", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("ValidateScoreMap() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package sandbox
 
 import (
-	"OJ-API/config"
 	"OJ-API/utils"
 	"errors"
 	"fmt"
@@ -12,13 +11,13 @@ import (
 	"time"
 )
 
-func WriteToTempFile(b []byte, boxID int) (string, error) {
-	boxRoot := fmt.Sprintf("%s/%d/box", config.GetIsolatePath(), boxID)
+func (s *Sandbox) WriteToTempFile(b []byte, boxID int) (string, error) {
+	boxRoot := s.runner.BoxRoot(boxID)
 	CodeStorageFolder := fmt.Sprintf("%s/code", boxRoot)
 
 	// using nano second to avoid filename collision in highly concurrent requests
 	id := fmt.Sprintf("%v", time.Now().UnixNano())
-	err := os.WriteFile(shellFilename(id, boxID), b, 0777)
+	err := os.WriteFile(s.shellFilename(id, boxID), b, 0777)
 
 	if err != nil && errors.Is(err, os.ErrNotExist) {
 		// may be the folder absent. so trying to create it
@@ -29,14 +28,14 @@ func WriteToTempFile(b []byte, boxID int) (string, error) {
 		}
 		utils.Info("created folder:", CodeStorageFolder)
 		// second attempt
-		err = os.WriteFile(shellFilename(id, boxID), b, 0777)
+		err = os.WriteFile(s.shellFilename(id, boxID), b, 0777)
 	}
 
 	return id, err
 }
 
-func shellFilename(timestamp string, boxID int) string {
-	boxRoot := fmt.Sprintf("%s/%d/box", config.GetIsolatePath(), boxID)
+func (s *Sandbox) shellFilename(timestamp string, boxID int) string {
+	boxRoot := s.runner.BoxRoot(boxID)
 	CodeStorageFolder := fmt.Sprintf("%s/code", boxRoot)
 
 	return fmt.Sprintf("%v/%v.sh", CodeStorageFolder, timestamp)
@@ -52,8 +51,8 @@ func LogWithLocation(msg string) {
 	}
 }
 
-func CopyCodeToBox(boxID int, codePath string) (string, error) {
-	boxRoot := fmt.Sprintf("%s/%d/box", config.GetIsolatePath(), boxID)
+func (s *Sandbox) CopyCodeToBox(boxID int, codePath string) (string, error) {
+	boxRoot := s.runner.BoxRoot(boxID)
 
 	err := filepath.Walk(codePath, func(src string, info os.FileInfo, err error) error {
 		if err != nil {
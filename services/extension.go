@@ -0,0 +1,100 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	"OJ-API/database"
+	"OJ-API/models"
+	pb "OJ-API/proto"
+)
+
+// FindExtension 取得使用者在題目上的延長設定，沒有則回傳 nil
+//
+// 題目層級的截止時間優先；未設定截止時間時沿用題目所屬考試的延長截止時間，時間限制倍率取兩者較大者。
+func FindExtension(db *gorm.DB, userID uint, questionID uint) (*models.Extension, error) {
+	var questionExt models.Extension
+	err := db.Where("user_id = ? AND question_id = ?", userID, questionID).Take(&questionExt).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	hasQuestionExt := err == nil
+
+	var examExt models.Extension
+	err = db.Joins("JOIN exam_questions EQ ON EQ.exam_id = extensions.exam_id").
+		Where("extensions.user_id = ? AND EQ.question_id = ?", userID, questionID).
+		Order("extensions.end_time DESC NULLS LAST").Take(&examExt).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	hasExamExt := err == nil
+
+	switch {
+	case hasQuestionExt && hasExamExt:
+		return mergeExtensions(&questionExt, &examExt), nil
+	case hasQuestionExt:
+		return &questionExt, nil
+	case hasExamExt:
+		return &examExt, nil
+	}
+	return nil, nil
+}
+
+// mergeExtensions 合併題目與考試的延長設定
+//
+// 截止時間以題目層級為準，未設定時沿用考試的；時間限制倍率取兩者較大者。
+func mergeExtensions(questionExt *models.Extension, examExt *models.Extension) *models.Extension {
+	merged := *questionExt
+	if merged.EndTime == nil {
+		merged.EndTime = examExt.EndTime
+	}
+	merged.LimitMultiplier = math.Max(merged.LimitMultiplier, examExt.LimitMultiplier)
+	return &merged
+}
+
+// EffectiveEndTime 套用延長設定後的截止時間
+func EffectiveEndTime(question *models.Question, ext *models.Extension) time.Time {
+	if ext != nil && ext.EndTime != nil {
+		return *ext.EndTime
+	}
+	return question.EndTime
+}
+
+// EffectiveEndTimeSQL 產生套用延長設定後截止時間的 SQL 運算式，與 FindExtension 的優先順序相同
+//
+// userColumn 與 questionTable 直接嵌入 SQL，只能傳入欄位名稱或數字。
+func EffectiveEndTimeSQL(userColumn string, questionTable string) string {
+	return fmt.Sprintf("COALESCE("+
+		"(SELECT E.end_time FROM extensions E WHERE E.user_id = %[1]s AND E.question_id = %[2]s.id), "+
+		"(SELECT MAX(E.end_time) FROM extensions E JOIN exam_questions EQ ON EQ.exam_id = E.exam_id WHERE E.user_id = %[1]s AND EQ.question_id = %[2]s.id), "+
+		"%[2]s.end_time)", userColumn, questionTable)
+}
+
+// applyExtensionLimits 依提交者的延長設定放寬執行時間限制
+func applyExtensionLimits(spec *pb.JudgeSpec, uqtID uint) error {
+	db := database.DBConn
+
+	var uqr models.UserQuestionRelation
+	if err := db.Joins("JOIN user_question_tables UQT ON UQT.uqr_id = user_question_relations.id").
+		Where("UQT.id = ?", uqtID).Take(&uqr).Error; err != nil {
+		return err
+	}
+	ext, err := FindExtension(db, uqr.UserID, uqr.QuestionID)
+	if err != nil || ext == nil || ext.LimitMultiplier <= 1 {
+		return err
+	}
+
+	scale := func(v uint32) uint32 {
+		return uint32(math.Ceil(float64(v) * ext.LimitMultiplier))
+	}
+	spec.Time = scale(spec.Time)
+	spec.WallTime = scale(spec.WallTime)
+	spec.JudgeTimeout = scale(spec.JudgeTimeout)
+	for _, tc := range spec.TestCases {
+		tc.Time = scale(tc.Time)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"OJ-API/database/dbtest"
+	"OJ-API/models"
+)
+
+func TestEffectiveEndTime(t *testing.T) {
+	deadline := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	extended := deadline.Add(48 * time.Hour)
+	question := &models.Question{EndTime: deadline}
+
+	cases := []struct {
+		name string
+		ext  *models.Extension
+		want time.Time
+	}{
+		{name: "no extension", want: deadline},
+		{name: "limits only", ext: &models.Extension{LimitMultiplier: 2}, want: deadline},
+		{name: "extended deadline", ext: &models.Extension{EndTime: &extended}, want: extended},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := EffectiveEndTime(question, tc.ext); !got.Equal(tc.want) {
+				t.Errorf("EffectiveEndTime() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMergeExtensions(t *testing.T) {
+	questionEnd := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	examEnd := time.Date(2025, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name           string
+		question       models.Extension
+		exam           models.Extension
+		wantEnd        time.Time
+		wantMultiplier float64
+	}{
+		{
+			name:           "question deadline and multiplier",
+			question:       models.Extension{EndTime: &questionEnd, LimitMultiplier: 2},
+			exam:           models.Extension{EndTime: &examEnd, LimitMultiplier: 1.5},
+			wantEnd:        questionEnd,
+			wantMultiplier: 2,
+		},
+		{
+			name:           "exam multiplier is larger",
+			question:       models.Extension{EndTime: &questionEnd, LimitMultiplier: 1},
+			exam:           models.Extension{EndTime: &examEnd, LimitMultiplier: 1.5},
+			wantEnd:        questionEnd,
+			wantMultiplier: 1.5,
+		},
+		{
+			name:           "exam deadline when the question has none",
+			question:       models.Extension{LimitMultiplier: 1.5},
+			exam:           models.Extension{EndTime: &examEnd, LimitMultiplier: 1},
+			wantEnd:        examEnd,
+			wantMultiplier: 1.5,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := mergeExtensions(&tc.question, &tc.exam)
+			if got.EndTime == nil || !got.EndTime.Equal(tc.wantEnd) || got.LimitMultiplier != tc.wantMultiplier {
+				t.Errorf("mergeExtensions() = end %v, multiplier %v, want end %v, multiplier %v", got.EndTime, got.LimitMultiplier, tc.wantEnd, tc.wantMultiplier)
+			}
+		})
+	}
+}
+
+func TestFindExtension(t *testing.T) {
+	tx := dbtest.Open(t,
+		&models.User{},
+		&models.Exam{},
+		&models.Question{},
+		&models.ExamQuestion{},
+		&models.UserQuestionRelation{},
+		&models.Extension{},
+	)
+
+	deadline := time.Now().UTC().Truncate(time.Second)
+	at := func(hours int) *time.Time {
+		end := deadline.Add(time.Duration(hours) * time.Hour)
+		return &end
+	}
+	type ext struct {
+		exam       int // index of the exam, -1 for the question itself
+		end        *time.Time
+		multiplier float64
+	}
+	cases := []struct {
+		name           string
+		exams          int // exams containing the question
+		exts           []ext
+		wantEnd        *time.Time
+		wantMultiplier float64 // 0 if no extension is found
+	}{
+		{name: "no extension"},
+		{name: "question extension", exts: []ext{{-1, at(24), 1.5}}, wantEnd: at(24), wantMultiplier: 1.5},
+		{name: "exam extension", exams: 1, exts: []ext{{0, at(48), 2}}, wantEnd: at(48), wantMultiplier: 2},
+		{name: "latest of several exams", exams: 2, exts: []ext{{0, at(48), 1}, {1, at(72), 1}}, wantEnd: at(72), wantMultiplier: 1},
+		{
+			name:           "question extension takes precedence",
+			exams:          1,
+			exts:           []ext{{-1, at(24), 1.5}, {0, at(48), 1.2}},
+			wantEnd:        at(24),
+			wantMultiplier: 1.5,
+		},
+		{
+			name:           "question extension without a deadline keeps the exam deadline",
+			exams:          1,
+			exts:           []ext{{-1, nil, 1.5}, {0, at(48), 1.2}},
+			wantEnd:        at(48),
+			wantMultiplier: 1.5,
+		},
+		{
+			name:           "larger exam multiplier is kept",
+			exams:          1,
+			exts:           []ext{{-1, at(24), 1}, {0, at(48), 2}},
+			wantEnd:        at(24),
+			wantMultiplier: 2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uqr := seedUserQuestion(t, tx)
+			exams := make([]models.Exam, tc.exams)
+			for i := range exams {
+				exams[i] = models.Exam{OwnerID: uqr.UserID, Title: "Midterm"}
+				dbtest.Create(t, tx, &exams[i])
+				dbtest.Create(t, tx, &models.ExamQuestion{ExamID: exams[i].ID, QuestionID: uqr.QuestionID, Point: 100})
+			}
+			for _, e := range tc.exts {
+				extension := models.Extension{UserID: uqr.UserID, EndTime: e.end, LimitMultiplier: e.multiplier, CreatedBy: uqr.UserID}
+				if e.exam < 0 {
+					extension.QuestionID = &uqr.QuestionID
+				} else {
+					extension.ExamID = &exams[e.exam].ID
+				}
+				dbtest.Create(t, tx, &extension)
+			}
+
+			got, err := FindExtension(tx, uqr.UserID, uqr.QuestionID)
+			if err != nil {
+				t.Fatalf("FindExtension() error = %v", err)
+			}
+			if tc.wantMultiplier == 0 {
+				if got != nil {
+					t.Errorf("FindExtension() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("FindExtension() = nil, want an extension")
+			}
+			if got.EndTime == nil || tc.wantEnd == nil || !got.EndTime.Equal(*tc.wantEnd) || got.LimitMultiplier != tc.wantMultiplier {
+				t.Errorf("FindExtension() = end %v, multiplier %v, want end %v, multiplier %v", got.EndTime, got.LimitMultiplier, tc.wantEnd, tc.wantMultiplier)
+			}
+		})
+	}
+}
@@ -43,10 +43,10 @@ func giteaCheck() {
 		utils.Errorf("Failed to create Gitea client: %v", err)
 		return
 	}
-	// 找出有效的 UQR
+	// 找出有效的 UQR，截止時間依使用者的延長設定計算
 	var uqr []models.UserQuestionRelation
 	if err := db.Preload("User").Joins("JOIN questions ON questions.id = user_question_relations.question_id").
-		Where("questions.start_time <= ? AND questions.end_time >= ?", time.Now(), time.Now()).
+		Where("questions.start_time <= ? AND "+EffectiveEndTimeSQL("user_question_relations.user_id", "questions")+" >= ?", time.Now(), time.Now()).
 		Find(&uqr).Error; err != nil {
 		utils.Errorf("Failed to find user question relations: %v", err)
 		return
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"OJ-API/models"
+	"sync"
+	"time"
+)
+
+const (
+	JudgeEventQueued   = "queued"   // 任務已加入隊列
+	JudgeEventStarted  = "started"  // 沙箱開始評測
+	JudgeEventProgress = "progress" // 單一 target 完成某個評測階段
+	JudgeEventFinished = "finished" // 評測結束（包含系統錯誤）
+)
+
+// JudgeEvent 提交評測狀態變化事件
+type JudgeEvent struct {
+	UQTID   uint      `json:"uqt_id" example:"1"`
+	Type    string    `json:"type" example:"progress"`
+	Stage   string    `json:"stage,omitempty" example:"compile"`
+	Target  string    `json:"target,omitempty" example:"test_add"`
+	Status  string    `json:"status,omitempty" example:"SUCCESS"`
+	Score   float64   `json:"score" example:"-1"`
+	Verdict string    `json:"verdict,omitempty" example:"ACCEPTED"`
+	Message string    `json:"message,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// judgeEventHub 在 API Server 內分發評測事件給訂閱者
+type judgeEventHub struct {
+	mutex       sync.RWMutex
+	subscribers map[uint]map[chan JudgeEvent]struct{}
+}
+
+var judgeEvents = &judgeEventHub{
+	subscribers: make(map[uint]map[chan JudgeEvent]struct{}),
+}
+
+// SubscribeJudgeEvents 訂閱指定提交的評測事件，回傳的函式用於取消訂閱
+func SubscribeJudgeEvents(uqtID uint) (<-chan JudgeEvent, func()) {
+	ch := make(chan JudgeEvent, 32)
+
+	judgeEvents.mutex.Lock()
+	if judgeEvents.subscribers[uqtID] == nil {
+		judgeEvents.subscribers[uqtID] = make(map[chan JudgeEvent]struct{})
+	}
+	judgeEvents.subscribers[uqtID][ch] = struct{}{}
+	judgeEvents.mutex.Unlock()
+
+	unsubscribe := func() {
+		judgeEvents.mutex.Lock()
+		defer judgeEvents.mutex.Unlock()
+		if subs, ok := judgeEvents.subscribers[uqtID]; ok {
+			delete(subs, ch)
+			if len(subs) == 0 {
+				delete(judgeEvents.subscribers, uqtID)
+			}
+		}
+	}
+	return ch, unsubscribe
+}
+
+// publishJudgeEvent 發送事件給所有訂閱者，訂閱者來不及接收時丟棄事件
+func publishJudgeEvent(event JudgeEvent) {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	judgeEvents.mutex.RLock()
+	defer judgeEvents.mutex.RUnlock()
+	for ch := range judgeEvents.subscribers[event.UQTID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// JudgeEventFromScore 依資料庫中的提交紀錄產生目前狀態的事件
+func JudgeEventFromScore(uqt models.UserQuestionTable) JudgeEvent {
+	event := JudgeEvent{
+		UQTID:   uqt.ID,
+		Type:    JudgeEventFinished,
+		Score:   uqt.Score,
+		Verdict: uqt.Verdict,
+		Message: uqt.Message,
+		Time:    uqt.JudgeTime,
+	}
+	switch uqt.Score {
+	case -3:
+		event.Type = JudgeEventQueued
+	case -1:
+		event.Type = JudgeEventStarted
+	}
+	return event
+}
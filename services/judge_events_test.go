@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+
+	"OJ-API/models"
+)
+
+func TestJudgeEvents(t *testing.T) {
+	events, unsubscribe := SubscribeJudgeEvents(1)
+	other, unsubscribeOther := SubscribeJudgeEvents(2)
+	defer unsubscribeOther()
+
+	publishJudgeEvent(JudgeEvent{UQTID: 1, Type: JudgeEventStarted, Score: -1})
+	select {
+	case event := <-events:
+		if event.Type != JudgeEventStarted || event.Time.IsZero() {
+			t.Errorf("event = %+v, want started with the time set", event)
+		}
+	default:
+		t.Fatal("subscriber did not receive the event")
+	}
+	if len(other) != 0 {
+		t.Errorf("subscriber of another submission received %d events", len(other))
+	}
+
+	// 訂閱者來不及接收時丟棄事件，不阻塞評測流程
+	for i := 0; i < cap(events)+1; i++ {
+		publishJudgeEvent(JudgeEvent{UQTID: 1, Type: JudgeEventProgress, Score: -1})
+	}
+	if len(events) != cap(events) {
+		t.Errorf("buffered %d events, want %d", len(events), cap(events))
+	}
+
+	unsubscribe()
+	if _, ok := judgeEvents.subscribers[1]; ok {
+		t.Errorf("subscribers of submission 1 were not removed")
+	}
+}
+
+func TestJudgeEventFromScore(t *testing.T) {
+	cases := []struct {
+		score float64
+		want  string
+	}{
+		{score: -3, want: JudgeEventQueued},
+		{score: -1, want: JudgeEventStarted},
+		{score: -2, want: JudgeEventFinished},
+		{score: 80, want: JudgeEventFinished},
+	}
+
+	for _, tc := range cases {
+		if got := JudgeEventFromScore(models.UserQuestionTable{ID: 1, Score: tc.score}); got.Type != tc.want || got.Score != tc.score {
+			t.Errorf("JudgeEventFromScore(score %v) = %+v, want type %s", tc.score, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,674 @@
+package services
+
+import (
+	"OJ-API/database"
+	"OJ-API/models"
+	"OJ-API/profiles"
+	pb "OJ-API/proto"
+	"OJ-API/sandbox"
+	"OJ-API/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// 任務派發後等待沙箱確認的租約時間
+	dispatchLeaseDuration = 2 * time.Minute
+	// 沙箱執行中任務的租約時間，沙箱透過狀態更新續約
+	runningLeaseDuration = 2 * time.Minute
+	// 沙箱斷線後保留執行中任務的時間，等待沙箱重新連線
+	orphanGracePeriod = 30 * time.Second
+	// 單一任務最多嘗試次數，超過則標記為失敗
+	maxJobAttempts = 3
+	// 重新評測的任務等待此時間後提升到練習的優先等級，避免飢餓
+	priorityAgingInterval = 5 * time.Minute
+)
+
+// QueuePriorityStats 隊列中單一優先等級的統計
+type QueuePriorityStats struct {
+	Priority          models.JudgeJobPriority `json:"priority" example:"exam"`
+	Queued            int64                   `json:"queued" example:"3"`
+	Users             int64                   `json:"users" example:"2"`
+	OldestWaitSeconds int64                   `json:"oldest_wait_seconds" example:"42"`
+}
+
+// enqueueJob 將任務寫入資料庫隊列
+func enqueueJob(job *models.JudgeJob) error {
+	job.Status = models.JudgeJobQueued
+	if err := database.DBConn.Create(job).Error; err != nil {
+		return err
+	}
+
+	publishJudgeEvent(JudgeEvent{UQTID: job.UQTID, Type: JudgeEventQueued, Score: -3})
+	return nil
+}
+
+// jobPriority 決定推送任務的優先等級，題目屬於進行中的考試（含使用者的延長時間）時為考試等級
+func jobPriority(parentGitFullName string, uqtID uint) models.JudgeJobPriority {
+	db := database.DBConn
+	now := time.Now().UTC()
+
+	extended := db.Table("extensions X").Select("1").
+		Joins("JOIN user_question_relations UQR ON UQR.user_id = X.user_id").
+		Joins("JOIN user_question_tables UQT ON UQT.uqr_id = UQR.id").
+		Where("X.exam_id = E.id AND X.end_time >= ? AND UQT.id = ?", now, uqtID)
+	var count int64
+	if err := db.Table("exams E").
+		Joins("JOIN exam_questions EQ ON EQ.exam_id = E.id").
+		Joins("JOIN questions Q ON Q.id = EQ.question_id").
+		Where("Q.git_repo_url = ? AND E.start_time <= ?", parentGitFullName, now).
+		Where("E.end_time >= ? OR EXISTS (?)", now, extended).
+		Count(&count).Error; err != nil {
+		utils.Warnf("Failed to check exam of %s, judging as practice: %v", parentGitFullName, err)
+	}
+	if count > 0 {
+		return models.JudgeJobPriorityExam
+	}
+	return models.JudgeJobPriorityPractice
+}
+
+// fetchQueuedJobs 取得待派發的任務，依序以下列條件排序：
+//   - 優先等級：考試 > 練習 > 重新評測，重新評測等待 priorityAgingInterval 後與練習同級，任何任務都不會提升到考試
+//   - 公平分配：同一等級內各使用者輪流，已派發給沙箱的任務也計入，大量推送的使用者不會擋住其他人
+//   - 建立順序
+func fetchQueuedJobs(limit int) ([]models.JudgeJob, error) {
+	var jobs []models.JudgeJob
+	err := database.DBConn.Raw(`
+	WITH queued AS (
+		SELECT id, git_username,
+			CASE priority WHEN @exam THEN 0 WHEN @practice THEN 1
+				ELSE GREATEST(2 - FLOOR(EXTRACT(EPOCH FROM CAST(@now AS timestamptz) - created_at) / @aging)::int, 1) END AS class
+		FROM judge_jobs
+		WHERE status = @queued
+	), in_flight AS (
+		SELECT git_username, COUNT(*) AS count
+		FROM judge_jobs
+		WHERE status IN @in_flight
+		GROUP BY git_username
+	), ranked AS (
+		SELECT Q.id, Q.class,
+			ROW_NUMBER() OVER (PARTITION BY Q.class, Q.git_username ORDER BY Q.id) + COALESCE(F.count, 0) AS share
+		FROM queued Q
+		LEFT JOIN in_flight F ON F.git_username = Q.git_username
+	)
+	SELECT J.*
+	FROM judge_jobs J
+	JOIN ranked R ON R.id = J.id
+	ORDER BY R.class, R.share, J.id
+	LIMIT @limit
+	`, map[string]interface{}{
+		"exam":      models.JudgeJobPriorityExam,
+		"practice":  models.JudgeJobPriorityPractice,
+		"now":       time.Now().UTC(),
+		"aging":     priorityAgingInterval.Seconds(),
+		"queued":    models.JudgeJobQueued,
+		"in_flight": []models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning},
+		"limit":     limit,
+	}).Scan(&jobs).Error
+	return jobs, err
+}
+
+// queueStats 統計隊列中各優先等級的任務數、使用者數與最久的等待時間
+func queueStats() []QueuePriorityStats {
+	var rows []struct {
+		Priority models.JudgeJobPriority
+		Queued   int64
+		Users    int64
+		Oldest   time.Time
+	}
+	if err := database.DBConn.Model(&models.JudgeJob{}).
+		Select("priority, COUNT(*) AS queued, COUNT(DISTINCT git_username) AS users, MIN(created_at) AS oldest").
+		Where("status = ?", models.JudgeJobQueued).
+		Group("priority").
+		Scan(&rows).Error; err != nil {
+		utils.Errorf("Failed to collect queue stats: %v", err)
+	}
+
+	stats := make([]QueuePriorityStats, 0, 3)
+	for _, priority := range []models.JudgeJobPriority{
+		models.JudgeJobPriorityExam, models.JudgeJobPriorityPractice, models.JudgeJobPriorityRejudge} {
+		stat := QueuePriorityStats{Priority: priority}
+		for _, row := range rows {
+			if row.Priority == priority {
+				stat.Queued = row.Queued
+				stat.Users = row.Users
+				stat.OldestWaitSeconds = int64(time.Since(row.Oldest).Seconds())
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// claimJob 以樂觀鎖將任務由 queued 轉為 dispatched，避免多個 API Server 重複派發
+func claimJob(jobID uint, sandboxID string) (bool, error) {
+	lease := time.Now().Add(dispatchLeaseDuration)
+	result := database.DBConn.Model(&models.JudgeJob{}).
+		Where("id = ? AND status = ?", jobID, models.JudgeJobQueued).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobDispatched,
+			"sandbox_id":       sandboxID,
+			"attempts":         gorm.Expr("attempts + 1"),
+			"lease_expires_at": lease,
+			"pending_reason":   "",
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// markJobUnroutable 記錄任務因沒有符合要求的沙箱而等待，並顯示於提交紀錄
+func markJobUnroutable(job *models.JudgeJob, reason string) {
+	if job.PendingReason == reason {
+		return
+	}
+	job.PendingReason = reason
+
+	db := database.DBConn
+	if err := db.Model(&models.JudgeJob{}).
+		Where("id = ? AND status = ?", job.ID, models.JudgeJobQueued).
+		Update("pending_reason", reason).Error; err != nil {
+		utils.Errorf("Failed to record pending reason of job %d: %v", job.ID, err)
+		return
+	}
+	db.Model(&models.UserQuestionTable{}).
+		Where("id = ? AND score = ?", job.UQTID, -3).
+		Update("message", reason)
+	publishJudgeEvent(JudgeEvent{UQTID: job.UQTID, Type: JudgeEventQueued, Score: -3, Message: reason})
+}
+
+// releaseJob 將派發失敗的任務放回隊列，不計入嘗試次數
+func releaseJob(jobID uint) error {
+	return database.DBConn.Model(&models.JudgeJob{}).
+		Where("id = ? AND status = ?", jobID, models.JudgeJobDispatched).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobQueued,
+			"sandbox_id":       "",
+			"attempts":         gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"lease_expires_at": nil,
+		}).Error
+}
+
+// markJobRunning 沙箱確認接收任務後標記為執行中
+func markJobRunning(jobID uint, sandboxID string) bool {
+	result := database.DBConn.Model(&models.JudgeJob{}).
+		Where("id = ? AND sandbox_id = ? AND status = ?", jobID, sandboxID, models.JudgeJobDispatched).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobRunning,
+			"lease_expires_at": time.Now().Add(runningLeaseDuration),
+		})
+	if result.Error != nil {
+		utils.Errorf("Failed to mark job %d as running: %v", jobID, result.Error)
+		return false
+	}
+	return result.RowsAffected == 1
+}
+
+// renewJobLeases 延長沙箱回報中仍在執行的任務租約
+func renewJobLeases(sandboxID string, jobIDs []uint64) {
+	if len(jobIDs) == 0 {
+		return
+	}
+	if err := database.DBConn.Model(&models.JudgeJob{}).
+		Where("id IN ? AND sandbox_id = ? AND status IN ?", jobIDs, sandboxID,
+			[]models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning}).
+		Update("lease_expires_at", time.Now().Add(runningLeaseDuration)).Error; err != nil {
+		utils.Errorf("Failed to renew job leases for sandbox %s: %v", sandboxID, err)
+	}
+}
+
+// nackJob 沙箱退回任務，放回隊列等待重新派發
+func nackJob(jobID uint, sandboxID string, reason string) {
+	if err := database.DBConn.Model(&models.JudgeJob{}).
+		Where("id = ? AND sandbox_id = ? AND status IN ?", jobID, sandboxID,
+			[]models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning}).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobQueued,
+			"sandbox_id":       "",
+			"last_error":       truncate(reason, 1000),
+			"lease_expires_at": nil,
+		}).Error; err != nil {
+		utils.Errorf("Failed to requeue job %d: %v", jobID, err)
+	}
+}
+
+// recordJobProgress 處理沙箱回報的評測進度，開始評測時更新提交狀態
+func recordJobProgress(sandboxID string, progress *pb.JobProgress) {
+	db := database.DBConn
+
+	var job models.JudgeJob
+	if err := db.Where("id = ? AND sandbox_id = ? AND status IN ?", progress.JobId, sandboxID,
+		[]models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning}).
+		Take(&job).Error; err != nil {
+		utils.Warnf("Ignoring progress of job %d from sandbox %s: %v", progress.JobId, sandboxID, err)
+		return
+	}
+
+	if progress.Stage != "" && progress.Stage != JudgeEventStarted {
+		publishJudgeEvent(JudgeEvent{
+			UQTID:  job.UQTID,
+			Type:   JudgeEventProgress,
+			Stage:  progress.Stage,
+			Target: progress.Target,
+			Status: progress.Status,
+			Score:  -1,
+		})
+		return
+	}
+
+	startedAt := time.UnixMilli(progress.StartedAt).UTC()
+	db.Model(&job).Update("started_at", startedAt)
+	db.Model(&models.UserQuestionTable{ID: job.UQTID}).Updates(map[string]interface{}{
+		"score":      -1,
+		"message":    progress.Message,
+		"judge_time": startedAt,
+	})
+
+	publishJudgeEvent(JudgeEvent{
+		UQTID:   job.UQTID,
+		Type:    JudgeEventStarted,
+		Score:   -1,
+		Message: progress.Message,
+		Time:    startedAt,
+	})
+}
+
+// recordJobResult 寫入沙箱回報的評測結果，只接受目前持有該任務的沙箱，回傳結果是否被接受
+func recordJobResult(sandboxID string, result *pb.JobResult) bool {
+	var job models.JudgeJob
+	var score float64
+	var verdict string
+	err := database.DBConn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND sandbox_id = ? AND status IN ?", result.JobId, sandboxID,
+			[]models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning}).
+			Take(&job).Error; err != nil {
+			return err
+		}
+
+		score = result.Score
+		verdict = result.Verdict
+		status := models.JudgeJobDone
+		lastError := ""
+		if !result.Success {
+			score = -2
+			verdict = string(sandbox.SYSTEM_FAILED)
+			status = models.JudgeJobFailed
+			lastError = truncate(result.Message, 1000)
+		} else if verdict == string(sandbox.CANCELLED) {
+			score = -4
+			status = models.JudgeJobCancelled
+		}
+
+		// 遲交的提交保留原始分數，計分以扣除遲交懲罰後的分數為準
+		var uqt models.UserQuestionTable
+		if err := tx.Select("id", "uqr_id", "commit", "created_at", "late_penalty", "is_rejudge").Take(&uqt, job.UQTID).Error; err != nil {
+			return err
+		}
+		rawScore := score
+		if score > 0 && uqt.LatePenalty > 0 {
+			score = rawScore * (100 - uqt.LatePenalty) / 100
+		}
+
+		// 以各 target 中最長的 CPU 時間與最大的峰值記憶體作為提交的資源用量
+		var cpuTimeMs, memoryKB int64
+		metrics := make([]models.ExecutionMetric, 0, len(result.Executions))
+		for _, record := range result.Executions {
+			cpuTimeMs = max(cpuTimeMs, record.TimeMs)
+			memoryKB = max(memoryKB, record.MemoryKb)
+			metrics = append(metrics, models.ExecutionMetric{
+				UQTID:      job.UQTID,
+				Target:     truncate(record.Target, 200),
+				Verdict:    record.Verdict,
+				CPUTimeMs:  record.TimeMs,
+				WallTimeMs: record.WallTimeMs,
+				MemoryKB:   record.MemoryKb,
+				ExitCode:   int(record.ExitCode),
+				ExitSignal: int(record.ExitSignal),
+			})
+		}
+
+		if err := tx.Where("uqt_id = ?", job.UQTID).Delete(&models.ExecutionMetric{}).Error; err != nil {
+			return err
+		}
+		if len(metrics) > 0 {
+			if err := tx.Create(&metrics).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.UserQuestionTable{ID: job.UQTID}).Updates(map[string]interface{}{
+			"score":       score,
+			"raw_score":   rawScore,
+			"message":     result.Message,
+			"verdict":     verdict,
+			"cpu_time_ms": cpuTimeMs,
+			"memory_kb":   memoryKB,
+		}).Error; err != nil {
+			return err
+		}
+
+		if uqt.IsRejudge && score >= 0 {
+			if err := supersedeJudgedPush(tx, uqt); err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&job).Updates(map[string]interface{}{
+			"status":           status,
+			"last_error":       lastError,
+			"lease_expires_at": nil,
+			"finished_at":      time.UnixMilli(result.FinishedAt).UTC(),
+			"compile_time_ms":  result.CompileTimeMs,
+			"execute_time_ms":  result.ExecuteTimeMs,
+			"score_time_ms":    result.ScoreTimeMs,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		utils.Warnf("Ignoring result of job %d from sandbox %s: job is no longer assigned to it", result.JobId, sandboxID)
+		return false
+	}
+	if err != nil {
+		utils.Errorf("Failed to record result of job %d: %v", result.JobId, err)
+		return false
+	}
+
+	publishJudgeEvent(JudgeEvent{
+		UQTID:   job.UQTID,
+		Type:    JudgeEventFinished,
+		Score:   score,
+		Verdict: verdict,
+		Message: result.Message,
+	})
+	return true
+}
+
+// supersedeJudgedPush 重新評測完成後，以最新一次完成的評測取代同一次推送的其他評測紀錄
+//
+// 重新評測沿用原推送的 commit 與時間，同一次推送的紀錄只有最新完成的一筆列入計分。
+func supersedeJudgedPush(tx *gorm.DB, uqt models.UserQuestionTable) error {
+	if uqt.Commit == "" {
+		return nil
+	}
+	push := tx.Model(&models.UserQuestionTable{}).
+		Where("uqr_id = ? AND commit = ? AND created_at = ?", uqt.UQRID, uqt.Commit, uqt.CreatedAt)
+
+	// 較新的重新評測已經先完成時，這筆紀錄由它取代
+	var newer models.UserQuestionTable
+	if err := push.Session(&gorm.Session{}).
+		Where("id > ? AND is_rejudge = ? AND score >= 0", uqt.ID, true).
+		Order("id DESC").Limit(1).Find(&newer).Error; err != nil {
+		return err
+	}
+	if newer.ID != 0 {
+		return tx.Model(&models.UserQuestionTable{ID: uqt.ID}).Update("superseded_by", newer.ID).Error
+	}
+
+	return push.Session(&gorm.Session{}).
+		Where("id < ? AND (superseded_by IS NULL OR superseded_by < ?)", uqt.ID, uqt.ID).
+		Update("superseded_by", uqt.ID).Error
+}
+
+// loadJudgeSpec 讀取題目的評測設定，下發給沙箱
+func loadJudgeSpec(parentGitFullName string) (*pb.JudgeSpec, error) {
+	var cmd models.QuestionTestScript
+	if err := database.DBConn.Joins("Question").
+		Where("git_repo_url = ?", parentGitFullName).Take(&cmd).Error; err != nil {
+		return nil, err
+	}
+
+	// 依評測設定模板產生腳本，自訂腳本優先
+	scripts, err := profiles.Resolve(cmd.Profile, profiles.Scripts{
+		CompileScript: cmd.CompileScript,
+		ExecuteScript: cmd.ExecuteScript,
+		ScoreScript:   cmd.ScoreScript,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &pb.JudgeSpec{
+		CompileScript:  scripts.CompileScript,
+		ExecuteScript:  scripts.ExecuteScript,
+		ScoreScript:    scripts.ScoreScript,
+		ScoreMap:       cmd.ScoreMap,
+		Memory:         uint32(cmd.Memory),
+		StackMemory:    uint32(cmd.StackMemory),
+		Time:           uint32(cmd.Time),
+		WallTime:       uint32(cmd.WallTime),
+		FileSize:       uint32(cmd.FileSize),
+		Processes:      uint32(cmd.Processes),
+		OpenFiles:      uint32(cmd.OpenFiles),
+		JudgeMode:      string(cmd.JudgeMode),
+		Checker:        string(cmd.Checker),
+		FloatTolerance: cmd.FloatTolerance,
+		CheckerScript:  cmd.CheckerScript,
+		JudgeTimeout:   uint32(cmd.JudgeTimeout),
+		CompileLimits: &pb.StageLimits{
+			Time:      uint32(cmd.CompileTime),
+			WallTime:  uint32(cmd.CompileWallTime),
+			Memory:    uint32(cmd.CompileMemory),
+			Processes: uint32(cmd.CompileProcesses),
+			FileSize:  uint32(cmd.CompileFileSize),
+		},
+		ScoreLimits: &pb.StageLimits{
+			Time:      uint32(cmd.ScoreTime),
+			WallTime:  uint32(cmd.ScoreWallTime),
+			Memory:    uint32(cmd.ScoreMemory),
+			Processes: uint32(cmd.ScoreProcesses),
+			FileSize:  uint32(cmd.ScoreFileSize),
+		},
+	}
+
+	// 題目要求的沙箱標籤，以及評測設定模板需要的工具鏈
+	for _, label := range strings.Split(cmd.RequiredLabels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			spec.RequiredLabels = append(spec.RequiredLabels, label)
+		}
+	}
+	if profile, ok := profiles.Get(cmd.Profile); ok {
+		spec.RequiredToolchains = profile.Requires
+	}
+
+	// 輸入輸出模式：資料庫中的測資隨任務下發，沒有則由沙箱讀取題目倉庫的 testcases/
+	if cmd.JudgeMode == models.JudgeModeIO {
+		var cases []models.QuestionTestCase
+		if err := database.DBConn.Where("question_id = ?", cmd.QuestionID).
+			Order("ordinal, id").Find(&cases).Error; err != nil {
+			return nil, err
+		}
+		for _, tc := range cases {
+			spec.TestCases = append(spec.TestCases, &pb.TestCaseSpec{
+				Name:   tc.Name,
+				Input:  tc.Input,
+				Output: tc.Output,
+				Weight: tc.Weight,
+				Time:   uint32(tc.Time),
+				Memory: uint32(tc.Memory),
+			})
+		}
+	}
+
+	return spec, nil
+}
+
+// orphanSandboxJobs 處理斷線沙箱的任務：未確認的任務立即放回隊列，
+// 執行中的任務縮短租約，若沙箱未在期限內重新連線則由租約監控重新派發
+func orphanSandboxJobs(sandboxID string) {
+	db := database.DBConn
+	result := db.Model(&models.JudgeJob{}).
+		Where("sandbox_id = ? AND status = ?", sandboxID, models.JudgeJobDispatched).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobQueued,
+			"sandbox_id":       "",
+			"attempts":         gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"last_error":       "sandbox disconnected before acknowledging",
+			"lease_expires_at": nil,
+		})
+	if result.Error != nil {
+		utils.Errorf("Failed to requeue unacknowledged jobs of sandbox %s: %v", sandboxID, result.Error)
+	} else if result.RowsAffected > 0 {
+		utils.Warnf("Requeued %d unacknowledged jobs of sandbox %s", result.RowsAffected, sandboxID)
+	}
+
+	if err := db.Model(&models.JudgeJob{}).
+		Where("sandbox_id = ? AND status = ? AND lease_expires_at > ?", sandboxID, models.JudgeJobRunning, time.Now().Add(orphanGracePeriod)).
+		Update("lease_expires_at", time.Now().Add(orphanGracePeriod)).Error; err != nil {
+		utils.Errorf("Failed to shorten job leases of sandbox %s: %v", sandboxID, err)
+	}
+}
+
+// requeueSandboxJobs 將被移除的沙箱尚未完成的任務立即放回隊列，不計入嘗試次數
+func requeueSandboxJobs(sandboxID string, reason string) {
+	result := database.DBConn.Model(&models.JudgeJob{}).
+		Where("sandbox_id = ? AND status IN ?", sandboxID,
+			[]models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning}).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobQueued,
+			"sandbox_id":       "",
+			"attempts":         gorm.Expr("GREATEST(attempts - 1, 0)"),
+			"last_error":       truncate(reason, 1000),
+			"lease_expires_at": nil,
+		})
+	if result.Error != nil {
+		utils.Errorf("Failed to requeue jobs of sandbox %s: %v", sandboxID, result.Error)
+	} else if result.RowsAffected > 0 {
+		utils.Warnf("Requeued %d jobs of evicted sandbox %s", result.RowsAffected, sandboxID)
+	}
+}
+
+// failJob 將任務標記為失敗並更新提交紀錄
+func failJob(job *models.JudgeJob, reason string) {
+	db := database.DBConn
+	if err := db.Model(&models.JudgeJob{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]interface{}{
+			"status":           models.JudgeJobFailed,
+			"last_error":       truncate(reason, 1000),
+			"lease_expires_at": nil,
+		}).Error; err != nil {
+		utils.Errorf("Failed to mark job %d as failed: %v", job.ID, err)
+	}
+
+	db.Model(&models.UserQuestionTable{ID: job.UQTID}).Updates(models.UserQuestionTable{
+		Score:   -2,
+		Message: reason,
+		Verdict: string(sandbox.SYSTEM_FAILED),
+	})
+
+	publishJudgeEvent(JudgeEvent{UQTID: job.UQTID, Type: JudgeEventFinished, Score: -2, Verdict: string(sandbox.SYSTEM_FAILED), Message: reason})
+}
+
+// cancelJudgeJob 將尚未完成的任務標記為取消並更新提交紀錄
+//
+// 回傳任務原本的狀態，任務已結束時回傳空字串。
+func cancelJudgeJob(job *models.JudgeJob, reason string) (models.JudgeJobStatus, error) {
+	previous := job.Status
+	cancelled := false
+	err := database.DBConn.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.JudgeJob{}).
+			Where("id = ? AND status IN ?", job.ID, []models.JudgeJobStatus{
+				models.JudgeJobQueued, models.JudgeJobDispatched, models.JudgeJobRunning}).
+			Updates(map[string]interface{}{
+				"status":           models.JudgeJobCancelled,
+				"last_error":       truncate(reason, 1000),
+				"lease_expires_at": nil,
+				"finished_at":      time.Now().UTC(),
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		cancelled = true
+
+		return tx.Model(&models.UserQuestionTable{ID: job.UQTID}).Updates(map[string]interface{}{
+			"score":   -4,
+			"message": reason,
+			"verdict": string(sandbox.CANCELLED),
+		}).Error
+	})
+	if err != nil || !cancelled {
+		return "", err
+	}
+
+	publishJudgeEvent(JudgeEvent{UQTID: job.UQTID, Type: JudgeEventFinished, Score: -4, Verdict: string(sandbox.CANCELLED), Message: reason})
+	return previous, nil
+}
+
+// supersedeQueuedJob 將尚未派發的任務標記為被較新的提交取代並更新提交紀錄
+func supersedeQueuedJob(job *models.JudgeJob, reason string) (bool, error) {
+	superseded := false
+	err := database.DBConn.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.JudgeJob{}).
+			Where("id = ? AND status = ?", job.ID, models.JudgeJobQueued).
+			Updates(map[string]interface{}{
+				"status":      models.JudgeJobSuperseded,
+				"last_error":  truncate(reason, 1000),
+				"finished_at": time.Now().UTC(),
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		superseded = true
+
+		return tx.Model(&models.UserQuestionTable{ID: job.UQTID}).Updates(map[string]interface{}{
+			"score":   -5,
+			"message": reason,
+			"verdict": string(sandbox.SUPERSEDED),
+		}).Error
+	})
+	if err != nil || !superseded {
+		return false, err
+	}
+
+	publishJudgeEvent(JudgeEvent{UQTID: job.UQTID, Type: JudgeEventFinished, Score: -5, Verdict: string(sandbox.SUPERSEDED), Message: reason})
+	return true, nil
+}
+
+// requeueExpiredJobs 將租約過期的任務重新放回隊列
+func requeueExpiredJobs() {
+	result := database.DBConn.Model(&models.JudgeJob{}).
+		Where("status IN ? AND lease_expires_at < ?",
+			[]models.JudgeJobStatus{models.JudgeJobDispatched, models.JudgeJobRunning}, time.Now()).
+		Updates(map[string]interface{}{
+			"status":     models.JudgeJobQueued,
+			"sandbox_id": "",
+			// 沙箱未確認接收的任務沒有執行過，不計入嘗試次數
+			"attempts":         gorm.Expr("CASE WHEN status = ? THEN GREATEST(attempts - 1, 0) ELSE attempts END", models.JudgeJobDispatched),
+			"last_error":       "lease expired",
+			"lease_expires_at": nil,
+		})
+	if result.Error != nil {
+		utils.Errorf("Failed to requeue expired jobs: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		utils.Warnf("Requeued %d jobs with expired lease", result.RowsAffected)
+	}
+}
+
+// countQueuedJobs 獲取隊列中等待派發的任務數量
+func countQueuedJobs() int64 {
+	var count int64
+	if err := database.DBConn.Model(&models.JudgeJob{}).
+		Where("status = ?", models.JudgeJobQueued).
+		Count(&count).Error; err != nil {
+		utils.Errorf("Failed to count queued jobs: %v", err)
+	}
+	return count
+}
+
+func exhaustedMessage(job *models.JudgeJob) string {
+	msg := fmt.Sprintf("Judge job failed after %d attempts", job.Attempts)
+	if job.LastError != "" {
+		msg += ": " + job.LastError
+	}
+	return msg
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
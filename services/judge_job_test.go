@@ -0,0 +1,404 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"OJ-API/database/dbtest"
+	"OJ-API/models"
+	pb "OJ-API/proto"
+)
+
+func openJobTestDB(t *testing.T) *gorm.DB {
+	return dbtest.Open(t,
+		&models.User{},
+		&models.Question{},
+		&models.UserQuestionRelation{},
+		&models.RejudgeBatch{},
+		&models.UserQuestionTable{},
+		&models.ExecutionMetric{},
+		&models.JudgeJob{},
+	)
+}
+
+// seedJob 建立一筆等待評測的提交與其任務
+func seedJob(t *testing.T, tx *gorm.DB, job models.JudgeJob) models.JudgeJob {
+	t.Helper()
+	uqt := models.UserQuestionTable{UQRID: seedUserQuestion(t, tx).ID, Score: -3}
+	dbtest.Create(t, tx, &uqt)
+	job.UQTID = uqt.ID
+	job.ParentGitFullName = "teacher/two-sum"
+	job.GitRepoURL = "http://gitea/student/two-sum.git"
+	job.GitFullName = "student/two-sum"
+	if job.GitUsername == "" {
+		job.GitUsername = "student"
+	}
+	if job.Status == "" {
+		job.Status = models.JudgeJobQueued
+	}
+	dbtest.Create(t, tx, &job)
+	return job
+}
+
+func loadJob(t *testing.T, tx *gorm.DB, id uint) models.JudgeJob {
+	t.Helper()
+	var job models.JudgeJob
+	if err := tx.Take(&job, id).Error; err != nil {
+		t.Fatalf("failed to load job %d: %v", id, err)
+	}
+	return job
+}
+
+func TestJobLease(t *testing.T) {
+	tx := openJobTestDB(t)
+	job := seedJob(t, tx, models.JudgeJob{})
+
+	if claimed, err := claimJob(job.ID, "sandbox-1"); err != nil || !claimed {
+		t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+	}
+	// 另一個 API Server 不能重複派發
+	if claimed, err := claimJob(job.ID, "sandbox-2"); err != nil || claimed {
+		t.Fatalf("second claimJob() = %t, %v, want the job already claimed", claimed, err)
+	}
+	job = loadJob(t, tx, job.ID)
+	if job.Status != models.JudgeJobDispatched || job.SandboxID != "sandbox-1" || job.Attempts != 1 || job.LeaseExpiresAt == nil {
+		t.Fatalf("claimed job = %+v, want dispatched to sandbox-1 with one attempt and a lease", job)
+	}
+
+	// 只有持有任務的沙箱可以確認接收
+	if markJobRunning(job.ID, "sandbox-2") {
+		t.Errorf("markJobRunning() by another sandbox succeeded")
+	}
+	if !markJobRunning(job.ID, "sandbox-1") {
+		t.Fatalf("markJobRunning() failed")
+	}
+
+	expiring := time.Now().Add(time.Second)
+	if err := tx.Model(&job).Update("lease_expires_at", expiring).Error; err != nil {
+		t.Fatalf("failed to shorten the lease: %v", err)
+	}
+	renewJobLeases("sandbox-2", []uint64{uint64(job.ID)})
+	if job = loadJob(t, tx, job.ID); job.LeaseExpiresAt.After(expiring) {
+		t.Errorf("lease renewed by another sandbox")
+	}
+	renewJobLeases("sandbox-1", []uint64{uint64(job.ID)})
+	if job = loadJob(t, tx, job.ID); job.Status != models.JudgeJobRunning || !job.LeaseExpiresAt.After(expiring) {
+		t.Errorf("renewed job = %+v, want running with a longer lease", job)
+	}
+}
+
+func TestNackJob(t *testing.T) {
+	tx := openJobTestDB(t)
+	job := seedJob(t, tx, models.JudgeJob{})
+	if claimed, err := claimJob(job.ID, "sandbox-1"); err != nil || !claimed {
+		t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+	}
+
+	nackJob(job.ID, "sandbox-2", "not mine")
+	if job = loadJob(t, tx, job.ID); job.Status != models.JudgeJobDispatched {
+		t.Fatalf("job nacked by another sandbox is %s", job.Status)
+	}
+	nackJob(job.ID, "sandbox-1", "sandbox is full")
+	job = loadJob(t, tx, job.ID)
+	if job.Status != models.JudgeJobQueued || job.SandboxID != "" || job.LeaseExpiresAt != nil || job.LastError != "sandbox is full" {
+		t.Errorf("nacked job = %+v, want queued again with the reason", job)
+	}
+}
+
+func TestRecordJobResult(t *testing.T) {
+	cases := []struct {
+		name         string
+		sandboxID    string
+		result       *pb.JobResult
+		wantAccepted bool
+		wantStatus   models.JudgeJobStatus
+		wantScore    float64
+	}{
+		{
+			name:         "success",
+			sandboxID:    "sandbox-1",
+			result:       &pb.JobResult{Success: true, Score: 80, Message: "{}"},
+			wantAccepted: true,
+			wantStatus:   models.JudgeJobDone,
+			wantScore:    80,
+		},
+		{
+			name:         "system error",
+			sandboxID:    "sandbox-1",
+			result:       &pb.JobResult{Success: false, Message: "Failed to clone repository"},
+			wantAccepted: true,
+			wantStatus:   models.JudgeJobFailed,
+			wantScore:    -2,
+		},
+		{
+			name:       "result from another sandbox",
+			sandboxID:  "sandbox-2",
+			result:     &pb.JobResult{Success: true, Score: 80},
+			wantStatus: models.JudgeJobRunning,
+			wantScore:  -3,
+		},
+	}
+
+	tx := openJobTestDB(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			job := seedJob(t, tx, models.JudgeJob{})
+			if claimed, err := claimJob(job.ID, "sandbox-1"); err != nil || !claimed {
+				t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+			}
+			markJobRunning(job.ID, "sandbox-1")
+
+			tc.result.JobId = uint64(job.ID)
+			tc.result.FinishedAt = time.Now().UnixMilli()
+			if accepted := recordJobResult(tc.sandboxID, tc.result); accepted != tc.wantAccepted {
+				t.Errorf("recordJobResult() = %t, want %t", accepted, tc.wantAccepted)
+			}
+
+			if job = loadJob(t, tx, job.ID); job.Status != tc.wantStatus {
+				t.Errorf("status = %s, want %s", job.Status, tc.wantStatus)
+			}
+			var uqt models.UserQuestionTable
+			if err := tx.Take(&uqt, job.UQTID).Error; err != nil {
+				t.Fatalf("failed to load submission: %v", err)
+			}
+			if uqt.Score != tc.wantScore {
+				t.Errorf("score = %v, want %v", uqt.Score, tc.wantScore)
+			}
+		})
+	}
+}
+
+func TestRecordJobResultMetrics(t *testing.T) {
+	tx := openJobTestDB(t)
+	job := seedJob(t, tx, models.JudgeJob{})
+	if claimed, err := claimJob(job.ID, "sandbox-1"); err != nil || !claimed {
+		t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+	}
+
+	recordJobResult("sandbox-1", &pb.JobResult{
+		JobId:   uint64(job.ID),
+		Success: true,
+		Score:   50,
+		Verdict: "WRONG_ANSWER",
+		Executions: []*pb.ExecutionRecord{
+			{Target: "sum", Verdict: "ACCEPTED", TimeMs: 120, WallTimeMs: 150, MemoryKb: 4096},
+			{Target: "product", Verdict: "WRONG_ANSWER", TimeMs: 80, WallTimeMs: 90, MemoryKb: 8192},
+		},
+		FinishedAt: time.Now().UnixMilli(),
+	})
+
+	// 提交的資源用量取各 target 中最長的 CPU 時間與最大的峰值記憶體
+	var uqt models.UserQuestionTable
+	if err := tx.Take(&uqt, job.UQTID).Error; err != nil {
+		t.Fatalf("failed to load submission: %v", err)
+	}
+	if uqt.Verdict != "WRONG_ANSWER" || uqt.CPUTimeMs != 120 || uqt.MemoryKB != 8192 {
+		t.Errorf("submission = %+v, want WRONG_ANSWER using 120 ms and 8192 KB", uqt)
+	}
+	var metrics []models.ExecutionMetric
+	if err := tx.Where("uqt_id = ?", job.UQTID).Order("id").Find(&metrics).Error; err != nil {
+		t.Fatalf("failed to load metrics: %v", err)
+	}
+	if len(metrics) != 2 || metrics[0].Target != "sum" || metrics[1].MemoryKB != 8192 {
+		t.Errorf("metrics = %+v, want one per target", metrics)
+	}
+}
+
+func TestRecordJobResultLatePenalty(t *testing.T) {
+	tx := openJobTestDB(t)
+	job := seedJob(t, tx, models.JudgeJob{})
+	if err := tx.Model(&models.UserQuestionTable{ID: job.UQTID}).Update("late_penalty", 25).Error; err != nil {
+		t.Fatalf("failed to set the late penalty: %v", err)
+	}
+	if claimed, err := claimJob(job.ID, "sandbox-1"); err != nil || !claimed {
+		t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+	}
+
+	recordJobResult("sandbox-1", &pb.JobResult{JobId: uint64(job.ID), Success: true, Score: 80, FinishedAt: time.Now().UnixMilli()})
+
+	var uqt models.UserQuestionTable
+	if err := tx.Take(&uqt, job.UQTID).Error; err != nil {
+		t.Fatalf("failed to load submission: %v", err)
+	}
+	if uqt.RawScore != 80 || uqt.Score != 60 {
+		t.Errorf("raw score/score = %v/%v, want 80/60", uqt.RawScore, uqt.Score)
+	}
+}
+
+func TestFetchQueuedJobs(t *testing.T) {
+	type queued struct {
+		priority models.JudgeJobPriority
+		user     string
+		age      time.Duration
+		status   models.JudgeJobStatus // empty for queued
+	}
+	cases := []struct {
+		name string
+		jobs []queued
+		want []int // indexes of the queued jobs in dispatch order
+	}{
+		{
+			name: "priority classes",
+			jobs: []queued{
+				{priority: models.JudgeJobPriorityPractice, user: "alice"},
+				{priority: models.JudgeJobPriorityExam, user: "alice"},
+				{priority: models.JudgeJobPriorityRejudge, user: "alice"},
+			},
+			want: []int{1, 0, 2},
+		},
+		{
+			name: "users take turns within a class",
+			jobs: []queued{
+				{priority: models.JudgeJobPriorityPractice, user: "alice"},
+				{priority: models.JudgeJobPriorityPractice, user: "alice"},
+				{priority: models.JudgeJobPriorityPractice, user: "alice"},
+				{priority: models.JudgeJobPriorityPractice, user: "bob"},
+			},
+			want: []int{0, 3, 1, 2},
+		},
+		{
+			name: "dispatched jobs count toward the share",
+			jobs: []queued{
+				{priority: models.JudgeJobPriorityPractice, user: "alice", status: models.JudgeJobRunning},
+				{priority: models.JudgeJobPriorityPractice, user: "alice"},
+				{priority: models.JudgeJobPriorityPractice, user: "bob"},
+			},
+			want: []int{2, 1},
+		},
+		{
+			name: "waiting rejudge catches up with practice",
+			jobs: []queued{
+				{priority: models.JudgeJobPriorityRejudge, user: "alice", age: 6 * time.Minute},
+				{priority: models.JudgeJobPriorityPractice, user: "bob"},
+			},
+			want: []int{0, 1},
+		},
+		{
+			name: "rejudge never reaches exam priority",
+			jobs: []queued{
+				{priority: models.JudgeJobPriorityRejudge, user: "alice", age: time.Hour},
+				{priority: models.JudgeJobPriorityExam, user: "bob"},
+			},
+			want: []int{1, 0},
+		},
+		{
+			name: "practice does not age into exam priority",
+			jobs: []queued{
+				{priority: models.JudgeJobPriorityPractice, user: "alice", age: time.Hour},
+				{priority: models.JudgeJobPriorityExam, user: "bob"},
+			},
+			want: []int{1, 0},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := openJobTestDB(t)
+			now := time.Now().UTC()
+			ids := make(map[uint]int)
+			for i, q := range tc.jobs {
+				job := seedJob(t, tx, models.JudgeJob{
+					Priority:    q.priority,
+					GitUsername: q.user,
+					Status:      q.status,
+					CreatedAt:   now.Add(-q.age),
+				})
+				ids[job.ID] = i
+			}
+
+			jobs, err := fetchQueuedJobs(10)
+			if err != nil {
+				t.Fatalf("fetchQueuedJobs() error = %v", err)
+			}
+			got := make([]int, len(jobs))
+			for i, job := range jobs {
+				got[i] = ids[job.ID]
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("dispatch order = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRequeueExpiredJobs(t *testing.T) {
+	cases := []struct {
+		name         string
+		status       models.JudgeJobStatus
+		lease        time.Duration // relative to now
+		wantStatus   models.JudgeJobStatus
+		wantAttempts int
+	}{
+		{name: "expired unacknowledged job", status: models.JudgeJobDispatched, lease: -time.Second, wantStatus: models.JudgeJobQueued, wantAttempts: 0},
+		{name: "expired running job", status: models.JudgeJobRunning, lease: -time.Second, wantStatus: models.JudgeJobQueued, wantAttempts: 1},
+		{name: "running job with a valid lease", status: models.JudgeJobRunning, lease: time.Minute, wantStatus: models.JudgeJobRunning, wantAttempts: 1},
+	}
+
+	tx := openJobTestDB(t)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lease := time.Now().Add(tc.lease)
+			job := seedJob(t, tx, models.JudgeJob{Status: tc.status, SandboxID: "sandbox-1", Attempts: 1, LeaseExpiresAt: &lease})
+
+			requeueExpiredJobs()
+			job = loadJob(t, tx, job.ID)
+			if job.Status != tc.wantStatus || job.Attempts != tc.wantAttempts {
+				t.Errorf("status/attempts = %s/%d, want %s/%d", job.Status, job.Attempts, tc.wantStatus, tc.wantAttempts)
+			}
+			if tc.wantStatus == models.JudgeJobQueued && (job.SandboxID != "" || job.LastError != "lease expired") {
+				t.Errorf("requeued job = %+v, want no sandbox and the lease expired", job)
+			}
+		})
+	}
+}
+
+func TestReleaseBufferedJobs(t *testing.T) {
+	tx := openJobTestDB(t)
+	instance := &SandboxInstance{ID: "sandbox-1", JobChan: make(chan *pb.AddJobRequest, 2)}
+	var jobs []models.JudgeJob
+	for i := 0; i < 2; i++ {
+		job := seedJob(t, tx, models.JudgeJob{})
+		if claimed, err := claimJob(job.ID, instance.ID); err != nil || !claimed {
+			t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+		}
+		instance.JobChan <- &pb.AddJobRequest{JobId: uint64(job.ID)}
+		jobs = append(jobs, job)
+	}
+
+	releaseBufferedJobs(instance)
+	if len(instance.JobChan) != 0 {
+		t.Errorf("%d jobs left in the channel, want none", len(instance.JobChan))
+	}
+	for _, job := range jobs {
+		job = loadJob(t, tx, job.ID)
+		if job.Status != models.JudgeJobQueued || job.SandboxID != "" || job.Attempts != 0 {
+			t.Errorf("released job = %+v, want queued without a sandbox or an attempt", job)
+		}
+	}
+}
+
+func TestOrphanSandboxJobs(t *testing.T) {
+	tx := openJobTestDB(t)
+
+	lease := time.Now().Add(runningLeaseDuration)
+	dispatched := seedJob(t, tx, models.JudgeJob{Status: models.JudgeJobDispatched, SandboxID: "sandbox-1", Attempts: 1, LeaseExpiresAt: &lease})
+	running := seedJob(t, tx, models.JudgeJob{Status: models.JudgeJobRunning, SandboxID: "sandbox-1", Attempts: 1, LeaseExpiresAt: &lease})
+	other := seedJob(t, tx, models.JudgeJob{Status: models.JudgeJobDispatched, SandboxID: "sandbox-2", Attempts: 1, LeaseExpiresAt: &lease})
+
+	orphanSandboxJobs("sandbox-1")
+
+	// 未確認的任務立即放回隊列，不計入嘗試次數
+	if job := loadJob(t, tx, dispatched.ID); job.Status != models.JudgeJobQueued || job.Attempts != 0 || job.SandboxID != "" {
+		t.Errorf("unacknowledged job = %+v, want queued again without the attempt", job)
+	}
+	// 執行中的任務保留給沙箱重新連線，但租約縮短
+	if job := loadJob(t, tx, running.ID); job.Status != models.JudgeJobRunning || job.LeaseExpiresAt.After(time.Now().Add(orphanGracePeriod)) {
+		t.Errorf("running job = %+v, want running with a lease within the grace period", job)
+	}
+	if job := loadJob(t, tx, other.ID); job.Status != models.JudgeJobDispatched || job.SandboxID != "sandbox-2" {
+		t.Errorf("job of another sandbox = %+v, want untouched", job)
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"OJ-API/database/dbtest"
+	"OJ-API/models"
+	"OJ-API/utils"
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("LOG_LEVEL", "error")
+	utils.InitLog()
+	os.Exit(m.Run())
+}
+
+// seedUserQuestion 建立一位使用者與一題題目，回傳兩者的關聯
+func seedUserQuestion(t *testing.T, tx *gorm.DB) models.UserQuestionRelation {
+	t.Helper()
+	user := models.User{UserName: "student", Email: "student@example.com"}
+	question := models.Question{Title: "Two Sum", Description: "Two Sum", GitRepoURL: "teacher/two-sum"}
+	dbtest.Create(t, tx, &user, &question)
+	uqr := models.UserQuestionRelation{UserID: user.ID, QuestionID: question.ID, GitUserRepoURL: "student/two-sum"}
+	dbtest.Create(t, tx, &uqr)
+	return uqr
+}
@@ -26,8 +26,18 @@ func GetSandboxClientManager() *SandboxClientManager {
 }
 
 // ReserveJob 添加任務到沙箱隊列
-func (m *SandboxClientManager) ReserveJob(parentGitFullName string, gitRepoURL string, gitFullName string, gitAfterHash string, gitUsername string, gitToken string, userQuestionTableID uint64) error {
-	return m.scheduler.ReserveJob(parentGitFullName, gitRepoURL, gitFullName, gitAfterHash, gitUsername, gitToken, userQuestionTableID)
+func (m *SandboxClientManager) ReserveJob(parentGitFullName string, gitRepoURL string, gitFullName string, gitAfterHash string, gitUsername string, userQuestionTableID uint64) error {
+	return m.scheduler.ReserveJob(parentGitFullName, gitRepoURL, gitFullName, gitAfterHash, gitUsername, userQuestionTableID)
+}
+
+// ReserveRejudgeJob 添加重新評測任務到沙箱隊列
+func (m *SandboxClientManager) ReserveRejudgeJob(parentGitFullName string, gitRepoURL string, gitFullName string, gitAfterHash string, gitUsername string, userQuestionTableID uint64) error {
+	return m.scheduler.ReserveRejudgeJob(parentGitFullName, gitRepoURL, gitFullName, gitAfterHash, gitUsername, userQuestionTableID)
+}
+
+// CancelJob 取消提交尚未完成的評測
+func (m *SandboxClientManager) CancelJob(uqtID uint, reason string) (int, error) {
+	return m.scheduler.CancelJob(uqtID, reason)
 }
 
 // GetStatus 獲取沙箱狀態
@@ -35,6 +45,31 @@ func (m *SandboxClientManager) GetStatus() (*pb.SandboxStatusResponse, error) {
 	return m.scheduler.GetGlobalStatus(), nil
 }
 
+// GetQueueStats 獲取隊列中各優先等級的統計
+func (m *SandboxClientManager) GetQueueStats() []QueuePriorityStats {
+	return m.scheduler.GetQueueStats()
+}
+
+// ListInstances 列出連線中的沙箱實例
+func (m *SandboxClientManager) ListInstances() []SandboxInstanceInfo {
+	return m.scheduler.ListInstances()
+}
+
+// CordonSandbox 暫停或恢復派發新任務到沙箱
+func (m *SandboxClientManager) CordonSandbox(sandboxID string, cordoned bool, reason string) error {
+	return m.scheduler.CordonSandbox(sandboxID, cordoned, reason)
+}
+
+// DrainSandbox 沙箱完成目前的任務後斷線
+func (m *SandboxClientManager) DrainSandbox(sandboxID string, reason string) error {
+	return m.scheduler.DrainSandbox(sandboxID, reason)
+}
+
+// EvictSandbox 立即移除沙箱並重新派發其任務
+func (m *SandboxClientManager) EvictSandbox(sandboxID string, reason string) error {
+	return m.scheduler.EvictSandbox(sandboxID, reason)
+}
+
 // Close 關閉客戶端連接
 func (m *SandboxClientManager) Close() error {
 	m.scheduler.Close()
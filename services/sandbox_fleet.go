@@ -0,0 +1,169 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "OJ-API/proto"
+	"OJ-API/utils"
+)
+
+var (
+	// ErrSandboxNotFound 沙箱未連線到此 API Server
+	ErrSandboxNotFound = errors.New("sandbox not found")
+	// ErrSandboxDraining 沙箱正在排空，不能恢復派發
+	ErrSandboxDraining = errors.New("sandbox is draining")
+)
+
+// SandboxInstanceInfo 沙箱實例的狀態，供管理員檢視
+type SandboxInstanceInfo struct {
+	ID             string    `json:"id" example:"0b7c6f1e-3a8e-4d0a-9a51-0f6f0c1e2d3a"`
+	Version        string    `json:"version" example:"v1.4.0"`
+	Capacity       int32     `json:"capacity" example:"4"`
+	Labels         []string  `json:"labels"`
+	Toolchains     []string  `json:"toolchains"`
+	ConnectedAt    time.Time `json:"connected_at"`
+	LastSeen       time.Time `json:"last_seen"`
+	Active         bool      `json:"active"`
+	Cordoned       bool      `json:"cordoned"`
+	Draining       bool      `json:"draining"`
+	AvailableCount int32     `json:"available_count" example:"2"`
+	ActiveJobs     int       `json:"active_jobs" example:"2"`
+	Judged         int64     `json:"judged" example:"120"`
+	Errors         int64     `json:"errors" example:"3"`
+	ErrorRate      float64   `json:"error_rate" example:"0.025"`
+}
+
+// ListInstances 列出連線到此 API Server 的沙箱實例
+func (s *SandboxScheduler) ListInstances() []SandboxInstanceInfo {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	infos := make([]SandboxInstanceInfo, 0, len(s.instances))
+	for _, instance := range s.instances {
+		info := SandboxInstanceInfo{
+			ID:          instance.ID,
+			Version:     instance.Version,
+			Capacity:    instance.Capacity,
+			Labels:      instance.Labels,
+			Toolchains:  instance.Toolchains,
+			ConnectedAt: instance.ConnectedAt,
+			LastSeen:    instance.LastSeen,
+			Active:      instance.Active,
+			Cordoned:    instance.Cordoned,
+			Draining:    instance.Draining,
+			Judged:      instance.Judged,
+			Errors:      instance.Errors,
+		}
+		if instance.Status != nil {
+			info.AvailableCount = instance.Status.AvailableCount
+			info.ActiveJobs = len(instance.Status.RunningJobIds)
+		}
+		if instance.Judged > 0 {
+			info.ErrorRate = float64(instance.Errors) / float64(instance.Judged)
+		}
+		infos = append(infos, info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ConnectedAt.Before(infos[j].ConnectedAt)
+	})
+	return infos
+}
+
+// CordonSandbox 暫停或恢復派發新任務到沙箱，執行中的任務不受影響
+func (s *SandboxScheduler) CordonSandbox(sandboxID string, cordoned bool, reason string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, ok := s.instances[sandboxID]
+	if !ok {
+		return ErrSandboxNotFound
+	}
+	if !cordoned && instance.Draining {
+		return ErrSandboxDraining
+	}
+	instance.Cordoned = cordoned
+	if cordoned {
+		s.cordoned[sandboxID] = true
+	} else {
+		delete(s.cordoned, sandboxID)
+	}
+	utils.Infof("Sandbox %s cordoned=%t: %s", sandboxID, cordoned, reason)
+
+	return sendControl(instance, &pb.SchedulerMessage{
+		SandboxId: sandboxID,
+		MessageType: &pb.SchedulerMessage_Cordon{
+			Cordon: &pb.CordonSandbox{Cordoned: cordoned, Reason: reason},
+		},
+	})
+}
+
+// DrainSandbox 停止派發新任務，沙箱完成目前的任務並回報結果後斷線
+func (s *SandboxScheduler) DrainSandbox(sandboxID string, reason string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	instance, ok := s.instances[sandboxID]
+	if !ok {
+		return ErrSandboxNotFound
+	}
+	instance.Cordoned = true
+	instance.Draining = true
+	s.cordoned[sandboxID] = true
+	utils.Infof("Draining sandbox %s: %s", sandboxID, reason)
+
+	return sendControl(instance, &pb.SchedulerMessage{
+		SandboxId: sandboxID,
+		MessageType: &pb.SchedulerMessage_Drain{
+			Drain: &pb.DrainSandbox{Reason: reason},
+		},
+	})
+}
+
+// EvictSandbox 立即移除沙箱，沙箱中止所有任務並斷線，未完成的任務放回隊列重新派發
+func (s *SandboxScheduler) EvictSandbox(sandboxID string, reason string) error {
+	s.mutex.Lock()
+	instance, ok := s.instances[sandboxID]
+	if !ok {
+		s.mutex.Unlock()
+		return ErrSandboxNotFound
+	}
+	// 不再派發任務，斷線時由 SandboxStream 移除實例並清除暫停狀態
+	instance.Active = false
+	instance.Cordoned = true
+	instance.Draining = true
+	s.cordoned[sandboxID] = true
+	// 丟棄尚未送出的任務，這些任務隨後由 requeueSandboxJobs 放回隊列
+	for drained := false; !drained; {
+		select {
+		case <-instance.JobChan:
+		default:
+			drained = true
+		}
+	}
+	err := sendControl(instance, &pb.SchedulerMessage{
+		SandboxId: sandboxID,
+		MessageType: &pb.SchedulerMessage_Evict{
+			Evict: &pb.EvictSandbox{Reason: reason},
+		},
+	})
+	s.mutex.Unlock()
+	utils.Warnf("Evicted sandbox %s: %s", sandboxID, reason)
+
+	// 沙箱之後回報的結果會因任務已不屬於它而被忽略
+	requeueSandboxJobs(sandboxID, fmt.Sprintf("sandbox evicted: %s", reason))
+	return err
+}
+
+// sendControl 非阻塞地將控制訊息交給沙箱的發送 goroutine
+func sendControl(instance *SandboxInstance, msg *pb.SchedulerMessage) error {
+	select {
+	case instance.Control <- msg:
+		return nil
+	default:
+		return fmt.Errorf("control queue of sandbox %s is full", instance.ID)
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"OJ-API/models"
+	pb "OJ-API/proto"
+)
+
+// fakeSandboxStream 以通道模擬沙箱的雙向流，Recv 在通道沒有訊息時一直等待
+type fakeSandboxStream struct {
+	grpc.ServerStream
+	recv chan *pb.SandboxMessage
+
+	mutex sync.Mutex
+	sent  []*pb.SchedulerMessage
+}
+
+func (f *fakeSandboxStream) Recv() (*pb.SandboxMessage, error) {
+	return <-f.recv, nil
+}
+
+func (f *fakeSandboxStream) Send(msg *pb.SchedulerMessage) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.sent = append(f.sent, msg)
+	return nil
+}
+
+// 以 go test -race 執行時，狀態更新與管理員查詢不可有資料競爭
+func TestListInstancesDuringStatusUpdates(t *testing.T) {
+	s := newTestScheduler()
+	stream := &fakeSandboxStream{recv: make(chan *pb.SandboxMessage)}
+	go s.SandboxStream(stream)
+
+	stream.recv <- &pb.SandboxMessage{
+		SandboxId: "sandbox-1",
+		MessageType: &pb.SandboxMessage_Connect{
+			Connect: &pb.SandboxConnectRequest{SandboxId: "sandbox-1", Capacity: 4, Version: "v1.0.0"},
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := int32(0); i < 100; i++ {
+			stream.recv <- &pb.SandboxMessage{
+				SandboxId: "sandbox-1",
+				MessageType: &pb.SandboxMessage_Status{
+					Status: &pb.SandboxStatusResponse{AvailableCount: i % 5, TotalCount: 4},
+				},
+			}
+		}
+	}()
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case <-done:
+			// 最後一次狀態更新可能仍在處理，等待它寫入後再檢查
+			time.Sleep(50 * time.Millisecond)
+			infos := s.ListInstances()
+			if len(infos) != 1 || infos[0].ID != "sandbox-1" || infos[0].Version != "v1.0.0" {
+				t.Fatalf("ListInstances() = %+v, want sandbox-1 with version v1.0.0", infos)
+			}
+			if infos[0].AvailableCount != 4 {
+				t.Errorf("AvailableCount = %d, want 4", infos[0].AvailableCount)
+			}
+			return
+		case <-deadline:
+			t.Fatal("timed out waiting for status updates")
+		default:
+			s.ListInstances()
+			s.availableSlots()
+		}
+	}
+}
+
+func TestCordonSandbox(t *testing.T) {
+	cases := []struct {
+		name         string
+		draining     bool
+		id           string
+		cordoned     bool
+		wantErr      error
+		wantCordoned bool
+	}{
+		{name: "cordon", id: "sandbox-1", cordoned: true, wantCordoned: true},
+		{name: "uncordon", id: "sandbox-1", cordoned: false, wantCordoned: false},
+		{name: "unknown sandbox", id: "sandbox-2", cordoned: true, wantErr: ErrSandboxNotFound},
+		{name: "uncordon draining sandbox", draining: true, id: "sandbox-1", cordoned: false, wantErr: ErrSandboxDraining, wantCordoned: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestScheduler()
+			instance := &SandboxInstance{
+				ID:       "sandbox-1",
+				Active:   true,
+				Control:  make(chan *pb.SchedulerMessage, 1),
+				Cordoned: tc.draining,
+				Draining: tc.draining,
+			}
+			s.instances[instance.ID] = instance
+			if tc.draining {
+				s.cordoned[instance.ID] = true
+			}
+
+			err := s.CordonSandbox(tc.id, tc.cordoned, "maintenance")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("CordonSandbox() error = %v, want %v", err, tc.wantErr)
+			}
+			if instance.Cordoned != tc.wantCordoned || s.cordoned[instance.ID] != tc.wantCordoned {
+				t.Errorf("cordoned = %t (remembered %t), want %t", instance.Cordoned, s.cordoned[instance.ID], tc.wantCordoned)
+			}
+			if tc.wantErr == nil && len(instance.Control) != 1 {
+				t.Errorf("sandbox was not notified")
+			}
+		})
+	}
+}
+
+func TestEvictSandbox(t *testing.T) {
+	tx := openJobTestDB(t)
+	s := newTestScheduler()
+	instance := &SandboxInstance{
+		ID:      "sandbox-1",
+		Active:  true,
+		JobChan: make(chan *pb.AddJobRequest, 1),
+		Control: make(chan *pb.SchedulerMessage, 1),
+		Done:    make(chan struct{}),
+	}
+	s.instances[instance.ID] = instance
+
+	buffered := seedJob(t, tx, models.JudgeJob{})
+	if claimed, err := claimJob(buffered.ID, instance.ID); err != nil || !claimed {
+		t.Fatalf("claimJob() = %t, %v, want the job claimed", claimed, err)
+	}
+	instance.JobChan <- &pb.AddJobRequest{JobId: uint64(buffered.ID)}
+
+	if err := s.EvictSandbox(instance.ID, "broken toolchain"); err != nil {
+		t.Fatalf("EvictSandbox() error = %v", err)
+	}
+	if instance.Active || len(instance.JobChan) != 0 {
+		t.Errorf("active = %t with %d buffered jobs, want inactive without buffered jobs", instance.Active, len(instance.JobChan))
+	}
+	// 暫停狀態保留到沙箱斷線，避免斷線前再被派發或恢復
+	if !instance.Cordoned || !s.cordoned[instance.ID] {
+		t.Errorf("cordoned = %t (remembered %t), want cordoned until the sandbox disconnects", instance.Cordoned, s.cordoned[instance.ID])
+	}
+	if err := s.CordonSandbox(instance.ID, false, "maintenance"); !errors.Is(err, ErrSandboxDraining) {
+		t.Errorf("CordonSandbox() error = %v, want %v", err, ErrSandboxDraining)
+	}
+	if msg := <-instance.Control; msg.GetEvict() == nil {
+		t.Errorf("control message = %+v, want an evict", msg)
+	}
+	if job := loadJob(t, tx, buffered.ID); job.Status != models.JudgeJobQueued || job.SandboxID != "" {
+		t.Errorf("buffered job = %+v, want queued without a sandbox", job)
+	}
+}
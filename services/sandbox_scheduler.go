@@ -1,34 +1,91 @@
 package services
 
 import (
+	"OJ-API/database"
+	"OJ-API/models"
 	pb "OJ-API/proto"
 	"OJ-API/utils"
+	"errors"
 	"fmt"
 	"io"
 	"sort"
+	"strings"
 	"sync"
 	"time"
+)
+
+const (
+	// 每次處理隊列時額外查看的任務數，避免無法派發的任務擋住後面的任務
+	queueLookahead = 50
+)
 
-	"golang.design/x/lockfree"
+var (
+	errNoAvailableSandbox = errors.New("no available sandbox instances")
+	errNoMatchingSandbox  = errors.New("no available sandbox matches the job requirements")
 )
 
 // SandboxInstance 表示一個沙箱實例
 type SandboxInstance struct {
-	ID       string
-	Capacity int32
-	Status   *pb.SandboxStatusResponse
-	LastSeen time.Time
-	Active   bool
-	Stream   pb.SchedulerService_SandboxStreamServer // 雙向流連接
-	JobChan  chan *pb.AddJobRequest                  // 任務通道
+	ID         string
+	Capacity   int32
+	Labels     []string
+	Toolchains []string // 空表示沙箱未回報，不檢查工具鏈
+	Status     *pb.SandboxStatusResponse
+	LastSeen   time.Time
+	Active     bool
+	Stream     pb.SchedulerService_SandboxStreamServer // 雙向流連接
+	JobChan    chan *pb.AddJobRequest                  // 任務通道
+	Control    chan *pb.SchedulerMessage               // 取消任務等控制訊息，與任務共用發送 goroutine
+	Done       chan struct{}                           // 連線結束時關閉，JobChan 不關閉以免派發時寫入已關閉的通道
+
+	Version     string
+	ConnectedAt time.Time
+	Cordoned    bool  // 暫停派發新任務
+	Draining    bool  // 完成目前的任務後斷線
+	Judged      int64 // 連線後回報結果的任務數
+	Errors      int64 // 連線後以系統錯誤結束的任務數
+}
+
+// Satisfies 判斷沙箱是否具備評測設定要求的標籤與工具鏈
+func (i *SandboxInstance) Satisfies(spec *pb.JudgeSpec) bool {
+	if !containsAll(i.Labels, spec.GetRequiredLabels()) {
+		return false
+	}
+	return len(i.Toolchains) == 0 || containsAll(i.Toolchains, spec.GetRequiredToolchains())
+}
+
+// disconnect 標記實例已斷線並通知發送 goroutine 結束，呼叫時需持有鎖
+func (i *SandboxInstance) disconnect() {
+	i.Active = false
+	select {
+	case <-i.Done:
+	default:
+		close(i.Done)
+	}
+}
+
+func containsAll(have []string, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, h := range have {
+			if h == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 // SandboxScheduler 管理多個沙箱實例的調度
 type SandboxScheduler struct {
 	pb.UnimplementedSchedulerServiceServer
 	instances map[string]*SandboxInstance
+	cordoned  map[string]bool // 管理員暫停派發的沙箱，重新連線後仍保留
 	mutex     sync.RWMutex
-	jobQueue  *lockfree.Queue // Storing Unjudge job
 }
 
 var (
@@ -41,10 +98,12 @@ func GetSandboxScheduler() *SandboxScheduler {
 	schedulerOnce.Do(func() {
 		globalScheduler = &SandboxScheduler{
 			instances: make(map[string]*SandboxInstance),
-			jobQueue:  lockfree.NewQueue(),
+			cordoned:  make(map[string]bool),
 		}
 		// 啟動清理 goroutine
 		go globalScheduler.cleanupInactiveInstances()
+		// 啟動任務租約監控 goroutine（啟動時會先回收上次遺留的任務）
+		go globalScheduler.monitorJobLeases()
 		// 啟動任務隊列處理 goroutine
 		go globalScheduler.processJobQueue()
 	})
@@ -59,11 +118,20 @@ func (s *SandboxScheduler) SandboxStream(stream pb.SchedulerService_SandboxStrea
 	defer func() {
 		if instance != nil {
 			s.mutex.Lock()
-			instance.Active = false
-			close(instance.JobChan)
-			delete(s.instances, sandboxID)
+			instance.disconnect()
+			// 沙箱可能已重新連線或已被清理，只移除屬於此連線的實例
+			if current, ok := s.instances[sandboxID]; ok && current == instance {
+				delete(s.instances, sandboxID)
+			}
+			// 排空的沙箱斷線後即關閉，不會再以相同 ID 連線
+			if instance.Draining {
+				delete(s.cordoned, sandboxID)
+			}
 			s.mutex.Unlock()
 			utils.Infof("Sandbox %s disconnected", sandboxID)
+
+			// 回收此沙箱尚未完成的任務
+			orphanSandboxJobs(sandboxID)
 		}
 	}()
 
@@ -84,15 +152,23 @@ func (s *SandboxScheduler) SandboxStream(stream pb.SchedulerService_SandboxStrea
 			sandboxID = connectReq.SandboxId
 
 			instance = &SandboxInstance{
-				ID:       sandboxID,
-				Capacity: connectReq.Capacity,
-				LastSeen: time.Now(),
-				Active:   true,
-				Stream:   stream,
-				JobChan:  make(chan *pb.AddJobRequest, 100),
+				ID:         sandboxID,
+				Capacity:   connectReq.Capacity,
+				Labels:     connectReq.Labels,
+				Toolchains: connectReq.Toolchains,
+				LastSeen:   time.Now(),
+				Active:     true,
+				Stream:     stream,
+				JobChan:    make(chan *pb.AddJobRequest, 100),
+				Control:    make(chan *pb.SchedulerMessage, 100),
+				Done:       make(chan struct{}),
+
+				Version:     connectReq.Version,
+				ConnectedAt: time.Now(),
 			}
 
 			s.mutex.Lock()
+			instance.Cordoned = s.cordoned[sandboxID]
 			s.instances[sandboxID] = instance
 			s.mutex.Unlock()
 
@@ -112,7 +188,8 @@ func (s *SandboxScheduler) SandboxStream(stream pb.SchedulerService_SandboxStrea
 				return err
 			}
 
-			utils.Infof("Sandbox %s connected successfully", sandboxID)
+			utils.Infof("Sandbox %s connected successfully (version: %s, labels: %v, toolchains: %v)",
+				sandboxID, connectReq.Version, connectReq.Labels, connectReq.Toolchains)
 
 			// 立即請求狀態更新
 			statusRequest := &pb.SchedulerMessage{
@@ -134,11 +211,17 @@ func (s *SandboxScheduler) SandboxStream(stream pb.SchedulerService_SandboxStrea
 		case *pb.SandboxMessage_Status:
 			// 處理狀態更新
 			if instance != nil {
-				instance.Status = msgType.Status
-				instance.LastSeen = time.Now()
 				utils.Debugf("Received status from sandbox %s - Available: %d, Waiting: %d, Processing: %d, Total: %d",
 					sandboxID, msgType.Status.AvailableCount, msgType.Status.WaitingCount,
 					msgType.Status.ProcessingCount, msgType.Status.TotalCount)
+				// 調度器在持有鎖時讀取並調整狀態，更新時同樣需要持有鎖
+				s.mutex.Lock()
+				instance.Status = msgType.Status
+				instance.LastSeen = time.Now()
+				s.mutex.Unlock()
+
+				// 續約沙箱中尚未完成的任務
+				renewJobLeases(sandboxID, msgType.Status.RunningJobIds)
 			}
 
 		case *pb.SandboxMessage_JobResponse:
@@ -146,15 +229,63 @@ func (s *SandboxScheduler) SandboxStream(stream pb.SchedulerService_SandboxStrea
 			jobResp := msgType.JobResponse
 			utils.Infof("Job response from sandbox %s: Success=%t, Message=%s",
 				sandboxID, jobResp.Success, jobResp.Message)
+
+		case *pb.SandboxMessage_JobAck:
+			// 處理任務確認
+			ack := msgType.JobAck
+			if ack.Accepted {
+				if !markJobRunning(uint(ack.JobId), sandboxID) {
+					utils.Warnf("Sandbox %s acknowledged job %d which is no longer dispatched to it", sandboxID, ack.JobId)
+				}
+			} else {
+				utils.Warnf("Sandbox %s rejected job %d: %s", sandboxID, ack.JobId, ack.Message)
+				nackJob(uint(ack.JobId), sandboxID, ack.Message)
+			}
+
+		case *pb.SandboxMessage_JobProgress:
+			// 處理任務評測進度
+			recordJobProgress(sandboxID, msgType.JobProgress)
+
+		case *pb.SandboxMessage_JobResult:
+			// 處理評測結果，由 API Server 寫入資料庫
+			result := msgType.JobResult
+			utils.Infof("Job %d finished on sandbox %s: Success=%t, Score=%.2f, Time=%dms/%dms/%dms",
+				result.JobId, sandboxID, result.Success, result.Score,
+				result.CompileTimeMs, result.ExecuteTimeMs, result.ScoreTimeMs)
+			// 只統計仍屬於此沙箱的任務，被移除後才回報的結果不計入
+			if recordJobResult(sandboxID, result) && instance != nil {
+				s.mutex.Lock()
+				instance.Judged++
+				if !result.Success {
+					instance.Errors++
+				}
+				s.mutex.Unlock()
+			}
 		}
 	}
 
 	return nil
 }
 
-// sendJobsToSandbox 發送任務到沙箱
+// sendJobsToSandbox 發送任務與控制訊息到沙箱
 func (s *SandboxScheduler) sendJobsToSandbox(instance *SandboxInstance) {
-	for jobReq := range instance.JobChan {
+	// 結束時將尚未送出的任務立即放回隊列，不必等待租約過期
+	defer releaseBufferedJobs(instance)
+
+	for {
+		var jobReq *pb.AddJobRequest
+		select {
+		case <-instance.Done:
+			return
+		case req := <-instance.JobChan:
+			jobReq = req
+		case control := <-instance.Control:
+			if err := instance.Stream.Send(control); err != nil {
+				utils.Errorf("Failed to send control message to sandbox %s: %v", instance.ID, err)
+			}
+			continue
+		}
+
 		message := &pb.SchedulerMessage{
 			SandboxId: instance.ID,
 			MessageType: &pb.SchedulerMessage_JobRequest{
@@ -164,19 +295,40 @@ func (s *SandboxScheduler) sendJobsToSandbox(instance *SandboxInstance) {
 
 		if err := instance.Stream.Send(message); err != nil {
 			utils.Errorf("Failed to send job to sandbox %s: %v", instance.ID, err)
-			// 將任務放回隊列或標記為失敗
-			break
+			// 將任務放回隊列，等待重新派發
+			if err := releaseJob(uint(jobReq.JobId)); err != nil {
+				utils.Errorf("Failed to release job %d: %v", jobReq.JobId, err)
+			}
+			// 串流已失效，停止派發到此沙箱
+			s.mutex.Lock()
+			instance.disconnect()
+			s.mutex.Unlock()
+			return
 		}
 
 		utils.Debugf("Sent job to sandbox %s", instance.ID)
 	}
 }
 
-// GetBestSandbox 根據負載選擇最佳的沙箱實例
-func (s *SandboxScheduler) GetBestSandbox() *SandboxInstance {
+// releaseBufferedJobs 將任務通道中尚未送出的任務放回隊列，實例需已斷線
+func releaseBufferedJobs(instance *SandboxInstance) {
+	for {
+		select {
+		case jobReq := <-instance.JobChan:
+			if err := releaseJob(uint(jobReq.JobId)); err != nil {
+				utils.Errorf("Failed to release job %d: %v", jobReq.JobId, err)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// GetBestSandbox 在符合評測設定要求的沙箱中，根據負載選擇最佳的沙箱實例
+func (s *SandboxScheduler) GetBestSandbox(spec *pb.JudgeSpec) *SandboxInstance {
 	var candidates []*SandboxInstance
 	for _, instance := range s.instances {
-		if instance.Active && instance.Status != nil && instance.Status.AvailableCount > 0 {
+		if instance.Active && !instance.Cordoned && instance.Status != nil && instance.Status.AvailableCount > 0 && instance.Satisfies(spec) {
 			candidates = append(candidates, instance)
 		}
 	}
@@ -193,23 +345,147 @@ func (s *SandboxScheduler) GetBestSandbox() *SandboxInstance {
 	return candidates[0]
 }
 
-// ReserveJob 添加任務到最佳沙箱或隊列
-func (s *SandboxScheduler) ReserveJob(parentGitFullName string, gitRepoURL string, gitFullName string, gitAfterHash string, gitUsername string, gitToken string, userQuestionTableID uint64) error {
-	jobReq := &pb.AddJobRequest{
-		ParentGitFullName:   parentGitFullName,
-		GitRepoUrl:          gitRepoURL,
-		GitFullName:         gitFullName,
-		GitAfterHash:        gitAfterHash,
-		GitUsername:         gitUsername,
-		GitToken:            gitToken,
-		UserQuestionTableId: userQuestionTableID,
+// ReserveJob 將任務寫入持久化隊列，等待派發到沙箱
+func (s *SandboxScheduler) ReserveJob(parentGitFullName string, gitRepoURL string, gitFullName string, gitAfterHash string, gitUsername string, userQuestionTableID uint64) error {
+	job := &models.JudgeJob{
+		UQTID:             uint(userQuestionTableID),
+		ParentGitFullName: parentGitFullName,
+		GitRepoURL:        gitRepoURL,
+		GitFullName:       gitFullName,
+		GitAfterHash:      gitAfterHash,
+		GitUsername:       gitUsername,
+		Priority:          jobPriority(parentGitFullName, uint(userQuestionTableID)),
+	}
+
+	// 將任務加入資料庫隊列，重啟後仍可恢復
+	if err := enqueueJob(job); err != nil {
+		return err
 	}
 
-	// 將任務加入全局隊列
-	s.jobQueue.Enqueue(jobReq)
+	s.supersedeOlderJobs(job)
 	return nil
 }
 
+// ReserveRejudgeJob 將重新評測指定 commit 的任務寫入持久化隊列
+//
+// 重新評測不是新的推送，不會取代同一使用者較舊的任務，也不會被之後的推送取代。
+func (s *SandboxScheduler) ReserveRejudgeJob(parentGitFullName string, gitRepoURL string, gitFullName string, gitAfterHash string, gitUsername string, userQuestionTableID uint64) error {
+	return enqueueJob(&models.JudgeJob{
+		UQTID:             uint(userQuestionTableID),
+		ParentGitFullName: parentGitFullName,
+		GitRepoURL:        gitRepoURL,
+		GitFullName:       gitFullName,
+		GitAfterHash:      gitAfterHash,
+		GitUsername:       gitUsername,
+		Rejudge:           true,
+		Priority:          models.JudgeJobPriorityRejudge,
+	})
+}
+
+// CancelJob 取消提交尚未完成的評測，已派發的任務會通知沙箱中止並釋放沙箱
+//
+// 回傳被取消的任務數量。
+func (s *SandboxScheduler) CancelJob(uqtID uint, reason string) (int, error) {
+	var jobs []models.JudgeJob
+	if err := database.DBConn.
+		Where("uqt_id = ? AND status IN ?", uqtID, []models.JudgeJobStatus{
+			models.JudgeJobQueued, models.JudgeJobDispatched, models.JudgeJobRunning}).
+		Find(&jobs).Error; err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for i := range jobs {
+		ok, err := s.cancelJob(&jobs[i], reason)
+		if err != nil {
+			return cancelled, err
+		}
+		if ok {
+			cancelled++
+		}
+	}
+	return cancelled, nil
+}
+
+// supersedeOlderJobs 依題目設定處理同一使用者在此題較舊的任務：
+// 尚未派發的任務標記為被取代，仍在評測中的任務則取消
+func (s *SandboxScheduler) supersedeOlderJobs(job *models.JudgeJob) {
+	db := database.DBConn
+
+	var cmd models.QuestionTestScript
+	if err := db.Joins("Question").
+		Where("git_repo_url = ?", job.ParentGitFullName).Take(&cmd).Error; err != nil {
+		return
+	}
+
+	var statuses []models.JudgeJobStatus
+	if cmd.CoalesceQueued {
+		statuses = append(statuses, models.JudgeJobQueued)
+	}
+	if cmd.CancelSuperseded {
+		statuses = append(statuses, models.JudgeJobDispatched, models.JudgeJobRunning)
+	}
+	if len(statuses) == 0 {
+		return
+	}
+
+	var jobs []models.JudgeJob
+	if err := db.Joins("JOIN user_question_tables ON user_question_tables.id = judge_jobs.uqt_id").
+		Where("user_question_tables.uqr_id = (?)", db.Model(&models.UserQuestionTable{}).Select("uqr_id").Where("id = ?", job.UQTID)).
+		Where("judge_jobs.id < ? AND judge_jobs.status IN ? AND judge_jobs.rejudge = ?", job.ID, statuses, false).
+		Find(&jobs).Error; err != nil {
+		utils.Errorf("Failed to find jobs superseded by job %d: %v", job.ID, err)
+		return
+	}
+
+	reason := "Superseded by a newer submission"
+	if job.GitAfterHash != "" {
+		reason = fmt.Sprintf("Superseded by a newer submission (commit %s)", job.GitAfterHash)
+	}
+	for i := range jobs {
+		var err error
+		if jobs[i].Status == models.JudgeJobQueued {
+			_, err = supersedeQueuedJob(&jobs[i], reason)
+		} else {
+			_, err = s.cancelJob(&jobs[i], reason)
+		}
+		if err != nil {
+			utils.Errorf("Failed to supersede job %d: %v", jobs[i].ID, err)
+		}
+	}
+}
+
+// cancelJob 取消單一任務，任務已派發時通知持有的沙箱
+func (s *SandboxScheduler) cancelJob(job *models.JudgeJob, reason string) (bool, error) {
+	previous, err := cancelJudgeJob(job, reason)
+	if err != nil || previous == "" {
+		return false, err
+	}
+	utils.Infof("Cancelled job %d (submission %d): %s", job.ID, job.UQTID, reason)
+
+	if previous == models.JudgeJobQueued || job.SandboxID == "" {
+		return true, nil
+	}
+
+	// 沙箱連線在其他 API Server 時無法通知，沙箱回報的結果會因任務已取消而被忽略
+	s.mutex.RLock()
+	instance, ok := s.instances[job.SandboxID]
+	if ok && instance.Active {
+		select {
+		case instance.Control <- &pb.SchedulerMessage{
+			SandboxId: instance.ID,
+			MessageType: &pb.SchedulerMessage_CancelJob{
+				CancelJob: &pb.CancelJob{JobId: uint64(job.ID), Reason: reason},
+			},
+		}:
+		default:
+			utils.Warnf("Control queue of sandbox %s is full, job %d keeps running until it finishes", instance.ID, job.ID)
+		}
+	}
+	s.mutex.RUnlock()
+	return true, nil
+}
+
 // GetGlobalStatus 獲取所有沙箱的全局狀態
 func (s *SandboxScheduler) GetGlobalStatus() *pb.SandboxStatusResponse {
 	s.mutex.RLock()
@@ -219,7 +495,9 @@ func (s *SandboxScheduler) GetGlobalStatus() *pb.SandboxStatusResponse {
 
 	for _, instance := range s.instances {
 		if instance.Active && instance.Status != nil {
-			totalAvailable += instance.Status.AvailableCount
+			if !instance.Cordoned {
+				totalAvailable += instance.Status.AvailableCount
+			}
 			totalWaiting += instance.Status.WaitingCount
 			totalProcessing += instance.Status.ProcessingCount
 			totalCount += instance.Status.TotalCount
@@ -227,7 +505,7 @@ func (s *SandboxScheduler) GetGlobalStatus() *pb.SandboxStatusResponse {
 	}
 
 	// 加上隊列中的任務數量到等待計數
-	totalWaiting += int32(s.jobQueue.Length())
+	totalWaiting += int32(countQueuedJobs())
 
 	return &pb.SandboxStatusResponse{
 		AvailableCount:  totalAvailable,
@@ -237,6 +515,11 @@ func (s *SandboxScheduler) GetGlobalStatus() *pb.SandboxStatusResponse {
 	}
 }
 
+// GetQueueStats 獲取隊列中各優先等級的統計
+func (s *SandboxScheduler) GetQueueStats() []QueuePriorityStats {
+	return queueStats()
+}
+
 // GetActiveInstanceCount 獲取活躍實例數量
 func (s *SandboxScheduler) GetActiveInstanceCount() int {
 	s.mutex.RLock()
@@ -270,7 +553,7 @@ func (s *SandboxScheduler) cleanupInactiveInstances() {
 				// 如果超過 5 分鐘沒有狀態更新，完全移除
 				if now.Sub(instance.LastSeen) > 5*time.Minute {
 					utils.Infof("Removing inactive sandbox %s", id)
-					close(instance.JobChan)
+					instance.disconnect()
 					delete(s.instances, id)
 				}
 			}
@@ -285,49 +568,122 @@ func (s *SandboxScheduler) Close() {
 	defer s.mutex.Unlock()
 
 	for _, instance := range s.instances {
-		close(instance.JobChan)
+		instance.disconnect()
 	}
 	s.instances = make(map[string]*SandboxInstance)
 }
 
+// monitorJobLeases 定期回收租約過期的任務
+func (s *SandboxScheduler) monitorJobLeases() {
+	requeueExpiredJobs()
+
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		requeueExpiredJobs()
+	}
+}
+
+// availableSlots 獲取所有活躍沙箱的可用容量總和
+func (s *SandboxScheduler) availableSlots() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	total := 0
+	for _, instance := range s.instances {
+		if instance.Active && !instance.Cordoned && instance.Status != nil && instance.Status.AvailableCount > 0 {
+			total += int(instance.Status.AvailableCount)
+		}
+	}
+	return total
+}
+
 // processJobQueue 處理任務隊列中的任務
 func (s *SandboxScheduler) processJobQueue() {
 	ticker := time.NewTicker(300 * time.Millisecond)
 	defer ticker.Stop()
 
 	for range ticker.C {
-		// 檢查是否有待處理的任務
-		for {
-			jobReq := s.jobQueue.Dequeue()
-			if jobReq == nil {
-				break // 隊列為空，退出內層循環
-			}
+		// 沒有可用的沙箱時不查詢資料庫
+		available := s.availableSlots()
+		if available == 0 {
+			continue
+		}
 
-			// 轉換回正確的類型
-			if addJobReq, ok := jobReq.(*pb.AddJobRequest); ok {
-				// 嘗試分配任務到可用的沙箱
-				if err := s.assignJobToSandbox(addJobReq); err != nil {
-					// 如果無法分配，重新放回隊列
-					s.jobQueue.Enqueue(addJobReq)
-
-					// utils.Debugf("Job reassigned to queue: %v", err)
-					break // 退出內層循環，等待下次檢查
-				} else {
-					utils.Infof("Job from queue assigned successfully (parentGitFullName: %s, userQuestionTableId: %d)",
-						addJobReq.ParentGitFullName, addJobReq.UserQuestionTableId)
-				}
+		jobs, err := fetchQueuedJobs(available + queueLookahead)
+		if err != nil {
+			utils.Errorf("Failed to fetch queued jobs: %v", err)
+			continue
+		}
+
+		for i := range jobs {
+			assigned, err := s.dispatchJob(&jobs[i])
+			if errors.Is(err, errNoMatchingSandbox) {
+				continue // 此任務需要其他沙箱，繼續嘗試後面的任務
+			}
+			if err != nil {
+				// utils.Debugf("Job kept in queue: %v", err)
+				break // 退出內層循環，等待下次檢查
+			}
+			if assigned {
+				utils.Infof("Job from queue assigned successfully (parentGitFullName: %s, userQuestionTableId: %d)",
+					jobs[i].ParentGitFullName, jobs[i].UQTID)
 			}
 		}
 	}
 }
 
+// dispatchJob 準備任務請求並派發到沙箱
+func (s *SandboxScheduler) dispatchJob(job *models.JudgeJob) (bool, error) {
+	if job.Attempts >= maxJobAttempts {
+		failJob(job, exhaustedMessage(job))
+		return false, nil
+	}
+
+	// Token 不寫入資料庫，派發時才取得
+	token, err := utils.GetTokenByUsername(job.GitUsername)
+	if err != nil {
+		failJob(job, fmt.Sprintf("Failed to get token: %v", err))
+		return false, nil
+	}
+
+	// 評測設定由 API Server 讀取後下發，沙箱不需連線資料庫
+	spec, err := loadJudgeSpec(job.ParentGitFullName)
+	if err != nil {
+		failJob(job, fmt.Sprintf("Failed to find shell command for %v: %v", job.ParentGitFullName, err))
+		return false, nil
+	}
+	if err := applyExtensionLimits(spec, job.UQTID); err != nil {
+		utils.Warnf("Failed to apply extension limits to job %d: %v", job.ID, err)
+	}
+
+	jobReq := &pb.AddJobRequest{
+		ParentGitFullName:   job.ParentGitFullName,
+		GitRepoUrl:          job.GitRepoURL,
+		GitFullName:         job.GitFullName,
+		GitAfterHash:        job.GitAfterHash,
+		GitUsername:         job.GitUsername,
+		GitToken:            token,
+		UserQuestionTableId: uint64(job.UQTID),
+		JobId:               uint64(job.ID),
+		Spec:                spec,
+	}
+
+	return s.assignJobToSandbox(job, jobReq)
+}
+
 // assignJobToSandbox 將任務分配給可用的沙箱
-func (s *SandboxScheduler) assignJobToSandbox(jobReq *pb.AddJobRequest) error {
+func (s *SandboxScheduler) assignJobToSandbox(job *models.JudgeJob, jobReq *pb.AddJobRequest) (bool, error) {
 	s.mutex.Lock()
-	instance := s.GetBestSandbox()
+	instance := s.GetBestSandbox(jobReq.Spec)
 	if instance == nil {
+		pendingReason, err := s.routingError(jobReq.Spec)
 		s.mutex.Unlock()
-		return fmt.Errorf("no available sandbox instances")
+		if pendingReason != "" {
+			markJobUnroutable(job, pendingReason)
+		}
+		return false, err
 	}
 
 	// 更新沙箱狀態
@@ -339,19 +695,87 @@ func (s *SandboxScheduler) assignJobToSandbox(jobReq *pb.AddJobRequest) error {
 	}
 	s.mutex.Unlock()
 
+	// 在資料庫中佔用任務，若已被其他 API Server 派發則跳過
+	claimed, err := claimJob(job.ID, instance.ID)
+	if err != nil || !claimed {
+		s.rollbackAssignment(instance)
+		return false, err
+	}
+
+	// 佔用任務期間沙箱可能已斷線或被移除，持有鎖確認後才放入任務通道
+	s.mutex.Lock()
+	connected := instance.Active
+	select {
+	case <-instance.Done:
+		connected = false
+	default:
+	}
+	if !connected {
+		s.mutex.Unlock()
+		s.rollbackAssignment(instance)
+		if err := releaseJob(job.ID); err != nil {
+			utils.Errorf("Failed to release job %d: %v", job.ID, err)
+		}
+		return false, fmt.Errorf("sandbox %s disconnected", instance.ID)
+	}
+
 	// 非阻塞發送到任務通道
 	select {
 	case instance.JobChan <- jobReq:
+		s.mutex.Unlock()
 		utils.Debugf("Job from queue assigned to sandbox %s", instance.ID)
-		return nil
+		return true, nil
 	default:
+		s.mutex.Unlock()
 		// 如果任務無法加入隊列，需要回滾之前的假設
-		s.mutex.Lock()
-		if instance.Status != nil {
-			instance.Status.WaitingCount--
-			instance.Status.AvailableCount++
+		s.rollbackAssignment(instance)
+		if err := releaseJob(job.ID); err != nil {
+			utils.Errorf("Failed to release job %d: %v", job.ID, err)
 		}
-		s.mutex.Unlock()
-		return fmt.Errorf("sandbox %s job queue is full", instance.ID)
+		return false, fmt.Errorf("sandbox %s job queue is full", instance.ID)
+	}
+}
+
+// routingError 判斷任務無法派發的原因，呼叫時需持有鎖
+//
+// 沒有任何連線中的沙箱符合要求時，另外回傳顯示給使用者的等待原因。
+func (s *SandboxScheduler) routingError(spec *pb.JudgeSpec) (string, error) {
+	anyAvailable, anyMatching := false, false
+	for _, instance := range s.instances {
+		if !instance.Active {
+			continue
+		}
+		if !instance.Cordoned && instance.Status != nil && instance.Status.AvailableCount > 0 {
+			anyAvailable = true
+		}
+		if instance.Satisfies(spec) {
+			anyMatching = true
+		}
+	}
+
+	pendingReason := ""
+	if !anyMatching && (len(spec.GetRequiredLabels()) > 0 || len(spec.GetRequiredToolchains()) > 0) {
+		var required []string
+		if labels := spec.GetRequiredLabels(); len(labels) > 0 {
+			required = append(required, "labels: "+strings.Join(labels, ", "))
+		}
+		if toolchains := spec.GetRequiredToolchains(); len(toolchains) > 0 {
+			required = append(required, "toolchains: "+strings.Join(toolchains, ", "))
+		}
+		pendingReason = "Waiting for a sandbox with " + strings.Join(required, "; ")
+	}
+	if anyAvailable {
+		return pendingReason, errNoMatchingSandbox
+	}
+	return pendingReason, errNoAvailableSandbox
+}
+
+// rollbackAssignment 回滾分配任務時對沙箱狀態的假設
+func (s *SandboxScheduler) rollbackAssignment(instance *SandboxInstance) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if instance.Status != nil {
+		instance.Status.WaitingCount--
+		instance.Status.AvailableCount++
 	}
 }
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	pb "OJ-API/proto"
+)
+
+func newTestScheduler() *SandboxScheduler {
+	return &SandboxScheduler{
+		instances: make(map[string]*SandboxInstance),
+		cordoned:  make(map[string]bool),
+	}
+}
+
+// addTestSandbox 加入一個連線中的沙箱，available 為可用的評測槽數
+func addTestSandbox(s *SandboxScheduler, id string, available int32, labels []string, toolchains []string) {
+	s.instances[id] = &SandboxInstance{
+		ID:         id,
+		Capacity:   4,
+		Labels:     labels,
+		Toolchains: toolchains,
+		Status:     &pb.SandboxStatusResponse{AvailableCount: available, TotalCount: 4},
+		Active:     true,
+	}
+}
+
+func TestSandboxSatisfies(t *testing.T) {
+	cases := []struct {
+		name       string
+		labels     []string
+		toolchains []string
+		spec       *pb.JudgeSpec
+		want       bool
+	}{
+		{name: "no requirements", spec: &pb.JudgeSpec{}, want: true},
+		{name: "required labels", labels: []string{"gpu", "x86"}, spec: &pb.JudgeSpec{RequiredLabels: []string{"gpu"}}, want: true},
+		{name: "missing label", labels: []string{"x86"}, spec: &pb.JudgeSpec{RequiredLabels: []string{"gpu"}}, want: false},
+		{name: "required toolchains", toolchains: []string{"g++", "go"}, spec: &pb.JudgeSpec{RequiredToolchains: []string{"go"}}, want: true},
+		{name: "missing toolchain", toolchains: []string{"g++"}, spec: &pb.JudgeSpec{RequiredToolchains: []string{"go"}}, want: false},
+		{name: "toolchains not reported", spec: &pb.JudgeSpec{RequiredToolchains: []string{"go"}}, want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := &SandboxInstance{Labels: tc.labels, Toolchains: tc.toolchains}
+			if got := instance.Satisfies(tc.spec); got != tc.want {
+				t.Errorf("Satisfies() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetBestSandbox(t *testing.T) {
+	s := newTestScheduler()
+	addTestSandbox(s, "idle", 4, nil, nil)
+	addTestSandbox(s, "gpu-busy", 1, []string{"gpu"}, nil)
+	addTestSandbox(s, "gpu-idle", 3, []string{"gpu"}, nil)
+	addTestSandbox(s, "gpu-full", 0, []string{"gpu"}, nil)
+	addTestSandbox(s, "gpu-offline", 4, []string{"gpu"}, nil)
+	s.instances["gpu-offline"].Active = false
+
+	cases := []struct {
+		name string
+		spec *pb.JudgeSpec
+		want string // empty if no sandbox is chosen
+	}{
+		{name: "most available sandbox", spec: &pb.JudgeSpec{}, want: "idle"},
+		{name: "most available matching sandbox", spec: &pb.JudgeSpec{RequiredLabels: []string{"gpu"}}, want: "gpu-idle"},
+		{name: "no matching sandbox", spec: &pb.JudgeSpec{RequiredLabels: []string{"arm"}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := s.GetBestSandbox(tc.spec)
+			switch {
+			case tc.want == "" && got != nil:
+				t.Errorf("GetBestSandbox() = %s, want none", got.ID)
+			case tc.want != "" && (got == nil || got.ID != tc.want):
+				t.Errorf("GetBestSandbox() = %v, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRoutingError(t *testing.T) {
+	cases := []struct {
+		name       string
+		sandboxes  map[string]int32 // available slots of sandboxes labelled by their name
+		spec       *pb.JudgeSpec
+		wantErr    error
+		wantReason string
+	}{
+		{name: "no sandbox", spec: &pb.JudgeSpec{}, wantErr: errNoAvailableSandbox},
+		{name: "matching sandbox is busy", sandboxes: map[string]int32{"gpu": 0}, spec: &pb.JudgeSpec{RequiredLabels: []string{"gpu"}}, wantErr: errNoAvailableSandbox},
+		{
+			name:       "no sandbox has the label",
+			sandboxes:  map[string]int32{"x86": 4},
+			spec:       &pb.JudgeSpec{RequiredLabels: []string{"gpu"}},
+			wantErr:    errNoMatchingSandbox,
+			wantReason: "Waiting for a sandbox with labels: gpu",
+		},
+		{
+			name:       "no sandbox has the label or toolchain",
+			spec:       &pb.JudgeSpec{RequiredLabels: []string{"gpu"}, RequiredToolchains: []string{"rustc"}},
+			wantErr:    errNoAvailableSandbox,
+			wantReason: "Waiting for a sandbox with labels: gpu; toolchains: rustc",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := newTestScheduler()
+			for label, available := range tc.sandboxes {
+				addTestSandbox(s, label, available, []string{label}, nil)
+			}
+			reason, err := s.routingError(tc.spec)
+			if !errors.Is(err, tc.wantErr) || reason != tc.wantReason {
+				t.Errorf("routingError() = %q, %v, want %q, %v", reason, err, tc.wantReason, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"OJ-API/models"
+)
+
+// FinalScores 依計分政策取得每個 UQR 的最終成績，所有排行榜與成績匯出皆以此計算
+//
+// 題目所屬考試有設定計分政策時優先使用考試的設定，否則使用題目的設定：
+//   - best：最高分
+//   - last：最後一次有效評測
+//   - last_before_deadline：截止時間（含延長期限與寬限期）前的最後一次有效評測，沒有截止時間時同 last
+//   - top_k_average：最高的 k 次分數平均，不足 k 次時以現有次數平均
+//
+// 只有分數不小於 0 的提交視為有效評測，沒有有效評測的 UQR 成績為 0；被重新評測取代（superseded_by）的紀錄不列入計算。
+// 有生效中的成績調整（ScoreOverride）時，score 改為調整後的分數（指定分數或加減分，不低於 0），
+// judged_score 保留評測結果計算出的分數；沒有任何提交的 UQR 只在有成績調整時列出。
+// questionIDs 不為 nil 時只計算這些題目。
+// 回傳子查詢的欄位：uqr_id、user_id、question_id、uqt_id（沒有有效評測時為 NULL）、score、judged_score、
+// override_id（沒有成績調整時為 NULL）、submitted_at。
+func FinalScores(db *gorm.DB, questionIDs *gorm.DB) *gorm.DB {
+	filter := "TRUE"
+	if questionIDs != nil {
+		filter = "UQR.question_id IN (@question_ids)"
+	}
+
+	return db.Raw(`
+	WITH candidates AS (
+		SELECT
+			UQR.id AS uqr_id,
+			UQR.user_id,
+			UQR.question_id,
+			UQT.id AS uqt_id,
+			UQT.score,
+			UQT.created_at,
+			P.policy,
+			P.top_k,
+			COALESCE(UQT.score >= 0 AND (P.policy <> @last_before_deadline OR D.end_time IS NULL OR D.end_time <= @no_deadline OR
+				UQT.created_at <= D.end_time + COALESCE(S.late_grace_period, 0) * INTERVAL '1 minute'), FALSE) AS valid
+		FROM user_question_relations UQR
+		LEFT JOIN user_question_tables UQT ON UQT.uqr_id = UQR.id AND UQT.superseded_by IS NULL
+		JOIN questions Q ON Q.id = UQR.question_id
+		LEFT JOIN question_test_scripts S ON S.question_id = Q.id
+		LEFT JOIN LATERAL (
+			SELECT E.score_policy, E.score_policy_top_k
+			FROM exams E JOIN exam_questions EQ ON EQ.exam_id = E.id
+			WHERE EQ.question_id = Q.id AND E.score_policy <> ''
+			ORDER BY E.id LIMIT 1
+		) E ON TRUE
+		CROSS JOIN LATERAL (
+			SELECT `+EffectiveEndTimeSQL("UQR.user_id", "Q")+` AS end_time
+		) D
+		CROSS JOIN LATERAL (
+			SELECT
+				COALESCE(E.score_policy, S.score_policy, @best) AS policy,
+				GREATEST(COALESCE(E.score_policy_top_k, S.score_policy_top_k, 1), 1) AS top_k
+		) P
+		WHERE `+filter+` AND (UQT.id IS NOT NULL OR EXISTS (
+			SELECT 1 FROM score_overrides O
+			WHERE O.user_id = UQR.user_id AND O.question_id = UQR.question_id AND O.revoked_at IS NULL
+		))
+	), ranked AS (
+		SELECT *,
+			ROW_NUMBER() OVER (PARTITION BY uqr_id ORDER BY valid DESC, score DESC, created_at ASC) AS best_rank,
+			ROW_NUMBER() OVER (PARTITION BY uqr_id ORDER BY valid DESC, created_at DESC, uqt_id DESC) AS last_rank
+		FROM candidates
+	), scored AS (
+		SELECT *,
+			AVG(score) FILTER (WHERE valid AND best_rank <= top_k) OVER (PARTITION BY uqr_id) AS top_k_score
+		FROM ranked
+	), final AS (
+		SELECT
+			uqr_id,
+			user_id,
+			question_id,
+			CASE WHEN valid THEN uqt_id END AS uqt_id,
+			CASE WHEN NOT valid THEN 0 WHEN policy = @top_k_average THEN top_k_score ELSE score END AS score,
+			created_at AS submitted_at
+		FROM scored
+		WHERE (policy IN @last AND last_rank = 1) OR (policy NOT IN @last AND best_rank = 1)
+	)
+	SELECT
+		F.uqr_id,
+		F.user_id,
+		F.question_id,
+		F.uqt_id,
+		CASE WHEN O.id IS NULL THEN F.score ELSE GREATEST(COALESCE(O.score, F.score + O.delta), 0) END AS score,
+		F.score AS judged_score,
+		O.id AS override_id,
+		F.submitted_at
+	FROM final F
+	LEFT JOIN score_overrides O ON O.user_id = F.user_id AND O.question_id = F.question_id AND O.revoked_at IS NULL
+	`, map[string]interface{}{
+		"question_ids":         questionIDs,
+		"best":                 models.ScorePolicyBest,
+		"last_before_deadline": models.ScorePolicyLastBeforeDeadline,
+		"no_deadline":          time.Time{},
+		"top_k_average":        models.ScorePolicyTopKAverage,
+		"last":                 []models.ScorePolicy{models.ScorePolicyLast, models.ScorePolicyLastBeforeDeadline},
+	})
+}
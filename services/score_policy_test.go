@@ -0,0 +1,381 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"OJ-API/database/dbtest"
+	"OJ-API/models"
+)
+
+// openScoreTestDB 建立計算最終成績所需的資料表
+func openScoreTestDB(t *testing.T) *gorm.DB {
+	return dbtest.Open(t,
+		&models.User{},
+		&models.Exam{},
+		&models.Question{},
+		&models.ExamQuestion{},
+		&models.QuestionTestScript{},
+		&models.UserQuestionRelation{},
+		&models.RejudgeBatch{},
+		&models.UserQuestionTable{},
+		&models.Extension{},
+		&models.ScoreOverride{},
+	)
+}
+
+// seedQuestion 建立一位使用者與一題指定計分政策的題目
+func seedQuestion(t *testing.T, tx *gorm.DB, policy models.ScorePolicy, topK uint) models.UserQuestionRelation {
+	t.Helper()
+	uqr := seedUserQuestion(t, tx)
+	dbtest.Create(t, tx, &models.QuestionTestScript{QuestionID: uqr.QuestionID, ScorePolicy: policy, ScorePolicyTopK: topK})
+	return uqr
+}
+
+// seedSubmission 建立一筆提交紀錄
+func seedSubmission(t *testing.T, tx *gorm.DB, uqt models.UserQuestionTable) models.UserQuestionTable {
+	t.Helper()
+	uqt.JudgeTime = time.Now().UTC()
+	dbtest.Create(t, tx, &uqt)
+	return uqt
+}
+
+type finalScoreRow struct {
+	UQTID       *uint
+	Score       float64
+	JudgedScore float64
+	OverrideID  *uint
+}
+
+// finalScores 取得題目的最終成績
+func finalScores(t *testing.T, tx *gorm.DB, questionID uint) []finalScoreRow {
+	t.Helper()
+	var rows []finalScoreRow
+	questionIDs := tx.Model(&models.Question{}).Select("id").Where("id = ?", questionID)
+	if err := tx.Table("(?) AS fs", FinalScores(tx, questionIDs)).Scan(&rows).Error; err != nil {
+		t.Fatalf("failed to query final scores: %v", err)
+	}
+	return rows
+}
+
+func TestFinalScoresPolicies(t *testing.T) {
+	tx := openScoreTestDB(t)
+
+	type push struct {
+		score float64
+		at    int // minutes relative to the deadline of the question
+	}
+	pushes := []push{{60, -30}, {90, -20}, {80, -10}}
+
+	cases := []struct {
+		name       string
+		policy     models.ScorePolicy
+		topK       uint
+		examPolicy models.ScorePolicy
+		grace      uint // late grace period in minutes
+		extension  int  // minutes the deadline of the user is extended by, 0 for none
+		noDeadline bool // the question has no end time
+		pushes     []push
+		want       float64
+		wantUQT    bool
+	}{
+		{name: "best", policy: models.ScorePolicyBest, pushes: pushes, want: 90, wantUQT: true},
+		{name: "last", policy: models.ScorePolicyLast, pushes: pushes, want: 80, wantUQT: true},
+		{
+			name:    "last skips system errors",
+			policy:  models.ScorePolicyLast,
+			pushes:  []push{{60, -30}, {80, -20}, {-2, -10}},
+			want:    80,
+			wantUQT: true,
+		},
+		{
+			name:    "last before deadline",
+			policy:  models.ScorePolicyLastBeforeDeadline,
+			pushes:  []push{{60, -30}, {70, -10}, {95, 10}},
+			want:    70,
+			wantUQT: true,
+		},
+		{
+			name:    "last before deadline with grace period",
+			policy:  models.ScorePolicyLastBeforeDeadline,
+			grace:   15,
+			pushes:  []push{{60, -30}, {70, -10}, {95, 10}},
+			want:    95,
+			wantUQT: true,
+		},
+		{
+			name:      "last before extended deadline",
+			policy:    models.ScorePolicyLastBeforeDeadline,
+			extension: 20,
+			pushes:    []push{{60, -30}, {70, -10}, {95, 10}},
+			want:      95,
+			wantUQT:   true,
+		},
+		{
+			name:       "last before deadline without a deadline",
+			policy:     models.ScorePolicyLastBeforeDeadline,
+			noDeadline: true,
+			pushes:     []push{{60, -30}, {70, -10}, {95, 10}},
+			want:       95,
+			wantUQT:    true,
+		},
+		{name: "top k average", policy: models.ScorePolicyTopKAverage, topK: 2, pushes: pushes, want: 85, wantUQT: true},
+		{
+			name:    "top k average with fewer pushes",
+			policy:  models.ScorePolicyTopKAverage,
+			topK:    3,
+			pushes:  []push{{60, -30}, {90, -20}},
+			want:    75,
+			wantUQT: true,
+		},
+		{
+			name:       "exam policy takes precedence",
+			policy:     models.ScorePolicyBest,
+			examPolicy: models.ScorePolicyLast,
+			pushes:     pushes,
+			want:       80,
+			wantUQT:    true,
+		},
+		{
+			name:   "no valid judge",
+			policy: models.ScorePolicyBest,
+			pushes: []push{{-2, -30}, {-4, -20}},
+			want:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uqr := seedQuestion(t, tx, tc.policy, max(tc.topK, 1))
+
+			deadline := time.Now().UTC().Truncate(time.Second)
+			endTime := deadline
+			if tc.noDeadline {
+				endTime = time.Time{}
+			}
+			if err := tx.Model(&models.Question{ID: uqr.QuestionID}).Update("end_time", endTime).Error; err != nil {
+				t.Fatalf("failed to set the deadline: %v", err)
+			}
+			if err := tx.Model(&models.QuestionTestScript{}).Where("question_id = ?", uqr.QuestionID).
+				Update("late_grace_period", tc.grace).Error; err != nil {
+				t.Fatalf("failed to set the grace period: %v", err)
+			}
+			if tc.extension != 0 {
+				end := deadline.Add(time.Duration(tc.extension) * time.Minute)
+				dbtest.Create(t, tx, &models.Extension{UserID: uqr.UserID, QuestionID: &uqr.QuestionID, EndTime: &end, CreatedBy: uqr.UserID})
+			}
+			if tc.examPolicy != "" {
+				exam := models.Exam{OwnerID: uqr.UserID, Title: "Midterm", ScorePolicy: tc.examPolicy, ScorePolicyTopK: 1}
+				dbtest.Create(t, tx, &exam)
+				dbtest.Create(t, tx, &models.ExamQuestion{ExamID: exam.ID, QuestionID: uqr.QuestionID, Point: 100})
+			}
+			for i, p := range tc.pushes {
+				seedSubmission(t, tx, models.UserQuestionTable{
+					UQRID:     uqr.ID,
+					Score:     p.score,
+					Commit:    fmt.Sprintf("%040d", i+1),
+					CreatedAt: deadline.Add(time.Duration(p.at) * time.Minute),
+				})
+			}
+
+			rows := finalScores(t, tx, uqr.QuestionID)
+			if len(rows) != 1 {
+				t.Fatalf("got %d final scores, want 1", len(rows))
+			}
+			if rows[0].Score != tc.want {
+				t.Errorf("final score = %v, want %v", rows[0].Score, tc.want)
+			}
+			if (rows[0].UQTID != nil) != tc.wantUQT {
+				t.Errorf("uqt_id = %v, want a submission: %t", rows[0].UQTID, tc.wantUQT)
+			}
+		})
+	}
+}
+
+func TestFinalScoresRejudge(t *testing.T) {
+	tx := openScoreTestDB(t)
+
+	type rejudge struct {
+		of    int // index of the rejudged push
+		score float64
+	}
+	cases := []struct {
+		name     string
+		policy   models.ScorePolicy
+		topK     uint
+		pushes   []float64 // scores of the pushes, one minute apart
+		rejudges []rejudge // created in this order
+		finish   []int     // indexes of the rejudges in the order they finish, nil for creation order
+		want     float64
+	}{
+		{
+			name:     "lower rejudge result replaces the old score",
+			policy:   models.ScorePolicyBest,
+			pushes:   []float64{90},
+			rejudges: []rejudge{{0, 70}},
+			want:     70,
+		},
+		{
+			name:     "rejudged push loses to another push",
+			policy:   models.ScorePolicyBest,
+			pushes:   []float64{90, 60},
+			rejudges: []rejudge{{0, 40}},
+			want:     60,
+		},
+		{
+			name:     "top k does not count a rejudged push twice",
+			policy:   models.ScorePolicyTopKAverage,
+			topK:     2,
+			pushes:   []float64{90, 50},
+			rejudges: []rejudge{{0, 70}},
+			want:     60,
+		},
+		{
+			name:     "failed rejudge keeps the old score",
+			policy:   models.ScorePolicyBest,
+			pushes:   []float64{90},
+			rejudges: []rejudge{{0, -2}},
+			want:     90,
+		},
+		{
+			name:     "newest rejudge wins when it finishes first",
+			policy:   models.ScorePolicyBest,
+			pushes:   []float64{90},
+			rejudges: []rejudge{{0, 80}, {0, 30}},
+			finish:   []int{1, 0},
+			want:     30,
+		},
+		{
+			name:     "rejudge keeps the push time",
+			policy:   models.ScorePolicyLast,
+			pushes:   []float64{90, 50},
+			rejudges: []rejudge{{0, 100}},
+			want:     50,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uqr := seedQuestion(t, tx, tc.policy, max(tc.topK, 1))
+
+			start := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+			pushes := make([]models.UserQuestionTable, len(tc.pushes))
+			for i, score := range tc.pushes {
+				pushes[i] = seedSubmission(t, tx, models.UserQuestionTable{
+					UQRID:     uqr.ID,
+					Score:     score,
+					Commit:    fmt.Sprintf("%040d", i+1),
+					CreatedAt: start.Add(time.Duration(i) * time.Minute),
+				})
+			}
+
+			// 重新評測先進入隊列，完成時才寫入分數
+			rejudges := make([]models.UserQuestionTable, len(tc.rejudges))
+			for i, r := range tc.rejudges {
+				source := pushes[r.of]
+				rejudges[i] = seedSubmission(t, tx, models.UserQuestionTable{
+					UQRID:     uqr.ID,
+					Score:     -3,
+					Commit:    source.Commit,
+					CreatedAt: source.CreatedAt,
+					IsRejudge: true,
+				})
+			}
+			finish := tc.finish
+			if finish == nil {
+				for i := range tc.rejudges {
+					finish = append(finish, i)
+				}
+			}
+			for _, i := range finish {
+				score := tc.rejudges[i].score
+				if err := tx.Model(&rejudges[i]).Update("score", score).Error; err != nil {
+					t.Fatalf("failed to record rejudge: %v", err)
+				}
+				if score >= 0 {
+					if err := supersedeJudgedPush(tx, rejudges[i]); err != nil {
+						t.Fatalf("supersedeJudgedPush: %v", err)
+					}
+				}
+			}
+
+			rows := finalScores(t, tx, uqr.QuestionID)
+			if len(rows) != 1 {
+				t.Fatalf("got %d final scores, want 1", len(rows))
+			}
+			if rows[0].Score != tc.want {
+				t.Errorf("final score = %v, want %v", rows[0].Score, tc.want)
+			}
+		})
+	}
+}
+
+func TestFinalScoresOverrides(t *testing.T) {
+	tx := openScoreTestDB(t)
+
+	cases := []struct {
+		name       string
+		pushes     []float64
+		score      *float64
+		delta      float64
+		revoked    bool
+		wantRows   int
+		want       float64
+		wantJudged float64
+	}{
+		{name: "override score", pushes: []float64{60}, score: float(80), wantRows: 1, want: 80, wantJudged: 60},
+		{name: "delta beyond full marks", pushes: []float64{60, 95}, delta: 10, wantRows: 1, want: 105, wantJudged: 95},
+		{name: "negative delta stops at zero", pushes: []float64{30}, delta: -50, wantRows: 1, want: 0, wantJudged: 30},
+		{name: "revoked override", pushes: []float64{60}, score: float(80), revoked: true, wantRows: 1, want: 60, wantJudged: 60},
+		{name: "override without submissions", score: float(70), wantRows: 1, want: 70, wantJudged: 0},
+		{name: "delta without submissions", delta: 5, wantRows: 1, want: 5, wantJudged: 0},
+		{name: "revoked override without submissions", score: float(70), revoked: true, wantRows: 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uqr := seedQuestion(t, tx, models.ScorePolicyBest, 1)
+			for i, score := range tc.pushes {
+				seedSubmission(t, tx, models.UserQuestionTable{
+					UQRID:  uqr.ID,
+					Score:  score,
+					Commit: fmt.Sprintf("%040d", i+1),
+				})
+			}
+			override := models.ScoreOverride{
+				UserID:     uqr.UserID,
+				QuestionID: uqr.QuestionID,
+				Score:      tc.score,
+				Delta:      tc.delta,
+				Reason:     "Test case 3 had a wrong expected output",
+				CreatedBy:  uqr.UserID,
+			}
+			if tc.revoked {
+				now := time.Now().UTC()
+				override.RevokedAt, override.RevokedBy = &now, &uqr.UserID
+			}
+			dbtest.Create(t, tx, &override)
+
+			rows := finalScores(t, tx, uqr.QuestionID)
+			if len(rows) != tc.wantRows {
+				t.Fatalf("got %d final scores, want %d", len(rows), tc.wantRows)
+			}
+			if tc.wantRows == 0 {
+				return
+			}
+			if rows[0].Score != tc.want || rows[0].JudgedScore != tc.wantJudged {
+				t.Errorf("score/judged = %v/%v, want %v/%v", rows[0].Score, rows[0].JudgedScore, tc.want, tc.wantJudged)
+			}
+			if (rows[0].OverrideID != nil) == tc.revoked {
+				t.Errorf("override_id = %v, want an override: %t", rows[0].OverrideID, !tc.revoked)
+			}
+		})
+	}
+}
+
+func float(v float64) *float64 {
+	return &v
+}
@@ -1,7 +1,9 @@
 package utils
 
 import (
+	"OJ-API/models"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,10 +11,28 @@ import (
 )
 
 type ExportQuestionScoreResponse struct {
-	UserName               string    `json:"user_name"`
-	GitUserRepoURL         string    `json:"git_user_repo_url"`
-	Score                  float64   `json:"score"`
-	EarliestBestSubmitTime time.Time `json:"earliest_best_submit_time"`
+	UserName               string                   `json:"user_name"`
+	GitUserRepoURL         string                   `json:"git_user_repo_url"`
+	Score                  float64                  `json:"score"`
+	JudgedScore            float64                  `json:"judged_score"`
+	RawScore               float64                  `json:"raw_score"`
+	LatePenalty            float64                  `json:"late_penalty"`
+	OverrideReason         string                   `json:"override_reason"`
+	EarliestBestSubmitTime time.Time                `json:"earliest_best_submit_time"`
+	BestUQTID              uint                     `json:"-"`
+	Verdict                string                   `json:"verdict"`
+	CPUTimeMs              int64                    `json:"cpu_time_ms"`
+	MemoryKB               int64                    `json:"memory_kb"`
+	Metrics                []models.ExecutionMetric `json:"metrics" gorm:"-"`
+}
+
+// FormatExecutionMetrics formats per-target metrics as "target: VERDICT 12ms 345KB; ..."
+func FormatExecutionMetrics(metrics []models.ExecutionMetric) string {
+	parts := make([]string, 0, len(metrics))
+	for _, m := range metrics {
+		parts = append(parts, fmt.Sprintf("%s: %s %dms %dKB", m.Target, m.Verdict, m.CPUTimeMs, m.MemoryKB))
+	}
+	return strings.Join(parts, "; ")
 }
 
 // ExportQuestionScoreToXLSX generates and sends an XLSX file with question scores
@@ -28,7 +48,7 @@ func ExportQuestionScoreToXLSX(c *gin.Context, questionID uint, scores []ExportQ
 	}
 
 	// Set headers
-	headers := []string{"User Name", "Git Repository URL", "Score", "Earliest Best Submit Time"}
+	headers := []string{"User Name", "Git Repository URL", "Score", "Judged Score", "Raw Score", "Late Penalty (%)", "Override Reason", "Earliest Best Submit Time", "Verdict", "CPU Time (ms)", "Peak Memory (KB)", "Targets"}
 	for i, header := range headers {
 		cell := fmt.Sprintf("%c1", 'A'+i)
 		f.SetCellValue(sheetName, cell, header)
@@ -40,7 +60,15 @@ func ExportQuestionScoreToXLSX(c *gin.Context, questionID uint, scores []ExportQ
 		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), score.UserName)
 		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), score.GitUserRepoURL)
 		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), score.Score)
-		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), score.EarliestBestSubmitTime.Format("2006-01-02 15:04:05"))
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), score.JudgedScore)
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), score.RawScore)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), score.LatePenalty)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), score.OverrideReason)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), score.EarliestBestSubmitTime.Format("2006-01-02 15:04:05"))
+		f.SetCellValue(sheetName, fmt.Sprintf("I%d", row), score.Verdict)
+		f.SetCellValue(sheetName, fmt.Sprintf("J%d", row), score.CPUTimeMs)
+		f.SetCellValue(sheetName, fmt.Sprintf("K%d", row), score.MemoryKB)
+		f.SetCellValue(sheetName, fmt.Sprintf("L%d", row), FormatExecutionMetrics(score.Metrics))
 	}
 
 	// Set active sheet
@@ -54,3 +82,74 @@ func ExportQuestionScoreToXLSX(c *gin.Context, questionID uint, scores []ExportQ
 	// Write to response
 	return f.Write(c.Writer)
 }
+
+type RejudgeTestSuiteDelta struct {
+	Name     string  `json:"name"`
+	OldScore float64 `json:"old_score"`
+	NewScore float64 `json:"new_score"`
+	Delta    float64 `json:"delta"`
+}
+
+type RejudgeReportRow struct {
+	UserName       string                  `json:"user_name"`
+	GitUserRepoURL string                  `json:"git_user_repo_url"`
+	Commit         string                  `json:"commit"`
+	OldUQTID       uint                    `json:"old_uqt_id"`
+	NewUQTID       uint                    `json:"new_uqt_id"`
+	OldScore       float64                 `json:"old_score"`
+	NewScore       float64                 `json:"new_score"`
+	Delta          float64                 `json:"delta"`
+	Verdict        string                  `json:"verdict"`
+	TestSuites     []RejudgeTestSuiteDelta `json:"test_suites" gorm:"-"`
+	OldMessage     string                  `json:"-"`
+	NewMessage     string                  `json:"-"`
+}
+
+// FormatTestSuiteDeltas formats the changed test suites as "suite: 10 -> 5 (-5); ..."
+func FormatTestSuiteDeltas(deltas []RejudgeTestSuiteDelta) string {
+	parts := make([]string, 0, len(deltas))
+	for _, d := range deltas {
+		if d.Delta != 0 {
+			parts = append(parts, fmt.Sprintf("%s: %g -> %g (%+g)", d.Name, d.OldScore, d.NewScore, d.Delta))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ExportRejudgeReportToXLSX generates and sends an XLSX file with the score changes of a rejudge batch
+func ExportRejudgeReportToXLSX(c *gin.Context, batchID uint, rows []RejudgeReportRow) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheetName := "Rejudge Report"
+	index, err := f.NewSheet(sheetName)
+	if err != nil {
+		return err
+	}
+
+	headers := []string{"User Name", "Git Repository URL", "Commit", "Old Score", "New Score", "Delta", "Verdict", "Test Suite Changes"}
+	for i, header := range headers {
+		cell := fmt.Sprintf("%c1", 'A'+i)
+		f.SetCellValue(sheetName, cell, header)
+	}
+
+	for i, r := range rows {
+		row := i + 2
+		f.SetCellValue(sheetName, fmt.Sprintf("A%d", row), r.UserName)
+		f.SetCellValue(sheetName, fmt.Sprintf("B%d", row), r.GitUserRepoURL)
+		f.SetCellValue(sheetName, fmt.Sprintf("C%d", row), r.Commit)
+		f.SetCellValue(sheetName, fmt.Sprintf("D%d", row), r.OldScore)
+		f.SetCellValue(sheetName, fmt.Sprintf("E%d", row), r.NewScore)
+		f.SetCellValue(sheetName, fmt.Sprintf("F%d", row), r.Delta)
+		f.SetCellValue(sheetName, fmt.Sprintf("G%d", row), r.Verdict)
+		f.SetCellValue(sheetName, fmt.Sprintf("H%d", row), FormatTestSuiteDeltas(r.TestSuites))
+	}
+
+	f.SetActiveSheet(index)
+
+	filename := fmt.Sprintf("rejudge_%d_report_%s.xlsx", batchID, time.Now().Format("20060102_150405"))
+	c.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+
+	return f.Write(c.Writer)
+}